@@ -0,0 +1,406 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pb33f/doctor/terminal"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+)
+
+const blamePathsPrefix = "$.paths['"
+
+// operationMethods lists the HTTP methods of a path item, in the order they are rendered.
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+func operationMethodRank(method string) int {
+	if method == "" {
+		return -1
+	}
+	for i, m := range operationMethods {
+		if m == method {
+			return i
+		}
+	}
+	return len(operationMethods)
+}
+
+func isOperationMethod(name string) bool {
+	rank := operationMethodRank(name)
+	return rank >= 0 && rank < len(operationMethods)
+}
+
+// operationForChange resolves the path item and HTTP method a change applies to.
+// location is the remainder of the JSONPath beneath the operation (or path item).
+// ok is false for changes outside of $.paths.
+func operationForChange(change *model.HashedChange) (pathKey, method, location string, ok bool) {
+	changeJSONPath := changePath(change)
+	property := changeProperty(change)
+
+	if changeJSONPath == "$.paths" {
+		if property == "" {
+			return "", "", "", false
+		}
+		return property, "", "", true
+	}
+	if !strings.HasPrefix(changeJSONPath, blamePathsPrefix) {
+		return "", "", "", false
+	}
+
+	rest := changeJSONPath[len(blamePathsPrefix):]
+	end := closingQuotedKeyIndex(rest)
+	if end < 0 {
+		return "", "", "", false
+	}
+	pathKey = strings.ReplaceAll(rest[:end], "\\'", "'")
+	rest = rest[end+len("']"):]
+
+	if rest == "" {
+		if isOperationMethod(property) {
+			return pathKey, property, "", true
+		}
+		return pathKey, "", "", true
+	}
+	if rest[0] == '.' {
+		segment := rest[1:]
+		name, remainder := segment, ""
+		if idx := strings.IndexAny(segment, ".["); idx >= 0 {
+			name, remainder = segment[:idx], strings.TrimPrefix(segment[idx:], ".")
+		}
+		if isOperationMethod(name) {
+			return pathKey, name, remainder, true
+		}
+	}
+	return pathKey, "", strings.TrimPrefix(rest, "."), true
+}
+
+// closingQuotedKeyIndex returns the index of the unescaped "']" that closes a
+// single-quoted JSONPath key, or -1 when there is none.
+func closingQuotedKeyIndex(s string) int {
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == '\'' && s[i+1] == ']' {
+			return i
+		}
+	}
+	return -1
+}
+
+type operationKey struct {
+	path   string
+	method string
+}
+
+// buildBlameReport regroups a historical report by operation. Changes keep the
+// newest-first order of the history. Changes to components are attributed to the
+// operations that use them according to usage, which is keyed by commit hash;
+// changes to components no operation uses are listed by component. When
+// pathFilter is set, only changes to that path item are included.
+func buildBlameReport(history *model.FlatHistoricalReport, usage map[string]componentUsage, pathFilter string) *model.BlameReport {
+	report := &model.BlameReport{
+		DateGenerated: time.Now().Format(time.RFC3339),
+		Operations:    []*model.OperationBlame{},
+	}
+	if history == nil {
+		return report
+	}
+	report.GitRepoPath = history.GitRepoPath
+	report.GitFilePath = history.GitFilePath
+	report.MetaData = history.MetaData
+
+	operations := make(map[operationKey]*model.OperationBlame)
+	addToOperation := func(key operationKey, entry *model.BlameEntry) {
+		if pathFilter != "" && key.path != pathFilter {
+			return
+		}
+		operation := operations[key]
+		if operation == nil {
+			operation = &model.OperationBlame{Path: key.path, Method: key.method}
+			operations[key] = operation
+			report.Operations = append(report.Operations, operation)
+		}
+		operation.Changes = append(operation.Changes, entry)
+	}
+	components := make(map[componentKey]*model.ComponentBlame)
+
+	for _, flat := range history.Reports {
+		if flat == nil {
+			continue
+		}
+		commit := flat.Commit
+		if commit == nil {
+			commit = &model.Commit{}
+		}
+		for _, change := range flat.Changes {
+			entry := &model.BlameEntry{
+				Hash:        commit.Hash,
+				Message:     commit.Message,
+				Author:      commit.Author,
+				AuthorEmail: commit.AuthorEmail,
+				CommitDate:  commit.CommitDate,
+				Change:      change,
+			}
+			if pathKey, method, _, ok := operationForChange(change); ok {
+				addToOperation(operationKey{path: pathKey, method: method}, entry)
+				continue
+			}
+			component, ok := componentForChange(change)
+			if !ok {
+				continue
+			}
+			entry.Component = string(component)
+			users := usage[commit.Hash][component]
+			for i, key := range users {
+				if i > 0 {
+					copied := *entry
+					entry = &copied
+				}
+				addToOperation(key, entry)
+			}
+			if len(users) > 0 || pathFilter != "" {
+				continue
+			}
+			unused := components[component]
+			if unused == nil {
+				unused = &model.ComponentBlame{Component: string(component)}
+				components[component] = unused
+				report.Components = append(report.Components, unused)
+			}
+			unused.Changes = append(unused.Changes, entry)
+		}
+	}
+
+	sort.SliceStable(report.Operations, func(i, j int) bool {
+		left, right := report.Operations[i], report.Operations[j]
+		if left.Path != right.Path {
+			return left.Path < right.Path
+		}
+		return operationMethodRank(left.Method) < operationMethodRank(right.Method)
+	})
+	sort.SliceStable(report.Components, func(i, j int) bool {
+		return report.Components[i].Component < report.Components[j].Component
+	})
+	return report
+}
+
+// loadBlameHistory builds the historical report of a local git or GitHub file
+// history, along with the component usage of each commit, keyed by commit hash.
+// A commit's usage covers the revisions on both sides of its comparison.
+func loadBlameHistory(args []string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig,
+) (*model.FlatHistoricalReport, map[string]componentUsage, error) {
+	var repoPath, filePath string
+	var loaded *loadedHistoryResult
+	var err error
+	if len(args) == 1 {
+		if repoPath, filePath, err = githubHistoryLocation(args[0]); err != nil {
+			return nil, nil, err
+		}
+		loaded, err = loadGitHubCommitsDetailed(args[0], opts, breakingConfig)
+	} else {
+		repoPath, filePath = args[0], args[1]
+		loaded, err = loadGitHistoryCommitsDetailed(args[0], args[1], opts, breakingConfig)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage := make(map[string]componentUsage)
+	if loaded != nil {
+		for _, commit := range loaded.Commits {
+			if commit != nil && commit.Document != nil && commit.OldDocument != nil {
+				usage[commit.Hash] = componentUsageFor(commit.OldData, commit.Data)
+			}
+		}
+	}
+	history, err := buildHistoricalReport(repoPath, filePath, loaded, breakingConfig, opts.rules, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return history, usage, nil
+}
+
+func operationLabel(operation *model.OperationBlame) string {
+	if operation.Method == "" {
+		return operation.Path
+	}
+	return strings.ToUpper(operation.Method) + " " + operation.Path
+}
+
+// blameChangeLocation describes where a change sits beneath its operation,
+// without repeating the path or method already shown in the operation heading.
+func blameChangeLocation(change *model.HashedChange, operation *model.OperationBlame) string {
+	_, _, location, _ := operationForChange(change)
+	property := changeProperty(change)
+	if changePath(change) == "$.paths" || (location == "" && property == operation.Method) {
+		return location
+	}
+	return strings.TrimSpace(location + " " + property)
+}
+
+// blameComponentChangeLocation describes where a change sits in the components.
+func blameComponentChangeLocation(change *model.HashedChange) string {
+	return strings.TrimSpace(strings.TrimPrefix(changePath(change), "$.") + " " + changeProperty(change))
+}
+
+// renderBlame renders a blame report as terminal text.
+func renderBlame(report *model.BlameReport, pathFilter string, styles summaryStyles) string {
+	var sb strings.Builder
+
+	sb.WriteString(styles.title.Render(fmt.Sprintf("Blame: %s (%s)", report.GitFilePath, report.GitRepoPath)))
+	sb.WriteString("\n\n")
+
+	if len(report.Operations) == 0 && len(report.Components) == 0 {
+		if pathFilter != "" {
+			sb.WriteString(styles.stat.Render(fmt.Sprintf("No changes found for path '%s'", pathFilter)))
+		} else {
+			sb.WriteString(styles.stat.Render("No changes found to any operations"))
+		}
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	for _, operation := range report.Operations {
+		renderBlameEntries(&sb, operationLabel(operation), operation.Changes, operation, styles)
+	}
+	if len(report.Components) > 0 {
+		sb.WriteString(styles.stat.Render("Components not used by any operation"))
+		sb.WriteString("\n\n")
+		for _, component := range report.Components {
+			renderBlameEntries(&sb, component.Component, component.Changes, nil, styles)
+		}
+	}
+	return sb.String()
+}
+
+// renderBlameEntries renders the changes of one operation, or of one component
+// when operation is nil, under a heading.
+func renderBlameEntries(sb *strings.Builder, heading string, entries []*model.BlameEntry, operation *model.OperationBlame,
+	styles summaryStyles,
+) {
+	breaking := 0
+	for _, entry := range entries {
+		if entry.Change != nil && entry.Change.Change != nil && entry.Change.Breaking {
+			breaking++
+		}
+	}
+	sb.WriteString(styles.title.Render(heading))
+	sb.WriteString(styles.stat.Render(fmt.Sprintf(" (%d changes, %d breaking)", len(entries), breaking)))
+	sb.WriteString("\n")
+
+	for _, entry := range entries {
+		var location string
+		if entry.Component != "" || operation == nil {
+			location = blameComponentChangeLocation(entry.Change)
+		} else {
+			location = blameChangeLocation(entry.Change, operation)
+		}
+		line := fmt.Sprintf("  - %s %s %s: ", shortCommitHash(entry.Hash), entry.CommitDate.Format("2006-01-02"), entry.Author)
+		sb.WriteString(styles.stat.Render(line))
+		if location != "" {
+			sb.WriteString(styles.detail.Render(location) + " ")
+		}
+		sb.WriteString(describeHashedChange(entry.Change))
+		if entry.Change != nil && entry.Change.Change != nil && entry.Change.Breaking {
+			sb.WriteString(" " + styles.breaking.Render("(breaking)"))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+func printBlameUsage(palette terminal.Palette) {
+	title := styleWithForeground(palette.Primary).Bold(true)
+	desc := styleWithForeground(palette.Muted)
+	cmdStyle := styleWithForeground(palette.Secondary).Bold(true)
+
+	fmt.Print(title.Render("How to use the "))
+	fmt.Print(cmdStyle.Render("blame"))
+	fmt.Println(title.Render(" command:"))
+	fmt.Println()
+	fmt.Println(desc.Render("The blame command walks the git history of a specification and lists every change\nthat touched each operation, along with the commit, author and date that made it."))
+	fmt.Println()
+	fmt.Println("  openapi-changes blame [options] <git-repo> <file-path>")
+	fmt.Println("  openapi-changes blame [options] <github-file-url>")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes blame ./ api/openapi.yaml"))
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes blame --path '/orders/{id}' ./ api/openapi.yaml"))
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes blame https://github.com/user/repo/blob/main/openapi.yaml"))
+	fmt.Println()
+	fmt.Println("Use --help for full flag details.")
+}
+
+// GetBlameCommand returns the cobra command for per-operation change history.
+func GetBlameCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "blame",
+		Short:        "Show the history of changes for each operation",
+		Long: "Walk the git history of a specification and list every change that touched each operation " +
+			"(or a single path with --path), along with the commit, author and date that made it. " +
+			"Changes to components are listed under the operations that reference them.",
+		Example: "openapi-changes blame --path '/orders/{id}' ./ api/openapi.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, configFlag, err := readCommonFlags(cmd)
+			if err != nil {
+				return err
+			}
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			pathFilter, _ := cmd.Flags().GetString("path")
+			if !jsonOutput {
+				maybePrintBanner(cmd, opts.palette)
+			}
+
+			if len(args) == 0 {
+				printBlameUsage(opts.palette)
+				return nil
+			}
+			if len(args) > 2 {
+				return fmt.Errorf("too many arguments provided, expecting at most two (2)")
+			}
+
+			if len(args) == 1 {
+				if err := validateGitHubURL(args[0]); err != nil {
+					return err
+				}
+			} else if f, statErr := os.Stat(args[0]); statErr != nil || !f.IsDir() {
+				return fmt.Errorf("blame requires a git repository and a file path, or a GitHub file URL")
+			}
+			breakingConfig, err := LoadBreakingRulesConfig(configFlag)
+			if err != nil {
+				PrintConfigError(err, opts.palette)
+				return err
+			}
+			history, usage, err := loadBlameHistory(args, opts, breakingConfig)
+			if err != nil {
+				return err
+			}
+
+			report := buildBlameReport(history, usage, pathFilter)
+			if history == nil && len(args) == 2 {
+				report.GitRepoPath = args[0]
+				report.GitFilePath = args[1]
+			}
+			if jsonOutput {
+				return printReportJSON(report)
+			}
+			fmt.Print(renderBlame(report, pathFilter, summaryStylesForPalette(opts.palette)))
+			return nil
+		},
+	}
+	addTerminalThemeFlags(cmd)
+	cmd.Flags().String("path", "", "Only show changes to a single path, e.g. '/orders/{id}'")
+	cmd.Flags().Bool("json", false, "Print the blame report as JSON")
+	return cmd
+}
@@ -0,0 +1,196 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pb33f/openapi-changes/model"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	blameComponentsPrefix = "$.components."
+	componentRefPrefix    = "#/components/"
+)
+
+// componentKey identifies a reusable component by its section and name, such as
+// "schemas/Order".
+type componentKey string
+
+// componentUsage maps the components of a specification to the operations that
+// use them, directly or through other components.
+type componentUsage map[componentKey][]operationKey
+
+// componentUsageFor indexes which operations use which components in one or more
+// revisions of a specification. A component used by an operation in any of the
+// revisions is attributed to it, so that a removal can be traced through the
+// revision before it as well as the one after. Only local references are followed.
+func componentUsageFor(specs ...[]byte) componentUsage {
+	users := make(map[componentKey]map[operationKey]struct{})
+	for _, spec := range specs {
+		if len(spec) == 0 {
+			continue
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(spec, &doc); err != nil || len(doc.Content) == 0 {
+			continue
+		}
+		root := doc.Content[0]
+
+		references := make(map[componentKey][]componentKey)
+		forEachMappingEntry(mappingValue(root, "components"), func(section string, components *yaml.Node) {
+			forEachMappingEntry(components, func(name string, component *yaml.Node) {
+				references[componentKey(section+"/"+name)] = collectComponentRefs(component, nil)
+			})
+		})
+
+		forEachMappingEntry(mappingValue(root, "paths"), func(pathKey string, pathItem *yaml.Node) {
+			forEachMappingEntry(pathItem, func(name string, node *yaml.Node) {
+				operation := operationKey{path: pathKey}
+				if isOperationMethod(name) {
+					operation.method = name
+				}
+				for component := range reachableComponents(collectComponentRefs(node, nil), references) {
+					if users[component] == nil {
+						users[component] = make(map[operationKey]struct{})
+					}
+					users[component][operation] = struct{}{}
+				}
+			})
+		})
+	}
+
+	usage := make(componentUsage, len(users))
+	for component, operations := range users {
+		for operation := range operations {
+			usage[component] = append(usage[component], operation)
+		}
+		sort.Slice(usage[component], func(i, j int) bool {
+			left, right := usage[component][i], usage[component][j]
+			if left.path != right.path {
+				return left.path < right.path
+			}
+			return operationMethodRank(left.method) < operationMethodRank(right.method)
+		})
+	}
+	return usage
+}
+
+// reachableComponents follows component references from a set of direct
+// references to every component they lead to.
+func reachableComponents(direct []componentKey, references map[componentKey][]componentKey) map[componentKey]struct{} {
+	reached := make(map[componentKey]struct{})
+	pending := append([]componentKey(nil), direct...)
+	for len(pending) > 0 {
+		component := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if _, ok := reached[component]; ok {
+			continue
+		}
+		reached[component] = struct{}{}
+		pending = append(pending, references[component]...)
+	}
+	return reached
+}
+
+// collectComponentRefs appends every local component reference beneath node.
+func collectComponentRefs(node *yaml.Node, refs []componentKey) []componentKey {
+	if node == nil {
+		return refs
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+				if component, ok := componentForRef(value.Value); ok {
+					refs = append(refs, component)
+				}
+				continue
+			}
+			refs = collectComponentRefs(value, refs)
+		}
+		return refs
+	}
+	for _, child := range node.Content {
+		refs = collectComponentRefs(child, refs)
+	}
+	return refs
+}
+
+// componentForRef resolves a local reference such as "#/components/schemas/Order"
+// to the component it points at, including references into a component.
+func componentForRef(ref string) (componentKey, bool) {
+	if !strings.HasPrefix(ref, componentRefPrefix) {
+		return "", false
+	}
+	segments := strings.SplitN(ref[len(componentRefPrefix):], "/", 3)
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "", false
+	}
+	name := strings.NewReplacer("~1", "/", "~0", "~").Replace(segments[1])
+	return componentKey(segments[0] + "/" + name), true
+}
+
+// componentForChange resolves the component a change applies to. ok is false for
+// changes outside of $.components.
+func componentForChange(change *model.HashedChange) (componentKey, bool) {
+	changeJSONPath := changePath(change)
+	if !strings.HasPrefix(changeJSONPath, blameComponentsPrefix) {
+		return "", false
+	}
+	rest := changeJSONPath[len(blameComponentsPrefix):]
+	end := strings.IndexAny(rest, ".[")
+	if end < 0 {
+		// a whole component was added or removed
+		if property := changeProperty(change); rest != "" && property != "" {
+			return componentKey(rest + "/" + property), true
+		}
+		return "", false
+	}
+	section, rest := rest[:end], rest[end:]
+
+	var name string
+	switch {
+	case strings.HasPrefix(rest, "['"):
+		keyEnd := closingQuotedKeyIndex(rest[2:])
+		if keyEnd < 0 {
+			return "", false
+		}
+		name = strings.ReplaceAll(rest[2:2+keyEnd], "\\'", "'")
+	case strings.HasPrefix(rest, "."):
+		name = rest[1:]
+		if idx := strings.IndexAny(name, ".["); idx >= 0 {
+			name = name[:idx]
+		}
+	}
+	if section == "" || name == "" {
+		return "", false
+	}
+	return componentKey(section + "/" + name), true
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// forEachMappingEntry calls fn for each key and value of a mapping node.
+func forEachMappingEntry(node *yaml.Node, fn func(key string, value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(node.Content[i].Value, node.Content[i+1])
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationForChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		property string
+		pathKey  string
+		method   string
+		location string
+		ok       bool
+	}{
+		{"path added", "$.paths", "/orders/{id}", "/orders/{id}", "", "", true},
+		{"operation removed", "$.paths['/orders/{id}']", "get", "/orders/{id}", "get", "", true},
+		{"path item property", "$.paths['/orders/{id}']", "summary", "/orders/{id}", "", "", true},
+		{"nested operation change", "$.paths['/orders/{id}'].get.responses['200']", "description", "/orders/{id}", "get", "responses['200']", true},
+		{"operation property", "$.paths['/orders/{id}'].post", "summary", "/orders/{id}", "post", "", true},
+		{"path item parameters", "$.paths['/orders/{id}'].parameters['id']", "required", "/orders/{id}", "", "parameters['id']", true},
+		{"escaped quote in key", "$.paths['/it\\'s'].get", "summary", "/it's", "get", "", true},
+		{"outside paths", "$.components.schemas['Order']", "type", "", "", "", false},
+		{"document root", "$.paths", "", "", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := mergeTestChange(tt.path, tt.property, wcModel.Modified, "", "", false)
+			pathKey, method, location, ok := operationForChange(change)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.pathKey, pathKey)
+			assert.Equal(t, tt.method, method)
+			assert.Equal(t, tt.location, location)
+		})
+	}
+}
+
+func blameTestHistory() *model.FlatHistoricalReport {
+	now := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	return &model.FlatHistoricalReport{
+		GitRepoPath: "./",
+		GitFilePath: "api/openapi.yaml",
+		Reports: []*model.FlatReport{
+			{
+				Commit: &model.Commit{Hash: "cccccccccccc", Author: "carol", CommitDate: now},
+				Changes: []*model.HashedChange{
+					mergeTestChange("$.paths['/orders/{id}'].get.responses['200']", "description", wcModel.Modified, "ok", "order", false),
+					mergeTestChange("$.components.schemas['Order']", "type", wcModel.Modified, "object", "string", true),
+				},
+			},
+			{
+				Commit: &model.Commit{Hash: "bbbbbbbbbbbb", Author: "bob", CommitDate: now.Add(-24 * time.Hour)},
+				Changes: []*model.HashedChange{
+					mergeTestChange("$.paths['/orders/{id}']", "delete", wcModel.ObjectRemoved, "delete", "", true),
+					mergeTestChange("$.paths['/orders/{id}'].get", "summary", wcModel.Modified, "a", "b", false),
+					mergeTestChange("$.paths", "/pets", wcModel.ObjectAdded, "", "/pets", false),
+				},
+			},
+		},
+	}
+}
+
+func TestBuildBlameReport_GroupsByOperation(t *testing.T) {
+	report := buildBlameReport(blameTestHistory(), nil, "")
+	require.Len(t, report.Operations, 3)
+
+	assert.Equal(t, "/orders/{id}", report.Operations[0].Path)
+	assert.Equal(t, "get", report.Operations[0].Method)
+	require.Len(t, report.Operations[0].Changes, 2)
+	assert.Equal(t, "cccccccccccc", report.Operations[0].Changes[0].Hash)
+	assert.Equal(t, "carol", report.Operations[0].Changes[0].Author)
+	assert.Equal(t, "bbbbbbbbbbbb", report.Operations[0].Changes[1].Hash)
+
+	assert.Equal(t, "/orders/{id}", report.Operations[1].Path)
+	assert.Equal(t, "delete", report.Operations[1].Method)
+	require.Len(t, report.Operations[1].Changes, 1)
+
+	assert.Equal(t, "/pets", report.Operations[2].Path)
+	assert.Empty(t, report.Operations[2].Method)
+
+	require.Len(t, report.Components, 1, "a component without known users is listed on its own")
+	assert.Equal(t, "schemas/Order", report.Components[0].Component)
+	require.Len(t, report.Components[0].Changes, 1)
+	assert.Equal(t, "cccccccccccc", report.Components[0].Changes[0].Hash)
+}
+
+const blameComponentsSpec = `openapi: 3.1.0
+info:
+  title: orders
+  version: 1.0.0
+paths:
+  /orders/{id}:
+    parameters:
+      - $ref: '#/components/parameters/OrderId'
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Order'
+    delete:
+      responses:
+        '204':
+          description: deleted
+  /customers:
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Customer'
+components:
+  parameters:
+    OrderId:
+      name: id
+      in: path
+  requestBodies:
+    Customer:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Customer'
+  schemas:
+    Order:
+      properties:
+        customer:
+          $ref: '#/components/schemas/Customer/properties/name'
+    Customer:
+      properties:
+        name:
+          type: string
+    a/b:
+      type: string
+    Unused:
+      type: string
+`
+
+func TestComponentUsageFor(t *testing.T) {
+	usage := componentUsageFor([]byte(blameComponentsSpec))
+
+	assert.Equal(t, []operationKey{{path: "/orders/{id}", method: "get"}}, usage["schemas/Order"])
+	assert.Equal(t, []operationKey{{path: "/customers", method: "post"}, {path: "/orders/{id}", method: "get"}},
+		usage["schemas/Customer"], "references are followed through other components")
+	assert.Equal(t, []operationKey{{path: "/customers", method: "post"}}, usage["requestBodies/Customer"])
+	assert.Equal(t, []operationKey{{path: "/orders/{id}"}}, usage["parameters/OrderId"])
+	assert.Empty(t, usage["schemas/Unused"])
+
+	older := strings.Replace(blameComponentsSpec, "'204':", "'204':\n          $ref: '#/components/responses/Gone'", 1)
+	usage = componentUsageFor([]byte(older), []byte(blameComponentsSpec), nil, []byte("not: [yaml"))
+	assert.Equal(t, []operationKey{{path: "/orders/{id}", method: "delete"}}, usage["responses/Gone"],
+		"a component used in either revision is attributed")
+}
+
+func TestComponentForChange(t *testing.T) {
+	tests := []struct {
+		path      string
+		property  string
+		component componentKey
+		ok        bool
+	}{
+		{"$.components.schemas['Order']", "type", "schemas/Order", true},
+		{"$.components.schemas['Order'].properties['id']", "type", "schemas/Order", true},
+		{"$.components.schemas", "Order", "schemas/Order", true},
+		{"$.components.schemas.Order.properties", "id", "schemas/Order", true},
+		{"$.components.schemas['a/b']", "type", "schemas/a/b", true},
+		{"$.components", "schemas", "", false},
+		{"$.paths['/orders']", "get", "", false},
+	}
+	for _, tt := range tests {
+		component, ok := componentForChange(mergeTestChange(tt.path, tt.property, wcModel.Modified, "", "", false))
+		assert.Equal(t, tt.ok, ok, tt.path)
+		assert.Equal(t, tt.component, component, tt.path)
+	}
+
+	component, ok := componentForRef("#/components/schemas/a~1b/properties/name")
+	assert.True(t, ok)
+	assert.Equal(t, componentKey("schemas/a/b"), component)
+}
+
+func TestBuildBlameReport_AttributesComponentChanges(t *testing.T) {
+	usage := map[string]componentUsage{
+		"cccccccccccc": componentUsageFor([]byte(blameComponentsSpec)),
+	}
+	report := buildBlameReport(blameTestHistory(), usage, "/orders/{id}")
+	require.Len(t, report.Operations, 2)
+	get := report.Operations[0]
+	assert.Equal(t, "get", get.Method)
+	require.Len(t, get.Changes, 3)
+	assert.Equal(t, "schemas/Order", get.Changes[1].Component)
+	assert.Equal(t, "cccccccccccc", get.Changes[1].Hash)
+	assert.Empty(t, report.Components)
+
+	output := renderBlame(report, "/orders/{id}", summaryStyles{})
+	assert.Contains(t, output, "GET /orders/{id} (3 changes, 1 breaking)")
+	assert.Contains(t, output, `- ccccccc 2026-03-04 carol: components.schemas['Order'] type modified "object" -> "string" (breaking)`)
+
+	unfiltered := renderBlame(buildBlameReport(blameTestHistory(), nil, ""), "", summaryStyles{})
+	assert.Contains(t, unfiltered, "Components not used by any operation")
+	assert.Contains(t, unfiltered, "schemas/Order (1 changes, 1 breaking)")
+}
+
+func TestBuildBlameReport_PathFilter(t *testing.T) {
+	report := buildBlameReport(blameTestHistory(), nil, "/pets")
+	require.Len(t, report.Operations, 1)
+	assert.Equal(t, "/pets", report.Operations[0].Path)
+
+	assert.Empty(t, buildBlameReport(blameTestHistory(), nil, "/missing").Operations)
+	assert.Empty(t, buildBlameReport(nil, nil, "").Operations)
+}
+
+func TestRenderBlame(t *testing.T) {
+	report := buildBlameReport(blameTestHistory(), nil, "/orders/{id}")
+
+	output := renderBlame(report, "/orders/{id}", summaryStyles{})
+	assert.Contains(t, output, "Blame: api/openapi.yaml (./)")
+	assert.Contains(t, output, "GET /orders/{id} (2 changes, 0 breaking)")
+	assert.Contains(t, output, `- ccccccc 2026-03-04 carol: responses['200'] description modified "ok" -> "order"`)
+	assert.Contains(t, output, `- bbbbbbb 2026-03-03 bob: summary modified "a" -> "b"`)
+	assert.Contains(t, output, "DELETE /orders/{id} (1 changes, 1 breaking)")
+	assert.Contains(t, output, `- bbbbbbb 2026-03-03 bob: removed "delete" (breaking)`)
+	assert.NotContains(t, output, "/pets")
+
+	empty := renderBlame(buildBlameReport(blameTestHistory(), nil, "/missing"), "/missing", summaryStyles{})
+	assert.Contains(t, empty, "No changes found for path '/missing'")
+}
+
+func TestBlameCommand_RequiresRepository(t *testing.T) {
+	cmd := testRootCmd(GetBlameCommand(), "--no-logo", "../sample-specs/petstorev3.json", "openapi.yaml")
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a git repository")
+}
@@ -0,0 +1,501 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pb33f/doctor/terminal"
+	"github.com/pb33f/openapi-changes/git"
+	"github.com/pb33f/openapi-changes/internal/changecounts"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	changelogVersionFromInfo   = "info"
+	changelogVersionFromTag    = "tag"
+	changelogVersionFromCommit = "commit"
+
+	changelogUnreleased = "Unreleased"
+
+	changelogSectionAdded      = "Added"
+	changelogSectionChanged    = "Changed"
+	changelogSectionDeprecated = "Deprecated"
+	changelogSectionRemoved    = "Removed"
+	changelogSectionBreaking   = "Breaking"
+)
+
+// changelogSections lists the sections of a version, in the order they are written.
+var changelogSections = []string{
+	changelogSectionAdded,
+	changelogSectionChanged,
+	changelogSectionDeprecated,
+	changelogSectionRemoved,
+	changelogSectionBreaking,
+}
+
+const changelogHeader = "# Changelog\n\n" +
+	"All notable changes to this API are documented in this file.\n\n" +
+	"The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).\n\n"
+
+var changelogVersionHeading = regexp.MustCompile(`^## \[([^\]]+)\](?: - (\d{4}-\d{2}-\d{2}))?`)
+
+// changelogVersion collects the changelog entries for a single released (or
+// unreleased) version of the specification. Versions read back from an existing
+// changelog may also carry text ahead of their sections, and sections of their
+// own, which are written out again after the standard ones.
+type changelogVersion struct {
+	name          string
+	date          time.Time
+	intro         []string
+	sections      map[string][]string
+	extraSections []string
+	seen          map[string]struct{}
+}
+
+func newChangelogVersion(name string, date time.Time) *changelogVersion {
+	return &changelogVersion{
+		name:     name,
+		date:     date,
+		sections: make(map[string][]string),
+		seen:     make(map[string]struct{}),
+	}
+}
+
+func (v *changelogVersion) add(section, entry string) {
+	key := section + "\x00" + entry
+	if _, ok := v.seen[key]; ok {
+		return
+	}
+	v.seen[key] = struct{}{}
+	v.sections[section] = append(v.sections[section], entry)
+}
+
+func (v *changelogVersion) empty() bool {
+	return len(v.seen) == 0
+}
+
+// changelogVersionLabeler names the version a commit was released in.
+type changelogVersionLabeler func(commit *model.Commit) (string, error)
+
+// infoVersionLabel uses info.version from the commit's copy of the specification,
+// falling back to the commit hash when the version cannot be read.
+func infoVersionLabel(commit *model.Commit) (string, error) {
+	var spec struct {
+		Info struct {
+			Version string `yaml:"version"`
+		} `yaml:"info"`
+	}
+	if err := yaml.Unmarshal(commit.Data, &spec); err == nil && spec.Info.Version != "" {
+		return spec.Info.Version, nil
+	}
+	return commitVersionLabel(commit)
+}
+
+func commitVersionLabel(commit *model.Commit) (string, error) {
+	return shortCommitHash(commit.Hash), nil
+}
+
+// tagVersionLabel uses the first release tag that contains the commit. Commits
+// that have not been tagged yet are unreleased.
+func tagVersionLabel(commit *model.Commit) (string, error) {
+	tag, err := git.ReleaseTagContaining(commit.RepoDirectory, commit.Hash)
+	if err != nil {
+		return "", err
+	}
+	if tag == "" {
+		return changelogUnreleased, nil
+	}
+	return tag, nil
+}
+
+func changelogLabelerFor(versionFrom string) (changelogVersionLabeler, error) {
+	switch versionFrom {
+	case changelogVersionFromInfo:
+		return infoVersionLabel, nil
+	case changelogVersionFromTag:
+		return tagVersionLabel, nil
+	case changelogVersionFromCommit:
+		return commitVersionLabel, nil
+	default:
+		return nil, fmt.Errorf("unknown --version-from value '%s' (expected %s, %s or %s)", versionFrom,
+			changelogVersionFromInfo, changelogVersionFromTag, changelogVersionFromCommit)
+	}
+}
+
+// changelogSectionFor decides which section a change is published under.
+func changelogSectionFor(change *model.HashedChange) string {
+	if change.Breaking {
+		return changelogSectionBreaking
+	}
+	if changeProperty(change) == "deprecated" && changeNew(change) == "true" {
+		return changelogSectionDeprecated
+	}
+	switch changecounts.CategoryOf(change.Change) {
+	case changecounts.CategoryAddition:
+		return changelogSectionAdded
+	case changecounts.CategoryRemoval:
+		return changelogSectionRemoved
+	default:
+		return changelogSectionChanged
+	}
+}
+
+// changelogEntry describes a change for API consumers, using the operation it
+// belongs to where there is one.
+func changelogEntry(change *model.HashedChange, section string) string {
+	subject := strings.TrimPrefix(changePath(change), "$.")
+	detail := changeProperty(change)
+	if pathKey, method, _, ok := operationForChange(change); ok {
+		operation := &model.OperationBlame{Path: pathKey, Method: method}
+		subject = operationLabel(operation)
+		detail = blameChangeLocation(change, operation)
+	}
+
+	entry := "`" + subject + "`"
+	if detail != "" {
+		entry += " " + detail
+	}
+	if section == changelogSectionChanged || section == changelogSectionBreaking {
+		entry += ": " + describeHashedChange(change)
+	}
+	return entry
+}
+
+// buildChangelogVersions groups a newest-first history into versions. The
+// returned versions keep the newest-first order.
+func buildChangelogVersions(reports []*model.FlatReport, label changelogVersionLabeler) ([]*changelogVersion, error) {
+	var versions []*changelogVersion
+	byName := make(map[string]*changelogVersion)
+	for _, report := range reports {
+		if report == nil || report.Commit == nil || len(report.Changes) == 0 {
+			continue
+		}
+		name, err := label(report.Commit)
+		if err != nil {
+			return nil, err
+		}
+		version := byName[name]
+		if version == nil {
+			version = newChangelogVersion(name, report.Commit.CommitDate)
+			byName[name] = version
+			versions = append(versions, version)
+		}
+		for _, change := range report.Changes {
+			if change == nil || change.Change == nil {
+				continue
+			}
+			section := changelogSectionFor(change)
+			version.add(section, changelogEntry(change, section))
+		}
+	}
+
+	nonEmpty := versions[:0]
+	for _, version := range versions {
+		if !version.empty() {
+			nonEmpty = append(nonEmpty, version)
+		}
+	}
+	return nonEmpty, nil
+}
+
+func renderChangelogVersion(version *changelogVersion) string {
+	var sb strings.Builder
+	if version.name == changelogUnreleased || version.date.IsZero() {
+		sb.WriteString(fmt.Sprintf("## [%s]\n\n", version.name))
+	} else {
+		sb.WriteString(fmt.Sprintf("## [%s] - %s\n\n", version.name, version.date.Format("2006-01-02")))
+	}
+	if len(version.intro) > 0 {
+		sb.WriteString(strings.Join(version.intro, "\n") + "\n\n")
+	}
+	for _, section := range append(slices.Clone(changelogSections), version.extraSections...) {
+		entries := version.sections[section]
+		if len(entries) == 0 {
+			continue
+		}
+		sb.WriteString("### " + section + "\n\n")
+		for _, entry := range entries {
+			sb.WriteString("- " + entry + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// changelogBlock is a "## " section of an existing changelog, or the text ahead
+// of the first one. name and date are set for version headings.
+type changelogBlock struct {
+	name  string
+	date  string
+	lines []string
+}
+
+func parseChangelogBlocks(existing string) []*changelogBlock {
+	blocks := []*changelogBlock{{}}
+	for _, line := range strings.SplitAfter(existing, "\n") {
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "## ") {
+			block := &changelogBlock{}
+			if match := changelogVersionHeading.FindStringSubmatch(line); match != nil {
+				block.name, block.date = match[1], match[2]
+			}
+			blocks = append(blocks, block)
+		}
+		current := blocks[len(blocks)-1]
+		current.lines = append(current.lines, line)
+	}
+	return blocks
+}
+
+// readChangelogVersion reads the entries of a version back from its block.
+// Lines that do not start an entry continue the one before them.
+func readChangelogVersion(block *changelogBlock) *changelogVersion {
+	version := newChangelogVersion(block.name, time.Time{})
+	section := ""
+	for _, line := range block.lines[1:] {
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "### "):
+			section = strings.TrimSpace(line[len("### "):])
+			if !slices.Contains(changelogSections, section) && !slices.Contains(version.extraSections, section) {
+				version.extraSections = append(version.extraSections, section)
+			}
+		case strings.TrimSpace(line) == "":
+		case section == "":
+			version.intro = append(version.intro, line)
+		case strings.HasPrefix(line, "- "):
+			version.add(section, line[len("- "):])
+		default:
+			if entries := version.sections[section]; len(entries) > 0 {
+				entries[len(entries)-1] += "\n" + line
+			}
+		}
+	}
+	return version
+}
+
+// mergeChangelogVersion adds the generated entries that a written version does not
+// have yet ahead of its own, keeping everything that was already written.
+func mergeChangelogVersion(written, generated *changelogVersion) *changelogVersion {
+	merged := newChangelogVersion(generated.name, generated.date)
+	merged.intro = written.intro
+	merged.extraSections = written.extraSections
+	for _, section := range append(slices.Clone(changelogSections), written.extraSections...) {
+		for _, entry := range generated.sections[section] {
+			if _, ok := written.seen[section+"\x00"+entry]; !ok {
+				merged.add(section, entry)
+			}
+		}
+		for _, entry := range written.sections[section] {
+			merged.add(section, entry)
+		}
+	}
+	return merged
+}
+
+// mergeChangelog prepends versions to an existing changelog and returns the
+// merged document and the number of versions that were added.
+//
+// Versions that are already written are left alone, with two exceptions. The
+// unreleased section is replaced when an unreleased version was generated (and
+// kept as it is otherwise). The newest written version takes in the entries of
+// commits that landed under it after the date in its heading, for a version
+// whose info.version was not bumped yet; its existing entries are kept.
+func mergeChangelog(existing string, versions []*changelogVersion) (string, int) {
+	if strings.TrimSpace(existing) == "" {
+		var sb strings.Builder
+		sb.WriteString(changelogHeader)
+		for _, version := range versions {
+			sb.WriteString(renderChangelogVersion(version))
+		}
+		return sb.String(), len(versions)
+	}
+
+	blocks := parseChangelogBlocks(existing)
+	generated := make(map[string]*changelogVersion, len(versions))
+	for _, version := range versions {
+		generated[version.name] = version
+	}
+	written := make(map[string]struct{})
+	var newest *changelogBlock
+	for _, block := range blocks[1:] {
+		if block.name == "" {
+			continue
+		}
+		written[block.name] = struct{}{}
+		if newest == nil && block.name != changelogUnreleased {
+			newest = block
+		}
+	}
+
+	var added strings.Builder
+	count := 0
+	for _, version := range versions {
+		if _, ok := written[version.name]; ok && version.name != changelogUnreleased {
+			continue
+		}
+		added.WriteString(renderChangelogVersion(version))
+		count++
+	}
+
+	var sb strings.Builder
+	preamble := strings.Join(blocks[0].lines, "")
+	if len(blocks) == 1 && !strings.HasSuffix(preamble, "\n\n") {
+		preamble = strings.TrimRight(preamble, "\n") + "\n\n"
+	}
+	sb.WriteString(preamble)
+	rest := blocks[1:]
+	if len(rest) > 0 && rest[0].name == changelogUnreleased && generated[changelogUnreleased] == nil {
+		sb.WriteString(strings.Join(rest[0].lines, ""))
+		rest = rest[1:]
+	}
+	sb.WriteString(added.String())
+	for _, block := range rest {
+		switch {
+		case block.name == changelogUnreleased && generated[changelogUnreleased] != nil:
+			continue
+		case block == newest && isChangelogVersionNewer(generated[block.name], block.date):
+			sb.WriteString(renderChangelogVersion(mergeChangelogVersion(readChangelogVersion(block), generated[block.name])))
+		default:
+			sb.WriteString(strings.Join(block.lines, ""))
+		}
+	}
+	return sb.String(), count
+}
+
+// isChangelogVersionNewer reports whether a generated version has commits from a
+// later day than the date in the heading of its written version.
+func isChangelogVersionNewer(version *changelogVersion, writtenDate string) bool {
+	if version == nil || version.date.IsZero() || writtenDate == "" {
+		return false
+	}
+	return version.date.Format("2006-01-02") > writtenDate
+}
+
+func printChangelogUsage(palette terminal.Palette) {
+	title := styleWithForeground(palette.Primary).Bold(true)
+	desc := styleWithForeground(palette.Muted)
+	cmdStyle := styleWithForeground(palette.Secondary).Bold(true)
+
+	fmt.Print(title.Render("How to use the "))
+	fmt.Print(cmdStyle.Render("changelog"))
+	fmt.Println(title.Render(" command:"))
+	fmt.Println()
+	fmt.Println(desc.Render("The changelog command turns the git history of a specification into a Keep a Changelog\nstyle CHANGELOG.md, with Added, Changed, Deprecated, Removed and Breaking sections per version."))
+	fmt.Println()
+	fmt.Println("  openapi-changes changelog [options] <git-repo> <file-path>")
+	fmt.Println("  openapi-changes changelog [options] <github-file-url>")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes changelog ./ api/openapi.yaml"))
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes changelog --version-from tag --limit 50 ./ api/openapi.yaml"))
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes changelog https://github.com/user/repo/blob/main/openapi.yaml"))
+	fmt.Println()
+	fmt.Println("Use --help for full flag details.")
+}
+
+// GetChangelogCommand returns the cobra command that writes a CHANGELOG.md from git history.
+func GetChangelogCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "changelog",
+		Short:        "Generate a Keep a Changelog style CHANGELOG.md",
+		Long: "Turn the git history of a specification into a CHANGELOG.md with Added, Changed, Deprecated, " +
+			"Removed and Breaking sections for each version. Versions already in the file are not written again, " +
+			"except that the newest one takes in changes from commits that landed under it since it was written.",
+		Example: "openapi-changes changelog --version-from tag ./ api/openapi.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, configFlag, err := readCommonFlags(cmd)
+			if err != nil {
+				return err
+			}
+			changelogFile, _ := cmd.Flags().GetString("changelog-file")
+			versionFrom, _ := cmd.Flags().GetString("version-from")
+			maybePrintBanner(cmd, opts.palette)
+
+			if len(args) == 0 {
+				printChangelogUsage(opts.palette)
+				return nil
+			}
+			if len(args) > 2 {
+				return fmt.Errorf("too many arguments provided, expecting at most two (2)")
+			}
+
+			labeler, err := changelogLabelerFor(versionFrom)
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				if err := validateGitHubURL(args[0]); err != nil {
+					return err
+				}
+				if versionFrom == changelogVersionFromTag {
+					return errors.New("--version-from tag requires a local git repository")
+				}
+			} else if f, statErr := os.Stat(args[0]); statErr != nil || !f.IsDir() {
+				return fmt.Errorf("changelog requires a git repository and a file path, or a GitHub file URL")
+			}
+
+			breakingConfig, err := LoadBreakingRulesConfig(configFlag)
+			if err != nil {
+				PrintConfigError(err, opts.palette)
+				return err
+			}
+
+			var history *model.FlatHistoricalReport
+			if len(args) == 1 {
+				history, err = runGithubHistoryReport(args[0], opts, breakingConfig)
+			} else {
+				history, err = runGitHistoryReport(args[0], args[1], opts, breakingConfig)
+			}
+			if err != nil {
+				return err
+			}
+			if history == nil || len(history.Reports) == 0 {
+				printNoChangesText()
+				return nil
+			}
+
+			versions, err := buildChangelogVersions(history.Reports, labeler)
+			if err != nil {
+				return err
+			}
+			if len(versions) == 0 {
+				printNoChangesText()
+				return nil
+			}
+
+			existing, err := os.ReadFile(changelogFile)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to read changelog: %w", err)
+			}
+			merged, added := mergeChangelog(string(existing), versions)
+			styles := commandStylesFor(opts.palette)
+			if added == 0 && merged == string(existing) {
+				fmt.Println(styles.success.Render(fmt.Sprintf("changelog '%s' is already up to date", changelogFile)))
+				return nil
+			}
+			if err := os.WriteFile(changelogFile, []byte(merged), 0644); err != nil {
+				return fmt.Errorf("failed to write changelog: %w", err)
+			}
+			fmt.Println(styles.success.Render(fmt.Sprintf("changelog written to '%s' (%d new versions)", changelogFile, added)))
+			return nil
+		},
+	}
+	addTerminalThemeFlags(cmd)
+	cmd.Flags().String("changelog-file", "CHANGELOG.md", "The changelog file to write; new versions are prepended to an existing file")
+	cmd.Flags().String("version-from", changelogVersionFromInfo, "How versions are named: 'info' (info.version), 'tag' (first git tag containing the commit) or 'commit'")
+	return cmd
+}
@@ -0,0 +1,237 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func changelogTestReports() []*model.FlatReport {
+	newest := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+	return []*model.FlatReport{
+		{
+			Commit: &model.Commit{Hash: "cccccccccc", CommitDate: newest, Data: []byte("info:\n  version: 1.1.0\n")},
+			Changes: []*model.HashedChange{
+				mergeTestChange("$.paths['/orders']", "post", wcModel.ObjectAdded, "", "post", false),
+				mergeTestChange("$.paths['/orders/{id}'].get", "deprecated", wcModel.PropertyAdded, "", "true", false),
+			},
+		},
+		{
+			Commit: &model.Commit{Hash: "bbbbbbbbbb", CommitDate: newest.Add(-24 * time.Hour), Data: []byte("info:\n  version: 1.1.0\n")},
+			Changes: []*model.HashedChange{
+				mergeTestChange("$.paths['/orders']", "post", wcModel.ObjectAdded, "", "post", false),
+				mergeTestChange("$.info", "title", wcModel.Modified, "Orders", "Orders API", false),
+			},
+		},
+		{
+			Commit: &model.Commit{Hash: "aaaaaaaaaa", CommitDate: newest.Add(-48 * time.Hour), Data: []byte(`{"info": {"version": "1.0.0"}}`)},
+			Changes: []*model.HashedChange{
+				mergeTestChange("$.paths['/orders/{id}']", "delete", wcModel.ObjectRemoved, "delete", "", true),
+				mergeTestChange("$.components.schemas['Legacy']", "description", wcModel.PropertyRemoved, "old", "", false),
+			},
+		},
+	}
+}
+
+func TestChangelogSectionFor(t *testing.T) {
+	assert.Equal(t, changelogSectionBreaking,
+		changelogSectionFor(mergeTestChange("$.paths['/a']", "get", wcModel.ObjectRemoved, "get", "", true)))
+	assert.Equal(t, changelogSectionDeprecated,
+		changelogSectionFor(mergeTestChange("$.paths['/a'].get", "deprecated", wcModel.Modified, "false", "true", false)))
+	assert.Equal(t, changelogSectionChanged,
+		changelogSectionFor(mergeTestChange("$.paths['/a'].get", "deprecated", wcModel.Modified, "true", "false", false)))
+	assert.Equal(t, changelogSectionAdded,
+		changelogSectionFor(mergeTestChange("$.paths['/a']", "get", wcModel.ObjectAdded, "", "get", false)))
+	assert.Equal(t, changelogSectionRemoved,
+		changelogSectionFor(mergeTestChange("$.paths['/a'].get", "summary", wcModel.PropertyRemoved, "x", "", false)))
+}
+
+func TestBuildChangelogVersions_GroupsByInfoVersion(t *testing.T) {
+	versions, err := buildChangelogVersions(changelogTestReports(), infoVersionLabel)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	assert.Equal(t, "1.1.0", versions[0].name)
+	assert.Equal(t, time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC), versions[0].date)
+	assert.Equal(t, []string{"`POST /orders`"}, versions[0].sections[changelogSectionAdded])
+	assert.Equal(t, []string{"`GET /orders/{id}` deprecated"}, versions[0].sections[changelogSectionDeprecated])
+	assert.Equal(t, []string{"`info` title: modified \"Orders\" -> \"Orders API\""}, versions[0].sections[changelogSectionChanged])
+
+	assert.Equal(t, "1.0.0", versions[1].name)
+	assert.Equal(t, []string{"`DELETE /orders/{id}`: removed \"delete\""}, versions[1].sections[changelogSectionBreaking])
+	assert.Equal(t, []string{"`components.schemas['Legacy']` description"}, versions[1].sections[changelogSectionRemoved])
+}
+
+func TestBuildChangelogVersions_ByCommit(t *testing.T) {
+	versions, err := buildChangelogVersions(changelogTestReports(), commitVersionLabel)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, "ccccccc", versions[0].name)
+	assert.Equal(t, "aaaaaaa", versions[2].name)
+}
+
+func TestChangelogLabelerFor_Unknown(t *testing.T) {
+	_, err := changelogLabelerFor("semver")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown --version-from value 'semver'")
+}
+
+func TestMergeChangelog_NewFile(t *testing.T) {
+	versions, err := buildChangelogVersions(changelogTestReports(), infoVersionLabel)
+	require.NoError(t, err)
+
+	merged, added := mergeChangelog("", versions)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, `# Changelog
+
+All notable changes to this API are documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).
+
+## [1.1.0] - 2026-05-02
+
+### Added
+
+- `+"`POST /orders`"+`
+
+### Changed
+
+- `+"`info`"+` title: modified "Orders" -> "Orders API"
+
+### Deprecated
+
+- `+"`GET /orders/{id}`"+` deprecated
+
+## [1.0.0] - 2026-04-30
+
+### Removed
+
+- `+"`components.schemas['Legacy']`"+` description
+
+### Breaking
+
+- `+"`DELETE /orders/{id}`"+`: removed "delete"
+
+`, merged)
+}
+
+func TestMergeChangelog_PrependsWithoutDuplicatingVersions(t *testing.T) {
+	existing := `# Changelog
+
+Hand written intro.
+
+## [Unreleased]
+
+### Added
+
+- something stale
+
+## [1.0.0] - 2026-04-30
+
+### Added
+
+- hand edited entry
+`
+	versions, err := buildChangelogVersions(changelogTestReports(), infoVersionLabel)
+	require.NoError(t, err)
+
+	merged, added := mergeChangelog(existing, versions)
+	assert.Equal(t, 1, added)
+	assert.Contains(t, merged, "Hand written intro.\n\n## [Unreleased]\n\n### Added\n\n- something stale\n\n## [1.1.0] - 2026-05-02\n",
+		"hand written unreleased notes are kept when no unreleased version is generated")
+	assert.Contains(t, merged, "## [1.0.0] - 2026-04-30\n\n### Added\n\n- hand edited entry\n")
+	assert.NotContains(t, merged, "`DELETE /orders/{id}`")
+
+	again, added := mergeChangelog(merged, versions)
+	assert.Equal(t, 0, added)
+	assert.Equal(t, merged, again)
+}
+
+func TestMergeChangelog_RegeneratesUnreleased(t *testing.T) {
+	existing := "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- stale\n\n## [1.0.0] - 2026-04-30\n\n### Added\n\n- kept\n"
+	unreleased := &changelogVersion{
+		name:     changelogUnreleased,
+		sections: map[string][]string{changelogSectionAdded: {"fresh"}},
+		seen:     map[string]struct{}{"fresh": {}},
+	}
+
+	merged, added := mergeChangelog(existing, []*changelogVersion{unreleased})
+	assert.Equal(t, 1, added)
+	assert.Equal(t, "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- fresh\n\n## [1.0.0] - 2026-04-30\n\n### Added\n\n- kept\n", merged)
+}
+
+func TestMergeChangelog_AddsLaterCommitsToNewestVersion(t *testing.T) {
+	existing := `# Changelog
+
+## [1.1.0] - 2026-05-01
+
+Release notes.
+
+### Added
+
+- ` + "`POST /orders`" + `
+  with a second line
+
+### Security
+
+- hand written
+
+## [1.0.0] - 2026-04-30
+
+### Added
+
+- kept
+`
+	versions, err := buildChangelogVersions(changelogTestReports(), infoVersionLabel)
+	require.NoError(t, err)
+
+	merged, added := mergeChangelog(existing, versions)
+	assert.Equal(t, 0, added)
+	assert.Equal(t, `# Changelog
+
+## [1.1.0] - 2026-05-02
+
+Release notes.
+
+### Added
+
+- `+"`POST /orders`"+`
+  with a second line
+
+### Changed
+
+- `+"`info`"+` title: modified "Orders" -> "Orders API"
+
+### Deprecated
+
+- `+"`GET /orders/{id}`"+` deprecated
+
+### Security
+
+- hand written
+
+## [1.0.0] - 2026-04-30
+
+### Added
+
+- kept
+`, merged, "the entries of later commits under the same version are added, older versions are left alone")
+
+	again, _ := mergeChangelog(merged, versions)
+	assert.Equal(t, merged, again, "a version is only updated when newer commits landed under it")
+}
+
+func TestChangelogCommand_RequiresRepository(t *testing.T) {
+	cmd := testRootCmd(GetChangelogCommand(), "--no-logo", "../sample-specs/petstorev3.json", "openapi.yaml")
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a git repository")
+}
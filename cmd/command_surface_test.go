@@ -15,5 +15,11 @@ func TestCommandSurface_UsesCanonicalNames(t *testing.T) {
 	assert.Equal(t, "markdown-report", GetMarkdownReportCommand().Use)
 	assert.Equal(t, "html-report", GetHTMLReportCommand().Use)
 	assert.Equal(t, "console", GetConsoleCommand().Use)
+	assert.Equal(t, "merge-check", GetMergeCheckCommand().Use)
+	assert.Equal(t, "blame", GetBlameCommand().Use)
+	assert.Equal(t, "changelog", GetChangelogCommand().Use)
+	assert.Equal(t, "deprecations", GetDeprecationsCommand().Use)
+	assert.Equal(t, "rules", GetRulesCommand().Use)
+	assert.Equal(t, "schema [name]", GetSchemaCommand().Use)
 	assert.Equal(t, "version", GetVersionCommand().Use)
 }
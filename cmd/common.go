@@ -28,8 +28,9 @@ type summaryOpts struct {
 	tektronix       bool
 	markdown        bool
 	withLines       bool
-	errorOnDiff     bool
+	failOn          string
 	latest          bool
+	squash          bool
 	limit           int
 	limitTime       int
 	base            string
@@ -37,6 +38,7 @@ type summaryOpts struct {
 	remote          bool
 	extRefs         bool
 	globalRevisions bool
+	rules           reportRules
 	theme           terminal.ThemeName
 	palette         terminal.Palette
 }
@@ -106,6 +108,7 @@ func readCommonFlags(cmd *cobra.Command) (opts summaryOpts, configFlag string, e
 	}
 	opts.withLines, _ = cmd.Flags().GetBool("with-lines")
 	opts.latest, _ = cmd.Flags().GetBool("top")
+	opts.squash, _ = cmd.Flags().GetBool("squash")
 	opts.limit, _ = cmd.Flags().GetInt("limit")
 	opts.limitTime, _ = cmd.Flags().GetInt("limit-time")
 	opts.base, _ = cmd.Flags().GetString("base")
@@ -132,6 +135,14 @@ func validateGitHubURL(arg string) error {
 	return nil
 }
 
+// shortCommitHash abbreviates a commit hash to the seven characters git uses.
+func shortCommitHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
 func printNoChangesText() {
 	fmt.Println(noChangesFoundMessage)
 }
@@ -201,11 +212,11 @@ func prepareCommandRun(
 		return nil, fmt.Errorf("too many arguments provided, expecting at most two (2)")
 	}
 
-	breakingConfig, err := LoadBreakingRulesConfig(configFlag)
+	breakingConfig, rules, err := readReportRules(cmd, configFlag, opts.palette)
 	if err != nil {
-		PrintConfigError(err, opts.palette)
 		return nil, err
 	}
+	opts.rules = rules
 
 	commits, err := loadCommitsFromArgs(args, opts, breakingConfig)
 	if err != nil {
@@ -26,8 +26,9 @@ func testRootCmd(sub *cobra.Command, args ...string) *cobra.Command {
 	root.PersistentFlags().BoolP("ext-refs", "", false, "")
 	root.PersistentFlags().StringP("config", "c", "", "")
 	root.PersistentFlags().BoolP("global-revisions", "R", false, "")
+	root.PersistentFlags().String("profile", "", "")
 	root.AddCommand(sub)
-	root.SetArgs(append([]string{sub.Use}, args...))
+	root.SetArgs(append([]string{sub.Name()}, args...))
 	return root
 }
 
@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/pb33f/doctor/terminal"
 	"github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/pb33f/openapi-changes/internal/breakingrules"
+	"github.com/pb33f/openapi-changes/internal/severity"
 	"go.yaml.in/yaml/v4"
 )
 
@@ -21,11 +23,33 @@ const (
 	DefaultConfigFileName = "changes-rules.yaml"
 )
 
+// RulesConfig is a loaded rules config file: the breaking rules, plus the
+// change severities from its optional top-level `severities` section. Files
+// named in its top-level `extends` section are merged underneath it, in order,
+// and listed in Extends along with everything they extend.
+type RulesConfig struct {
+	Breaking   *model.BreakingRulesConfig
+	Severities severity.Config
+	Path       string
+	Extends    []string
+}
+
 // LoadBreakingRulesConfig loads a breaking rules configuration from the specified path.
 // If configPath is empty, it searches default locations (current directory, then ~/.config).
 // Returns nil config if no config is found in default locations (uses libopenapi defaults).
 // Returns error if user-specified config path doesn't exist or has invalid YAML.
 func LoadBreakingRulesConfig(configPath string) (*model.BreakingRulesConfig, error) {
+	config, err := LoadRulesConfig(configPath)
+	if err != nil || config == nil {
+		return nil, err
+	}
+	return config.Breaking, nil
+}
+
+// LoadRulesConfig loads the breaking rules and severities from the specified path,
+// searching the same default locations as LoadBreakingRulesConfig when configPath is empty.
+// Returns nil if no config is found in default locations.
+func LoadRulesConfig(configPath string) (*RulesConfig, error) {
 	// If user specified a config path, it must exist
 	if configPath != "" {
 		return loadConfigFromPath(configPath, true)
@@ -51,7 +75,7 @@ func LoadBreakingRulesConfig(configPath string) (*model.BreakingRulesConfig, err
 // loadConfigFromPath loads config from a specific path.
 // If required is true, returns error if file doesn't exist.
 // If required is false, returns nil, nil if file doesn't exist.
-func loadConfigFromPath(configPath string, required bool) (*model.BreakingRulesConfig, error) {
+func loadConfigFromPath(configPath string, required bool) (*RulesConfig, error) {
 	// Expand ~ to home directory
 	expandedPath, err := expandUserPath(configPath)
 	if err != nil {
@@ -68,16 +92,49 @@ func loadConfigFromPath(configPath string, required bool) (*model.BreakingRulesC
 	if err != nil {
 		return nil, fmt.Errorf("failed to access config file '%s': %w", expandedPath, err)
 	}
+	return loadConfigFile(expandedPath, nil)
+}
+
+// loadConfigFile loads and validates a single config file, then merges it on top
+// of the files it extends. chain holds the files extending it, to detect cycles.
+func loadConfigFile(configPath string, chain []string) (*RulesConfig, error) {
+	absolutePath, err := filepath.Abs(configPath)
+	if err != nil {
+		absolutePath = configPath
+	}
+	if slices.Contains(chain, absolutePath) {
+		return nil, &ConfigParseError{
+			FilePath: configPath,
+			Err:      fmt.Errorf("extends cycle: %s", strings.Join(append(chain, absolutePath), " -> ")),
+		}
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file '%s': %w", configPath, err)
+	}
 
-	data, err := os.ReadFile(expandedPath)
+	// The extends and severities sections are not part of the libopenapi rules,
+	// so they are split out before the breaking rules are validated.
+	data, extends, err := splitExtendsSection(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file '%s': %w", expandedPath, err)
+		return nil, &ConfigParseError{
+			FilePath: configPath,
+			Err:      err,
+		}
+	}
+	data, severities, err := splitSeveritiesSection(data)
+	if err != nil {
+		return nil, &ConfigParseError{
+			FilePath: configPath,
+			Err:      err,
+		}
 	}
 
 	// Validate config structure before parsing
 	if validationResult := model.ValidateBreakingRulesConfigYAML(data); validationResult != nil {
 		return nil, &ConfigValidationError{
-			FilePath: expandedPath,
+			FilePath: configPath,
 			Result:   validationResult,
 		}
 	}
@@ -85,12 +142,127 @@ func loadConfigFromPath(configPath string, required bool) (*model.BreakingRulesC
 	var config model.BreakingRulesConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, &ConfigParseError{
-			FilePath: expandedPath,
+			FilePath: configPath,
 			Err:      err,
 		}
 	}
+	if len(extends) == 0 {
+		return &RulesConfig{Breaking: &config, Severities: severities, Path: configPath}, nil
+	}
 
-	return &config, nil
+	merged := &RulesConfig{Breaking: &model.BreakingRulesConfig{}, Path: configPath}
+	for _, extended := range extends {
+		basePath, err := resolveExtendsPath(configPath, extended)
+		if err != nil {
+			return nil, &ConfigParseError{
+				FilePath: configPath,
+				Err:      err,
+			}
+		}
+		base, err := loadConfigFile(basePath, append(slices.Clip(chain), absolutePath))
+		if err != nil {
+			return nil, err
+		}
+		merged.Breaking.Merge(base.Breaking)
+		merged.Severities = merged.Severities.Merge(base.Severities)
+		merged.Extends = append(append(merged.Extends, base.Extends...), basePath)
+	}
+	merged.Breaking.Merge(&config)
+	merged.Severities = merged.Severities.Merge(severities)
+	return merged, nil
+}
+
+// resolveExtendsPath resolves a path named in `extends` relative to the file naming it.
+func resolveExtendsPath(configPath, extended string) (string, error) {
+	expanded, err := expandUserPath(extended)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand extends path '%s': %w", extended, err)
+	}
+	if !filepath.IsAbs(expanded) {
+		expanded = filepath.Join(filepath.Dir(configPath), expanded)
+	}
+	if _, err := os.Stat(expanded); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("extended config file not found: %s", expanded)
+		}
+		return "", fmt.Errorf("failed to access extended config file '%s': %w", expanded, err)
+	}
+	return expanded, nil
+}
+
+// splitExtendsSection decodes the top-level `extends` section of a rules config,
+// a path or a list of paths, and blanks it out of the returned data.
+func splitExtendsSection(data []byte) ([]byte, []string, error) {
+	data, node := splitTopLevelSection(data, "extends")
+	if node == nil {
+		return data, nil, nil
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value == "" {
+			return data, nil, nil
+		}
+		return data, []string{node.Value}, nil
+	case yaml.SequenceNode:
+		var extends []string
+		if err := node.Decode(&extends); err != nil {
+			return nil, nil, fmt.Errorf("extends: %w", err)
+		}
+		return data, extends, nil
+	}
+	return nil, nil, fmt.Errorf("extends: line %d: expected a path or a list of paths", node.Line)
+}
+
+// splitSeveritiesSection decodes the top-level `severities` section of a rules
+// config and blanks it out of the returned data.
+func splitSeveritiesSection(data []byte) ([]byte, severity.Config, error) {
+	data, node := splitTopLevelSection(data, "severities")
+	if node == nil {
+		return data, nil, nil
+	}
+	var severities severity.Config
+	if err := node.Decode(&severities); err != nil {
+		return nil, nil, fmt.Errorf("severities: %w", err)
+	}
+	if err := severities.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return data, severities, nil
+}
+
+// splitTopLevelSection finds a top-level section of a rules config and blanks
+// its lines out of the returned data, so that validation errors for the breaking
+// rules still report the original line numbers. Data that is not valid YAML is
+// returned unchanged, without a section, for the parser to report.
+func splitTopLevelSection(data []byte, key string) ([]byte, *yaml.Node) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return data, nil
+	}
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return data, nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		lines := strings.SplitAfter(string(data), "\n")
+		first := mapping.Content[i].Line - 1
+		last := len(lines)
+		if i+2 < len(mapping.Content) {
+			last = mapping.Content[i+2].Line - 1
+		}
+		for line := first; line < last && line < len(lines); line++ {
+			if strings.HasSuffix(lines[line], "\n") {
+				lines[line] = "\n"
+			} else {
+				lines[line] = ""
+			}
+		}
+		return []byte(strings.Join(lines, "")), mapping.Content[i+1]
+	}
+	return data, nil
 }
 
 // getDefaultConfigPaths returns the list of default paths to search for config files.
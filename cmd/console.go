@@ -6,12 +6,14 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/pb33f/doctor/changerator"
 	v3 "github.com/pb33f/doctor/model/high/v3"
 	"github.com/pb33f/doctor/terminal"
 	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/review"
 	"github.com/pb33f/openapi-changes/model"
 	v2tui "github.com/pb33f/openapi-changes/tui/v2"
 	"github.com/spf13/cobra"
@@ -38,6 +40,37 @@ func bridgeRunChangerator(commit *model.Commit, breakingConfig *whatChangedModel
 	return result.Changerator, root, releaseFn, nil
 }
 
+// bridgeJSONReport builds the JSON report of a commit for the console's export
+// action, applying the same severities and policy as the report command, and
+// writing it in the current layout as the report command does by default.
+func bridgeJSONReport(rules reportRules) v2tui.JSONReportFn {
+	output := reportOutput{version: model.CurrentReportVersion, format: reportFormatJSON}
+	return func(commit *model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig) ([]byte, error) {
+		// the console keeps reading the commit, so the report is built from a copy
+		reportCommit := *commit
+		result, err := runChangerator(&reportCommit, breakingConfig)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return nil, nil
+		}
+		defer result.Release()
+		reportCommit.Changes = result.DocChanges
+		flat := FlattenReportWithParameterNames(createResultReport(&reportCommit, result), result.Changerator.ParameterNames)
+		if err := applyFlatReportRules(rules, &reportCommit, flat); err != nil {
+			return nil, err
+		}
+		if reportCommit.Synthetic && len(reportCommit.Squashed) == 0 {
+			// a comparison of two files rather than a commit, as the report command writes it
+			flat.Commit = nil
+			flat.OriginalPath = reportCommit.OriginalSource
+			flat.ModifiedPath = reportCommit.ModifiedSource
+		}
+		return output.encode(flat)
+	}
+}
+
 func hasRenderableDocuments(commits []*model.Commit) bool {
 	for _, commit := range commits {
 		if commit.Document != nil && commit.OldDocument != nil {
@@ -62,6 +95,23 @@ func GetConsoleCommand() *cobra.Command {
 		Long:         "Navigate through changes visually in an interactive terminal UI built with Bubbletea, using the doctor changerator engine.",
 		Example:      "openapi-changes console HEAD~1:openapi.yaml ./openapi.yaml",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cacheSize, _ := cmd.Flags().GetInt("cache-size")
+			if cacheSize < 1 {
+				return fmt.Errorf("invalid cache size %d (must be at least 1)", cacheSize)
+			}
+			preset, _ := cmd.Flags().GetString("keymap")
+			overrides, _ := cmd.Flags().GetStringArray("keys")
+			keys, err := v2tui.NewKeyMap(preset, overrides)
+			if err != nil {
+				return err
+			}
+			sideBySide, _ := cmd.Flags().GetBool("side-by-side")
+			mouse, _ := cmd.Flags().GetBool("mouse")
+			reviewFile, _ := cmd.Flags().GetString("review-file")
+			reviews, err := review.Load(reviewFile)
+			if err != nil {
+				return err
+			}
 			input, err := prepareCommandRun(cmd, args, printConsoleUsage)
 			if err != nil {
 				return err
@@ -85,8 +135,20 @@ func GetConsoleCommand() *cobra.Command {
 				return nil
 			}
 
+			// files materialized for the editor are removed when the console exits
+			editorFiles := newConsoleEditorFiles()
+			defer editorFiles.cleanup()
+
 			// Build and run the TUI
-			m := v2tui.NewConsoleModel(input.Commits, input.BreakingConfig, input.Opts.theme, Version, bridgeRunChangerator)
+			m := v2tui.NewConsoleModel(input.Commits, input.BreakingConfig, input.Opts.theme, Version, bridgeRunChangerator).
+				WithSeverities(input.Opts.rules.severities).
+				WithCacheSize(cacheSize).
+				WithReviews(reviews).
+				WithJSONReports(bridgeJSONReport(input.Opts.rules)).
+				WithEditorTargets(editorFiles.target).
+				WithKeyMap(keys).
+				WithSideBySide(sideBySide).
+				WithMouse(mouse)
 			p := tea.NewProgram(m)
 			if _, err := p.Run(); err != nil {
 				return wrapConsoleStartError(err)
@@ -95,5 +157,11 @@ func GetConsoleCommand() *cobra.Command {
 		},
 	}
 	addTerminalThemeFlags(cmd)
+	cmd.Flags().Int("cache-size", 3, "Number of commits whose changes are kept in memory; commits either side of the selected one are loaded ahead of time while there is room")
+	cmd.Flags().String("keymap", "default", "Key binding preset for the console: "+strings.Join(v2tui.KeyPresets(), ", "))
+	cmd.Flags().StringArray("keys", nil, "Override the keys of a console action, as action=key[,key...] (e.g. review=v); repeat for more actions, and press ? in the console to list them")
+	cmd.Flags().Bool("side-by-side", false, "Start with the old and new specs side by side in the diff panel when it is wide enough; press s in the console to toggle it")
+	cmd.Flags().Bool("mouse", false, "Capture the mouse to click, scroll and drag in the console, which stops the terminal from selecting text; press M in the console to toggle it")
+	cmd.Flags().String("review-file", "openapi-changes-review.json", "JSON file that keeps the review marks and notes made in the console; the review is exported beside it")
 	return cmd
 }
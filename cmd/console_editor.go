@@ -0,0 +1,192 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/git"
+	"github.com/pb33f/openapi-changes/model"
+)
+
+// consoleEditorFiles resolves where the console opens a change in an editor. A
+// change in a working tree file opens the file itself. A change at a git
+// revision, or in a remote spec, opens a copy of the file as it was there,
+// written to a temporary directory that lasts as long as the console.
+type consoleEditorFiles struct {
+	dir     string
+	written map[string]string
+}
+
+func newConsoleEditorFiles() *consoleEditorFiles {
+	return &consoleEditorFiles{written: make(map[string]string)}
+}
+
+// cleanup removes the copies written for the editor.
+func (f *consoleEditorFiles) cleanup() {
+	if f.dir != "" {
+		_ = os.RemoveAll(f.dir)
+	}
+}
+
+// editorSide describes where the right (new) side of a commit was read from.
+// Exactly one of local, repoRoot or neither (a remote spec) is set.
+type editorSide struct {
+	local    string // the working tree file of the root document
+	repoRoot string // the repository the revision is read from
+	revision string
+	rootName string // the name of the root document, used for its copy
+}
+
+// consoleEditorSide works out where the right side of a commit was read from:
+// the commit of a git history or a console comparison, or the right source of
+// a left/right comparison.
+func consoleEditorSide(commit *model.Commit) (editorSide, error) {
+	if commit.Synthetic && len(commit.Squashed) == 0 {
+		source := commit.ModifiedSource
+		if isHTTPURL(source) {
+			name := "openapi.yaml"
+			if u, err := url.Parse(source); err == nil && path.Base(u.Path) != "/" && path.Base(u.Path) != "." {
+				name = path.Base(u.Path)
+			}
+			return editorSide{revision: "remote", rootName: name}, nil
+		}
+		revision, filePath, ok := parseGitRef(source)
+		if !ok {
+			return editorSide{local: source}, nil
+		}
+		wd, err := os.Getwd()
+		if err != nil {
+			return editorSide{}, fmt.Errorf("cannot determine working directory: %w", err)
+		}
+		repoRoot, err := git.GetTopLevel(wd)
+		if err != nil {
+			return editorSide{}, fmt.Errorf("cannot open '%s' in an editor outside its git repository: %w", source, err)
+		}
+		normalizedPath, err := normalizeGitRefPath(repoRoot, filePath)
+		if err != nil {
+			return editorSide{}, err
+		}
+		return editorSide{repoRoot: repoRoot, revision: revision, rootName: normalizedPath}, nil
+	}
+
+	revision := commit.Hash
+	if commit.Synthetic {
+		// a comparison of two commits shows the spec as it was at the newer side
+		revision = commit.ModifiedSource
+	}
+	side := editorSide{revision: revision, rootName: commit.FilePath}
+	if commit.RepoDirectory != "" {
+		repoRoot, err := git.GetTopLevel(commit.RepoDirectory)
+		if err != nil {
+			return editorSide{}, err
+		}
+		side.repoRoot = repoRoot
+	}
+	return side, nil
+}
+
+// target returns the file and line where a change of a commit is opened: the
+// file of the change's right side, at its new line.
+func (f *consoleEditorFiles) target(commit *model.Commit, change *whatChangedModel.Change) (string, int, error) {
+	var location string
+	var line int
+	if change.Context != nil {
+		location = change.Context.DocumentLocation
+		if change.Context.NewLine != nil {
+			line = *change.Context.NewLine
+		}
+	}
+	side, err := consoleEditorSide(commit)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// changes in the root document carry no location
+	if location == "" {
+		if side.local != "" {
+			return side.local, line, nil
+		}
+		if len(commit.Data) == 0 {
+			return "", 0, fmt.Errorf("commit %s has no spec to open", commit.Hash)
+		}
+		written, err := f.write(side.revision, side.rootName, commit.Data)
+		return written, line, err
+	}
+
+	switch {
+	case side.local != "":
+		return localEditorLocation(side.local, location), line, nil
+	case side.repoRoot != "":
+		data, err := git.ReadFileAtRevision(side.repoRoot, side.revision, filepath.ToSlash(location))
+		if err != nil {
+			return "", 0, err
+		}
+		written, err := f.write(side.revision, location, data)
+		return written, line, err
+	default:
+		return "", 0, fmt.Errorf("cannot open '%s' in an editor: it is not in a local file or repository", location)
+	}
+}
+
+// localEditorLocation returns the file of a change in a document referenced by
+// a working tree spec. Its location is absolute, unless a git revision on the
+// other side of the comparison rewrote it relative to the root of its
+// repository, which is then the repository of the spec, or else the directory
+// the spec is in.
+func localEditorLocation(rootDocument, location string) string {
+	location = filepath.FromSlash(location)
+	if filepath.IsAbs(location) {
+		return location
+	}
+	dir := filepath.Dir(rootDocument)
+	if repoRoot, err := git.GetTopLevel(dir); err == nil {
+		return filepath.Join(repoRoot, location)
+	}
+	return filepath.Join(dir, location)
+}
+
+// write copies a file at a revision into the temporary directory, keeping its
+// name so that editors recognise the format. Each file is written once.
+func (f *consoleEditorFiles) write(revision, name string, data []byte) (string, error) {
+	key := revision + ":" + name
+	if written, ok := f.written[key]; ok {
+		return written, nil
+	}
+	if f.dir == "" {
+		dir, err := os.MkdirTemp("", "openapi-changes-console-")
+		if err != nil {
+			return "", fmt.Errorf("cannot create a directory for the editor: %w", err)
+		}
+		f.dir = dir
+	}
+	rel := filepath.FromSlash(name)
+	if !filepath.IsLocal(rel) {
+		rel = filepath.Base(rel)
+	}
+	written := filepath.Join(f.dir, shortRevision(revision), rel)
+	if err := os.MkdirAll(filepath.Dir(written), 0755); err != nil {
+		return "", fmt.Errorf("cannot write '%s' for the editor: %w", name, err)
+	}
+	if err := os.WriteFile(written, data, 0644); err != nil {
+		return "", fmt.Errorf("cannot write '%s' for the editor: %w", name, err)
+	}
+	f.written[key] = written
+	return written, nil
+}
+
+// shortRevision returns a revision as a directory name: a commit hash is cut
+// short, and any other revision is made safe to use as a path element.
+func shortRevision(revision string) string {
+	if len(revision) == 40 {
+		return revision[:7]
+	}
+	return strings.NewReplacer("/", "-", "\\", "-", "..", "-", ":", "-").Replace(revision)
+}
@@ -0,0 +1,125 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func editorTestChange(location string, line int) *whatChangedModel.Change {
+	return &whatChangedModel.Change{
+		Property: "title",
+		Context:  &whatChangedModel.ChangeContext{DocumentLocation: location, NewLine: &line},
+	}
+}
+
+func TestConsoleEditorFiles_GitRevisionIsMaterialized(t *testing.T) {
+	repo := t.TempDir()
+	runGitInDir(t, repo, "init")
+	runGitInDir(t, repo, "config", "user.email", "test@example.com")
+	runGitInDir(t, repo, "config", "user.name", "Test")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "schemas"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "schemas", "pet.yaml"), []byte("type: object\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "openapi.yaml"), []byte("openapi: 3.1.0\n"), 0o644))
+	runGitInDir(t, repo, "add", ".")
+	runGitInDir(t, repo, "commit", "-m", "initial")
+	hash := gitOutputInDir(t, repo, "rev-parse", "HEAD")
+
+	// the working tree has moved on since the commit
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "schemas", "pet.yaml"), []byte("type: string\n"), 0o644))
+
+	files := newConsoleEditorFiles()
+	defer files.cleanup()
+	commit := &model.Commit{Hash: hash, RepoDirectory: repo, FilePath: "openapi.yaml", Data: []byte("openapi: 3.1.0\n")}
+
+	path, line, err := files.target(commit, editorTestChange("", 3))
+	require.NoError(t, err)
+	assert.Equal(t, 3, line)
+	assert.Equal(t, "openapi.yaml", filepath.Base(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0\n", string(data))
+
+	path, line, err = files.target(commit, editorTestChange("schemas/pet.yaml", 1))
+	require.NoError(t, err)
+	assert.Equal(t, 1, line)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "type: object\n", string(data), "the file is opened as it was at the commit")
+
+	files.cleanup()
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "materialized files are removed with the console")
+}
+
+func TestConsoleEditorFiles_LocalFilesOpenInPlace(t *testing.T) {
+	files := newConsoleEditorFiles()
+	defer files.cleanup()
+	commit := &model.Commit{Synthetic: true, OriginalSource: "left.yaml", ModifiedSource: "right.yaml"}
+
+	path, line, err := files.target(commit, editorTestChange("", 9))
+	require.NoError(t, err)
+	assert.Equal(t, "right.yaml", path)
+	assert.Equal(t, 9, line)
+
+	path, _, err = files.target(commit, editorTestChange("/specs/shared.yaml", 2))
+	require.NoError(t, err)
+	assert.Equal(t, "/specs/shared.yaml", path)
+	assert.Empty(t, files.dir, "nothing is written for local files")
+}
+
+func TestConsoleEditorFiles_LocalSpecResolvesRepoRelativeLocations(t *testing.T) {
+	repo := t.TempDir()
+	runGitInDir(t, repo, "init")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "specs", "schemas"), 0o755))
+	right := filepath.Join(repo, "specs", "openapi.yaml")
+
+	files := newConsoleEditorFiles()
+	defer files.cleanup()
+	// a git revision on the left rewrites the locations of the right relative to the repository
+	commit := &model.Commit{Synthetic: true, OriginalSource: "HEAD:specs/openapi.yaml", ModifiedSource: right}
+
+	path, line, err := files.target(commit, editorTestChange("specs/schemas/pet.yaml", 4))
+	require.NoError(t, err)
+	assert.Equal(t, 4, line)
+	wantRoot, err := filepath.EvalSymlinks(repo)
+	require.NoError(t, err)
+	gotDir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(wantRoot, "specs", "schemas"), gotDir)
+	assert.Equal(t, "pet.yaml", filepath.Base(path))
+
+	// outside a repository, the location is relative to the spec
+	dir := t.TempDir()
+	commit.ModifiedSource = filepath.Join(dir, "openapi.yaml")
+	path, _, err = files.target(commit, editorTestChange("schemas/pet.yaml", 1))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "schemas", "pet.yaml"), path)
+	assert.Empty(t, files.dir, "nothing is written for local files")
+}
+
+func TestConsoleEditorFiles_RemoteSpec(t *testing.T) {
+	files := newConsoleEditorFiles()
+	defer files.cleanup()
+	commit := &model.Commit{
+		Synthetic:      true,
+		OriginalSource: "https://example.com/specs/old.yaml",
+		ModifiedSource: "https://example.com/specs/new.yaml",
+		Data:           []byte("openapi: 3.1.0\n"),
+	}
+
+	path, _, err := files.target(commit, editorTestChange("", 1))
+	require.NoError(t, err)
+	assert.Equal(t, "new.yaml", filepath.Base(path))
+
+	_, _, err = files.target(commit, editorTestChange("https://example.com/specs/shared.yaml", 1))
+	assert.ErrorContains(t, err, "not in a local file or repository")
+}
@@ -72,6 +72,18 @@ func TestConsoleCommand_TwoArgsURLFirst(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestConsoleCommand_InvalidKeyBindings(t *testing.T) {
+	cmd := testRootCmd(GetConsoleCommand(), "--no-logo", "--no-color", "--keymap", "nano",
+		"../sample-specs/petstorev3.json", "../sample-specs/petstorev3.json")
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "unknown key preset 'nano'")
+
+	cmd = testRootCmd(GetConsoleCommand(), "--no-logo", "--no-color", "--keymap", "vim", "--keys", "review=q",
+		"../sample-specs/petstorev3.json", "../sample-specs/petstorev3.json")
+	err = cmd.Execute()
+	assert.ErrorContains(t, err, "key 'q' is bound to both")
+}
+
 func TestHasRenderableDocuments(t *testing.T) {
 	doc, err := libopenapi.NewDocument([]byte("openapi: 3.0.0\ninfo:\n  title: test\n  version: 1.0.0\npaths: {}\n"))
 	require.NoError(t, err)
@@ -0,0 +1,258 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pb33f/doctor/terminal"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/openapi-changes/internal/deprecation"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+)
+
+// buildDeprecationSnapshots converts a newest-first commit history into
+// oldest-first snapshots. When the oldest commit carries its parent revision,
+// that revision becomes an undated baseline.
+func buildDeprecationSnapshots(commits []*model.Commit) []*deprecation.Snapshot {
+	var snapshots []*deprecation.Snapshot
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		if commit == nil || commit.Document == nil {
+			continue
+		}
+		if len(snapshots) == 0 && commit.OldDocument != nil {
+			if baseline := deprecationSnapshot(commit.OldDocument, "", time.Time{}); baseline != nil {
+				snapshots = append(snapshots, baseline)
+			}
+		}
+		if snapshot := deprecationSnapshot(commit.Document, commit.Hash, commit.CommitDate); snapshot != nil {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots
+}
+
+func deprecationSnapshot(doc libopenapi.Document, hash string, date time.Time) *deprecation.Snapshot {
+	v3Model, err := doc.BuildV3Model()
+	if err != nil || v3Model == nil {
+		label := hash
+		if label == "" {
+			label = "baseline"
+		}
+		fmt.Fprintf(os.Stderr, "warning: skipping revision '%s': %v\n", label, err)
+		return nil
+	}
+	return deprecation.NewSnapshot(&v3Model.Model, hash, date)
+}
+
+func runDeprecationCheck(args []string, opts summaryOpts, configFlag string, policy deprecation.Policy) (*model.DeprecationReport, error) {
+	breakingConfig, err := LoadBreakingRulesConfig(configFlag)
+	if err != nil {
+		PrintConfigError(err, opts.palette)
+		return nil, err
+	}
+
+	report := &model.DeprecationReport{
+		DateGenerated: time.Now().Format(time.RFC3339),
+		MinDays:       policy.MinDays,
+		MinReleases:   policy.MinReleases,
+		Lifecycles:    []*model.DeprecationLifecycle{},
+	}
+
+	var loaded *loadedHistoryResult
+	if len(args) == 1 {
+		loaded, err = loadGitHubCommitsDetailed(args[0], opts, breakingConfig)
+		report.GitRepoPath = args[0]
+	} else {
+		loaded, err = loadGitHistoryCommitsDetailed(args[0], args[1], opts, breakingConfig)
+		report.GitRepoPath = args[0]
+		report.GitFilePath = args[1]
+	}
+	if err != nil {
+		return nil, err
+	}
+	if loaded == nil {
+		return report, nil
+	}
+	if len(loaded.SkippedCommits) > 0 {
+		report.MetaData = &model.HistoricalReportMetaData{Partial: true, SkippedCommits: loaded.SkippedCommits}
+	}
+	if lifecycles := deprecation.Track(buildDeprecationSnapshots(loaded.Commits), policy); lifecycles != nil {
+		report.Lifecycles = lifecycles
+	}
+	return report, nil
+}
+
+func describeDeprecatedSince(lifecycle *model.DeprecationLifecycle) string {
+	if lifecycle.DeprecatedDate == nil {
+		return "deprecated before history window"
+	}
+	since := fmt.Sprintf("deprecated in %s (%s", shortCommitHash(lifecycle.DeprecatedCommit),
+		lifecycle.DeprecatedDate.Format("2006-01-02"))
+	if lifecycle.DeprecatedVersion != "" {
+		since += ", " + lifecycle.DeprecatedVersion
+	}
+	return since + ")"
+}
+
+func describeRemoved(lifecycle *model.DeprecationLifecycle) string {
+	removed := "removed in " + shortCommitHash(lifecycle.RemovedCommit)
+	if lifecycle.RemovedDate != nil && !lifecycle.RemovedDate.IsZero() {
+		removed += " (" + lifecycle.RemovedDate.Format("2006-01-02") + ")"
+	}
+	return removed
+}
+
+// renderDeprecationReport renders a deprecation report as terminal text.
+func renderDeprecationReport(report *model.DeprecationReport, styles summaryStyles) string {
+	var sb strings.Builder
+
+	sb.WriteString(styles.title.Render(fmt.Sprintf("Deprecations: %s (%s)", report.GitFilePath, report.GitRepoPath)))
+	sb.WriteString("\n")
+	sb.WriteString(styles.stat.Render(fmt.Sprintf(
+		"Policy: deprecate before removing, for at least %d days and %d releases", report.MinDays, report.MinReleases)))
+	sb.WriteString("\n\n")
+
+	var violations, removed, current []*model.DeprecationLifecycle
+	for _, lifecycle := range report.Lifecycles {
+		switch {
+		case lifecycle.Violation != "":
+			violations = append(violations, lifecycle)
+		case lifecycle.RemovedCommit != "":
+			removed = append(removed, lifecycle)
+		default:
+			current = append(current, lifecycle)
+		}
+	}
+
+	if len(violations) == 0 {
+		sb.WriteString(styles.addition.Render("No deprecation policy violations"))
+		sb.WriteString("\n\n")
+	} else {
+		sb.WriteString(styles.breaking.Render(fmt.Sprintf("Policy violations (%d)", len(violations))))
+		sb.WriteString("\n")
+		for _, lifecycle := range violations {
+			line := fmt.Sprintf("  - %s: %s", lifecycle.Label, describeRemoved(lifecycle))
+			if lifecycle.DeprecatedBeforeHistory {
+				line = fmt.Sprintf("  - %s: %s, %s", lifecycle.Label, describeDeprecatedSince(lifecycle), describeRemoved(lifecycle))
+			}
+			sb.WriteString(line + "\n")
+			sb.WriteString("      " + styles.breaking.Render(lifecycle.Violation) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(removed) > 0 {
+		sb.WriteString(styles.removal.Render(fmt.Sprintf("Removed after deprecation (%d)", len(removed))))
+		sb.WriteString("\n")
+		for _, lifecycle := range removed {
+			sb.WriteString(fmt.Sprintf("  - %s: %s, %s\n", lifecycle.Label,
+				describeDeprecatedSince(lifecycle), describeRemoved(lifecycle)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(current) > 0 {
+		sb.WriteString(styles.modification.Render(fmt.Sprintf("Currently deprecated (%d)", len(current))))
+		sb.WriteString("\n")
+		for _, lifecycle := range current {
+			line := fmt.Sprintf("  - %s: %s", lifecycle.Label, describeDeprecatedSince(lifecycle))
+			if lifecycle.Sunset != "" {
+				line += ", sunset " + styles.detail.Render(lifecycle.Sunset)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func printDeprecationsUsage(palette terminal.Palette) {
+	title := styleWithForeground(palette.Primary).Bold(true)
+	desc := styleWithForeground(palette.Muted)
+	cmdStyle := styleWithForeground(palette.Secondary).Bold(true)
+
+	fmt.Print(title.Render("How to use the "))
+	fmt.Print(cmdStyle.Render("deprecations"))
+	fmt.Println(title.Render(" command:"))
+	fmt.Println()
+	fmt.Println(desc.Render("The deprecations command follows deprecated operations, parameters and schemas through git history\nand fails when something is removed without being deprecated for long enough first."))
+	fmt.Println()
+	fmt.Println("  openapi-changes deprecations [options] <git-repo> <file-path>")
+	fmt.Println("  openapi-changes deprecations [options] <github-file-url>")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes deprecations ./ api/openapi.yaml"))
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes deprecations --min-days 90 --min-releases 2 --limit 100 ./ api/openapi.yaml"))
+	fmt.Println()
+	fmt.Println("Use --help for full flag details.")
+}
+
+// GetDeprecationsCommand returns the cobra command for deprecation lifecycle checks.
+func GetDeprecationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "deprecations",
+		Short:        "Track deprecations and enforce a deprecate-before-remove policy",
+		Long: "Follow deprecated operations, parameters, schemas and schema properties through git history, " +
+			"including x-sunset and Sunset/Deprecation response headers, and fail when something is removed " +
+			"without first being deprecated for a minimum number of days or releases.",
+		Example: "openapi-changes deprecations --min-days 90 ./ api/openapi.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, configFlag, err := readCommonFlags(cmd)
+			if err != nil {
+				return err
+			}
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			minDays, _ := cmd.Flags().GetInt("min-days")
+			minReleases, _ := cmd.Flags().GetInt("min-releases")
+			if !jsonOutput {
+				maybePrintBanner(cmd, opts.palette)
+			}
+
+			if len(args) == 0 {
+				printDeprecationsUsage(opts.palette)
+				return nil
+			}
+			if len(args) > 2 {
+				return fmt.Errorf("too many arguments provided, expecting at most two (2)")
+			}
+			if len(args) == 1 {
+				if err := validateGitHubURL(args[0]); err != nil {
+					return err
+				}
+			} else if f, statErr := os.Stat(args[0]); statErr != nil || !f.IsDir() {
+				return fmt.Errorf("deprecations requires a git repository and a file path, or a GitHub file URL")
+			}
+
+			report, err := runDeprecationCheck(args, opts, configFlag, deprecation.Policy{MinDays: minDays, MinReleases: minReleases})
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if err := printReportJSON(report); err != nil {
+					return err
+				}
+			} else {
+				fmt.Print(renderDeprecationReport(report, summaryStylesForPalette(opts.palette)))
+			}
+
+			if len(deprecation.Violations(report.Lifecycles)) > 0 {
+				return errors.New("deprecation policy violations discovered")
+			}
+			return nil
+		},
+	}
+	addTerminalThemeFlags(cmd)
+	cmd.Flags().Int("min-days", 0, "Minimum number of days something must be deprecated before it is removed")
+	cmd.Flags().Int("min-releases", 0, "Minimum number of releases (distinct info.version values) something must be deprecated for before it is removed")
+	cmd.Flags().Bool("json", false, "Print the deprecation report as JSON")
+	return cmd
+}
@@ -0,0 +1,92 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pb33f/openapi-changes/internal/deprecation"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDeprecationGitSpecRepo(t *testing.T) string {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	runGitInDir(t, repoDir, "init")
+	runGitInDir(t, repoDir, "config", "user.name", "Test User")
+	runGitInDir(t, repoDir, "config", "user.email", "test@example.com")
+
+	specs := []string{
+		"openapi: 3.0.3\ninfo:\n  title: pets\n  version: '1.0'\npaths:\n  /pets:\n    get:\n      responses:\n        \"200\":\n          description: ok\n    delete:\n      responses:\n        \"204\":\n          description: gone\n  /owners:\n    get:\n      responses:\n        \"200\":\n          description: ok\n",
+		"openapi: 3.0.3\ninfo:\n  title: pets\n  version: '1.1'\npaths:\n  /pets:\n    get:\n      responses:\n        \"200\":\n          description: ok\n    delete:\n      deprecated: true\n      responses:\n        \"204\":\n          description: gone\n  /owners:\n    get:\n      responses:\n        \"200\":\n          description: ok\n",
+		"openapi: 3.0.3\ninfo:\n  title: pets\n  version: '2.0'\npaths:\n  /pets:\n    get:\n      responses:\n        \"200\":\n          description: ok\n",
+	}
+	specPath := filepath.Join(repoDir, "openapi.yaml")
+	for _, spec := range specs {
+		require.NoError(t, os.WriteFile(specPath, []byte(spec), 0o644))
+		runGitInDir(t, repoDir, "add", "openapi.yaml")
+		runGitInDir(t, repoDir, "commit", "-m", "update")
+	}
+	return repoDir
+}
+
+func TestRunDeprecationCheck_GitHistory(t *testing.T) {
+	repoDir := createDeprecationGitSpecRepo(t)
+
+	report, err := runDeprecationCheck([]string{repoDir, "openapi.yaml"},
+		summaryOpts{base: repoDir, noColor: true, limitTime: -1}, "", deprecation.Policy{MinReleases: 2})
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.Equal(t, 2, report.MinReleases)
+	require.Len(t, report.Lifecycles, 2)
+
+	owners := report.Lifecycles[0]
+	assert.Equal(t, "$.paths['/owners'].get", owners.Path)
+	assert.Equal(t, "removed without being deprecated first", owners.Violation)
+	assert.Equal(t, gitOutputInDir(t, repoDir, "rev-parse", "--short", "HEAD"), owners.RemovedCommit)
+
+	petsDelete := report.Lifecycles[1]
+	assert.Equal(t, "$.paths['/pets'].delete", petsDelete.Path)
+	assert.Equal(t, gitOutputInDir(t, repoDir, "rev-parse", "--short", "HEAD~1"), petsDelete.DeprecatedCommit)
+	assert.Equal(t, "1.1", petsDelete.DeprecatedVersion)
+	assert.Equal(t, "deprecated for 1 releases, policy requires 2", petsDelete.Violation)
+}
+
+func TestRenderDeprecationReport(t *testing.T) {
+	deprecated := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	removed := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	report := &model.DeprecationReport{
+		GitRepoPath: "./",
+		GitFilePath: "openapi.yaml",
+		MinDays:     30,
+		Lifecycles: []*model.DeprecationLifecycle{
+			{Label: "GET /owners", RemovedCommit: "aaaaaaaaa", RemovedDate: &removed, Violation: "removed without being deprecated first"},
+			{Label: "DELETE /pets", DeprecatedCommit: "bbbbbbbbb", DeprecatedDate: &deprecated, DeprecatedVersion: "1.1",
+				RemovedCommit: "ccccccccc", RemovedDate: &removed},
+			{Label: "parameter 'limit' (query) of GET /pets", Sunset: "2026-12-01"},
+		},
+	}
+
+	output := renderDeprecationReport(report, summaryStyles{})
+	assert.Contains(t, output, "Deprecations: openapi.yaml (./)")
+	assert.Contains(t, output, "for at least 30 days and 0 releases")
+	assert.Contains(t, output, "Policy violations (1)")
+	assert.Contains(t, output, "  - GET /owners: removed in aaaaaaa (2026-03-04)\n      removed without being deprecated first")
+	assert.Contains(t, output, "  - DELETE /pets: deprecated in bbbbbbb (2026-01-02, 1.1), removed in ccccccc (2026-03-04)")
+	assert.Contains(t, output, "  - parameter 'limit' (query) of GET /pets: deprecated before history window, sunset 2026-12-01")
+}
+
+func TestDeprecationsCommand_RequiresRepository(t *testing.T) {
+	cmd := testRootCmd(GetDeprecationsCommand(), "--no-logo", "../sample-specs/petstorev3.json", "openapi.yaml")
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a git repository")
+}
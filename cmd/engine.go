@@ -27,6 +27,8 @@ type changeratorResult struct {
 	DocChanges  *whatChangedModel.DocumentChanges
 	RightDrDoc  *drModel.DrDocument
 	LeftDrDoc   *drModel.DrDocument
+	// Suppressions are the breaking changes the modified document suppressed.
+	Suppressions []*model.Suppression
 }
 
 func (r *changeratorResult) Release() {
@@ -88,13 +90,15 @@ func runChangerator(commit *model.Commit, breakingConfig *whatChangedModel.Break
 		leftDrDoc.Release()
 		return nil, nil
 	}
+	suppressions := suppressBreakingChanges(commit, docChanges)
 	rewriteOutputLocations(ctr, docChanges, commit.DocumentRewriters)
 
 	return &changeratorResult{
-		Changerator: ctr,
-		DocChanges:  docChanges,
-		RightDrDoc:  rightDrDoc,
-		LeftDrDoc:   leftDrDoc,
+		Changerator:  ctr,
+		DocChanges:   docChanges,
+		RightDrDoc:   rightDrDoc,
+		LeftDrDoc:    leftDrDoc,
+		Suppressions: suppressions,
 	}, nil
 }
 
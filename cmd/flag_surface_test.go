@@ -27,13 +27,21 @@ func TestCanonicalCommandLocalFlags(t *testing.T) {
 		"markdown":      true,
 		"with-lines":    true,
 		"error-on-diff": true,
+		"fail-on":       true,
+		"squash":        true,
+		"policy":        true,
 	}, flagNames(GetSummaryCommand()))
 
 	assert.Equal(t, map[string]bool{
-		"no-color":     true,
-		"roger-mode":   true,
-		"reproducible": true,
-		"tektronix":    true,
+		"no-color":       true,
+		"roger-mode":     true,
+		"reproducible":   true,
+		"report-version": true,
+		"format":         true,
+		"tektronix":      true,
+		"squash":         true,
+		"policy":         true,
+		"fail-on":        true,
 	}, flagNames(GetReportCommand()))
 
 	assert.Equal(t, map[string]bool{
@@ -42,6 +50,9 @@ func TestCanonicalCommandLocalFlags(t *testing.T) {
 		"tektronix":    true,
 		"report-file":  true,
 		"include-diff": true,
+		"squash":       true,
+		"policy":       true,
+		"fail-on":      true,
 	}, flagNames(GetMarkdownReportCommand()))
 
 	assert.Equal(t, map[string]bool{
@@ -50,13 +61,67 @@ func TestCanonicalCommandLocalFlags(t *testing.T) {
 		"tektronix":   true,
 		"report-file": true,
 		"no-explorer": true,
+		"squash":      true,
+		"policy":      true,
+		"fail-on":     true,
 	}, flagNames(GetHTMLReportCommand()))
 
+	assert.Equal(t, map[string]bool{
+		"no-color":     true,
+		"roger-mode":   true,
+		"tektronix":    true,
+		"cache-size":   true,
+		"review-file":  true,
+		"side-by-side": true,
+		"mouse":        true,
+		"keymap":       true,
+		"keys":         true,
+	}, flagNames(GetConsoleCommand()))
+
 	assert.Equal(t, map[string]bool{
 		"no-color":   true,
 		"roger-mode": true,
 		"tektronix":  true,
-	}, flagNames(GetConsoleCommand()))
+		"json":       true,
+		"policy":     true,
+		"fail-on":    true,
+	}, flagNames(GetMergeCheckCommand()))
+
+	assert.Equal(t, map[string]bool{
+		"no-color":   true,
+		"roger-mode": true,
+		"tektronix":  true,
+		"path":       true,
+		"json":       true,
+	}, flagNames(GetBlameCommand()))
+
+	assert.Equal(t, map[string]bool{
+		"no-color":       true,
+		"roger-mode":     true,
+		"tektronix":      true,
+		"changelog-file": true,
+		"version-from":   true,
+	}, flagNames(GetChangelogCommand()))
+
+	assert.Equal(t, map[string]bool{
+		"no-color":     true,
+		"roger-mode":   true,
+		"tektronix":    true,
+		"min-days":     true,
+		"min-releases": true,
+		"json":         true,
+	}, flagNames(GetDeprecationsCommand()))
+}
+
+func TestRulesCommandFlagSurface(t *testing.T) {
+	assert.Equal(t, map[string]bool{
+		"effective": true,
+	}, flagNames(getRulesPrintCommand()))
+	assert.Equal(t, map[string]bool{
+		"no-color":   true,
+		"roger-mode": true,
+		"tektronix":  true,
+	}, flagNames(getRulesValidateCommand()))
 }
 
 func TestRootPersistentFlagsRemainAvailable(t *testing.T) {
@@ -77,5 +142,6 @@ func TestRootPersistentFlagsRemainAvailable(t *testing.T) {
 		"ext-refs":         true,
 		"config":           true,
 		"global-revisions": true,
+		"profile":          true,
 	}, names)
 }
@@ -30,6 +30,7 @@ func flattenReport(report *model.Report, parameterNames map[string]string) *mode
 	flatReport.Summary = report.Summary
 	flatReport.DateGenerated = time.Now().Format(time.RFC3339)
 	var changes []*model.HashedChange
+	flattened := make(map[*wcModel.Change]*model.HashedChange)
 	rpt := report.Commit.Changes
 	for _, change := range rpt.GetAllChanges() {
 		rawPath := change.Path
@@ -45,9 +46,11 @@ func flattenReport(report *model.Report, parameterNames map[string]string) *mode
 		hashedChange.HashChange()
 
 		changes = append(changes, &hashedChange)
+		flattened[change] = &hashedChange
 	}
 	sortFlatReportChanges(changes)
 	flatReport.Changes = changes
+	flatReport.Suppressions = flattenSuppressions(report.Suppressions, flattened)
 
 	// Copy the Commit information from the report to the flatReport and then delete the changes
 	flatReport.Commit = &model.Commit{}
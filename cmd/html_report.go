@@ -27,10 +27,13 @@ func printHTMLReportUsage(palette terminal.Palette) {
 }
 
 // buildHTMLReportItems runs the changerator pipeline on each commit and produces
-// ReportItems for the HTML report. If at least one commit succeeds, failed
-// commits are logged and skipped; an error is returned only when every
-// candidate commit fails.
-func buildHTMLReportItems(commits []*model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig) ([]*htmlReport.ReportItem, error) {
+// ReportItems for the HTML report. Each item carries its change severities,
+// policy findings and suppressed breaking changes, which are also shown ahead of
+// its change report. If at least one commit succeeds, failed commits are logged
+// and skipped; an error is returned only when every candidate commit fails.
+func buildHTMLReportItems(commits []*model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig,
+	rules reportRules,
+) ([]*htmlReport.ReportItem, error) {
 	items := make([]*htmlReport.ReportItem, 0, len(commits))
 	var buildErrors []error
 
@@ -45,6 +48,16 @@ func buildHTMLReportItems(commits []*model.Commit, breakingConfig *whatChangedMo
 			continue
 		}
 
+		findings, commitSeverities, err := policyForResult(rules, commit, result)
+		if err != nil {
+			result.Release()
+			return nil, err
+		}
+		deduplicatedChanges := result.Changerator.DeduplicateChanges()
+		suppressions := result.Suppressions
+		severities := commitSeverities.Counts(deduplicatedChanges)
+		severitiesHTML := renderSeveritiesHTML(commitSeverities, deduplicatedChanges)
+
 		changeId := strconv.Itoa(i)
 		item, err := htmlReport.BuildReportItem(
 			commit,
@@ -61,6 +74,11 @@ func buildHTMLReportItems(commits []*model.Commit, breakingConfig *whatChangedMo
 			buildErrors = append(buildErrors, wrapCommitError(commit, wrappedErr))
 			continue
 		}
+		item.Severities = severities
+		item.PolicyFindings = findings
+		item.Suppressions = suppressions
+		item.HtmlReport = renderSquashedCommitsHTML(commit.Squashed) + renderPolicyFindingsHTML(findings) +
+			renderSuppressionsHTML(suppressions) + severitiesHTML + item.HtmlReport
 
 		items = append(items, item)
 	}
@@ -74,14 +92,21 @@ func buildHTMLReportItems(commits []*model.Commit, breakingConfig *whatChangedMo
 	return items, nil
 }
 
-// generateHTMLReport assembles the full HTML report from commits.
-func generateHTMLReport(commits []*model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig, noExplorer bool, args ...string) ([]byte, error) {
-	items, err := buildHTMLReportItems(commits, breakingConfig)
+// generateHTMLReport assembles the full HTML report from commits; the change
+// severities and policy findings of every commit are returned as its outcome.
+func generateHTMLReport(commits []*model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig,
+	rules reportRules, noExplorer bool, args ...string,
+) ([]byte, *changeOutcome, error) {
+	items, err := buildHTMLReportItems(commits, breakingConfig, rules)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(items) == 0 {
-		return nil, nil
+		return nil, nil, nil
+	}
+	outcome := &changeOutcome{}
+	for _, item := range items {
+		outcome.add(item.Severities, item.PolicyFindings)
 	}
 
 	history := htmlReport.BuildHistoryData(items)
@@ -104,7 +129,7 @@ func generateHTMLReport(commits []*model.Commit, breakingConfig *whatChangedMode
 	// json.Marshal escapes <, >, & by default — prevents </script> injection.
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("marshaling report payload: %w", err)
+		return nil, nil, fmt.Errorf("marshaling report payload: %w", err)
 	}
 
 	// Use text/template, NOT html/template — html/template would HTML-escape the JSON payload.
@@ -114,20 +139,20 @@ func generateHTMLReport(commits []*model.Commit, breakingConfig *whatChangedMode
 	tmpl := template.New("header")
 	t, err := tmpl.Parse(htmlReport.GetHeaderTemplate())
 	if err != nil {
-		return nil, fmt.Errorf("parsing header template: %w", err)
+		return nil, nil, fmt.Errorf("parsing header template: %w", err)
 	}
 	_, err = t.New("report").Parse(htmlReport.GetReportTemplate())
 	if err != nil {
-		return nil, fmt.Errorf("parsing report template: %w", err)
+		return nil, nil, fmt.Errorf("parsing report template: %w", err)
 	}
 
 	var buf bytes.Buffer
 	buf.Grow(len(payloadJSON) + len(reportData.BundledJS) + len(reportData.BundledCSS) + 4096)
 	if err := t.ExecuteTemplate(&buf, "report", reportData); err != nil {
-		return nil, fmt.Errorf("executing template: %w", err)
+		return nil, nil, fmt.Errorf("executing template: %w", err)
 	}
 
-	return buf.Bytes(), nil
+	return buf.Bytes(), outcome, nil
 }
 
 func GetHTMLReportCommand() *cobra.Command {
@@ -147,9 +172,13 @@ func GetHTMLReportCommand() *cobra.Command {
 			}
 			reportFile, _ := cmd.Flags().GetString("report-file")
 			noExplorer, _ := cmd.Flags().GetBool("no-explorer")
+			failOn, err := readFailOnFlag(cmd)
+			if err != nil {
+				return err
+			}
 			styles := commandStylesFor(input.Opts.palette)
 
-			report, err := generateHTMLReport(input.Commits, input.BreakingConfig, noExplorer, args...)
+			report, outcome, err := generateHTMLReport(input.Commits, input.BreakingConfig, input.Opts.rules, noExplorer, args...)
 			if err != nil {
 				return err
 			}
@@ -158,11 +187,17 @@ func GetHTMLReportCommand() *cobra.Command {
 				printNoChangesText()
 				return nil
 			}
-			return writeReportFile(reportFile, report, styles)
+			if err := writeReportFile(reportFile, report, styles); err != nil {
+				return err
+			}
+			return outcome.failOn(failOn)
 		},
 	}
 	addTerminalThemeFlags(cmd)
 	cmd.Flags().String("report-file", "report.html", "The name of the HTML report file (defaults to 'report.html')")
 	cmd.Flags().Bool("no-explorer", false, "Exclude the explorer graph tab (smaller bundle size)")
+	addSquashFlag(cmd)
+	addPolicyFlag(cmd)
+	addFailOnFlag(cmd)
 	return cmd
 }
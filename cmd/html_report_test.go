@@ -26,7 +26,7 @@ func TestGenerateHTMLReport_UnchangedLeftRight(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateHTMLReport(commits, nil, false,
+	report, _, err := generateHTMLReport(commits, nil, reportRules{}, false,
 		"../sample-specs/petstorev3.json",
 		"../sample-specs/petstorev3.json",
 	)
@@ -42,7 +42,7 @@ func TestGenerateHTMLReport_LeftRightIncludesSanitizedPaths(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateHTMLReport(commits, nil, true,
+	report, _, err := generateHTMLReport(commits, nil, reportRules{}, true,
 		"../sample-specs/petstorev3-original.json",
 		"../sample-specs/petstorev3.json",
 	)
@@ -66,7 +66,7 @@ func TestGenerateHTMLReport_LeftRightPreservesGitRefPaths(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateHTMLReport(commits, nil, true,
+	report, _, err := generateHTMLReport(commits, nil, reportRules{}, true,
 		"HEAD~1:openapi.yaml",
 		"HEAD:openapi.yaml",
 	)
@@ -85,7 +85,7 @@ func TestGenerateHTMLReport_GitHistoryBasePathUsesRevisionScopedSiblingRefs(t *t
 	require.NoError(t, err)
 	require.NotEmpty(t, commits)
 
-	report, err := generateHTMLReport(commits, nil, true, repoDir, fileName)
+	report, _, err := generateHTMLReport(commits, nil, reportRules{}, true, repoDir, fileName)
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -112,7 +112,7 @@ func TestGenerateHTMLReport_LeftRightSanitizesURLPaths(t *testing.T) {
 	commits, err := loadLeftRightCommits(leftURL, rightURL, summaryOpts{noColor: true})
 	require.NoError(t, err)
 
-	report, err := generateHTMLReport(commits, nil, true, leftURL, rightURL)
+	report, _, err := generateHTMLReport(commits, nil, reportRules{}, true, leftURL, rightURL)
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -124,7 +124,7 @@ func TestGenerateHTMLReport_LeftRightSanitizesURLPaths(t *testing.T) {
 }
 
 func TestBuildHTMLReportItems_AllCommitsFail(t *testing.T) {
-	items, err := buildHTMLReportItems([]*model.Commit{makeSwagger2Commit(t)}, nil)
+	items, err := buildHTMLReportItems([]*model.Commit{makeSwagger2Commit(t)}, nil, reportRules{})
 	require.Error(t, err)
 	assert.Nil(t, items)
 	assert.Contains(t, err.Error(), "all 1 commits failed to build report items")
@@ -138,7 +138,7 @@ func TestBuildHTMLReportItems_LeftRightModelBuildErrorNamesFiles(t *testing.T) {
 
 	var buildErr error
 	stderr := captureStderr(t, func() {
-		_, buildErr = buildHTMLReportItems(commits, nil)
+		_, buildErr = buildHTMLReportItems(commits, nil, reportRules{})
 	})
 
 	require.Error(t, buildErr)
@@ -162,7 +162,7 @@ func TestBuildHTMLReportItems_PartialFailureReturnsPartialResults(t *testing.T)
 
 	var items []*htmlReport.ReportItem
 	stderr := captureStderr(t, func() {
-		items, err = buildHTMLReportItems(mixed, nil)
+		items, err = buildHTMLReportItems(mixed, nil, reportRules{})
 	})
 	require.NoError(t, err)
 	assert.NotEmpty(t, items, "should return successfully built items despite partial failure")
@@ -181,7 +181,7 @@ func TestGenerateHTMLReport_PartialFailureReturnsPartialReport(t *testing.T) {
 
 	mixed := append(commits, makeSwagger2Commit(t))
 
-	report, err := generateHTMLReport(mixed, nil, false,
+	report, _, err := generateHTMLReport(mixed, nil, reportRules{}, false,
 		"../sample-specs/petstorev3-original.json",
 		"../sample-specs/petstorev3.json",
 	)
@@ -192,7 +192,7 @@ func TestGenerateHTMLReport_PartialFailureReturnsPartialReport(t *testing.T) {
 func TestHTMLReportCommand_LeftRightFiles(t *testing.T) {
 	reportFile := filepath.Join(t.TempDir(), "report.html")
 	cmd := testRootCmd(GetHTMLReportCommand(),
-		"--no-logo", "--no-color",
+		"--no-logo", "--no-color", "--fail-on", "none",
 		"--report-file", reportFile,
 		"../sample-specs/petstorev3-original.json",
 		"../sample-specs/petstorev3.json",
@@ -226,7 +226,7 @@ func TestBuildHTMLReportItems_PreservesSchemaNodesInDocumentTree(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, commits)
 
-	items, err := buildHTMLReportItems(commits, nil)
+	items, err := buildHTMLReportItems(commits, nil, reportRules{})
 	require.NoError(t, err)
 	require.NotEmpty(t, items)
 
@@ -268,7 +268,7 @@ func TestBuildHTMLReportItems_SplitsStandardAndChangeExplorerGraphs(t *testing.T
 	require.NoError(t, err)
 	require.NotEmpty(t, commits)
 
-	items, err := buildHTMLReportItems(commits, nil)
+	items, err := buildHTMLReportItems(commits, nil, reportRules{})
 	require.NoError(t, err)
 	require.NotEmpty(t, items)
 
@@ -318,7 +318,7 @@ func TestBuildHTMLReportItems_GitHistoryRewritesGraphOriginsToRepoRelativePaths(
 	require.NoError(t, err)
 	require.NotEmpty(t, commits)
 
-	items, err := buildHTMLReportItems(commits, nil)
+	items, err := buildHTMLReportItems(commits, nil, reportRules{})
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 
@@ -347,7 +347,7 @@ func TestBuildHTMLReportItems_ComposedSchemaTitleRemovalPreservesPropertyLabel(t
 	require.NoError(t, err)
 	require.Len(t, commits, 1)
 
-	items, err := buildHTMLReportItems(commits, nil)
+	items, err := buildHTMLReportItems(commits, nil, reportRules{})
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 
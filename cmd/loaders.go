@@ -155,6 +155,9 @@ func loadGitHubCommitsDetailed(rawURL string, opts summaryOpts, breakingConfig *
 	if opts.latest && len(commits) > 1 {
 		commits = commits[:1]
 	}
+	if opts.squash {
+		commits = squashCommits(commits)
+	}
 	return &loadedHistoryResult{
 		Commits:        commits,
 		SkippedCommits: result.SkippedCommits,
@@ -222,6 +225,9 @@ func loadGitHistoryCommitsDetailed(gitPath, filePath string, opts summaryOpts, b
 	if opts.latest {
 		commits = commits[:1]
 	}
+	if opts.squash {
+		commits = squashCommits(commits)
+	}
 	return &loadedHistoryResult{
 		Commits:        commits,
 		SkippedCommits: result.SkippedCommits,
@@ -240,6 +246,9 @@ func loadGitHistoryCommits(gitPath, filePath string, opts summaryOpts, breakingC
 }
 
 func loadLeftRightCommits(left, right string, opts summaryOpts) ([]*model.Commit, error) {
+	if opts.squash {
+		return nil, errSquashLeftRight
+	}
 	commit, err := buildLeftRightCommitAndSources(left, right, opts)
 	if err != nil {
 		return nil, err
@@ -24,15 +24,19 @@ func printMarkdownReportUsage(palette terminal.Palette) {
 		palette)
 }
 
-// renderCommitMarkdown runs the doctor changerator on a single commit and returns markdown.
-// Returns (markdown, nil) on success, ("", nil) for no changes, ("", err) for failures.
-func renderCommitMarkdown(commit *model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig) (string, error) {
+// renderCommitMarkdown runs the doctor changerator on a single commit and returns markdown
+// without the doctor heading, prefixed with the policy findings, suppressed breaking
+// changes and change severities, along with the severities and policy findings of the commit.
+// Returns (markdown, outcome, nil) on success, ("", nil, nil) for no changes, ("", nil, err) for failures.
+func renderCommitMarkdown(commit *model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig,
+	rules reportRules,
+) (string, *changeOutcome, error) {
 	result, err := runChangerator(commit, breakingConfig)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	if result == nil {
-		return "", nil
+		return "", nil, nil
 	}
 	defer result.Release()
 	deduplicatedChanges := result.Changerator.DeduplicateChanges()
@@ -45,9 +49,18 @@ func renderCommitMarkdown(commit *model.Commit, breakingConfig *whatChangedModel
 		Config:              renderer.DefaultRenderConfig(),
 	})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	return markdown, nil
+	findings, severities, err := policyForResult(rules, commit, result)
+	if err != nil {
+		return "", nil, err
+	}
+	outcome := &changeOutcome{}
+	outcome.add(severities.Counts(deduplicatedChanges), findings)
+	// Strip the doctor heading prefix
+	markdown = strings.TrimPrefix(markdown, "# What Changed Report\n\n")
+	return renderPolicyFindingsMarkdown(findings) + renderSuppressionsMarkdown(result.Suppressions) +
+		renderSeveritiesMarkdown(severities, deduplicatedChanges) + markdown, outcome, nil
 }
 
 // generateUnifiedDiff produces a unified diff between original and modified strings.
@@ -74,20 +87,25 @@ func isSyntheticLeftRightCommit(commit *model.Commit) bool {
 	return commit.Synthetic
 }
 
-// generateMarkdownReport assembles markdown from all commits.
-// Returns (nil, nil) if no commits produce changes and no errors occurred.
-// Returns (nil, err) if every candidate commit fails to render.
-// Returns (bytes, nil) when at least one commit renders successfully; failed
+// generateMarkdownReport assembles markdown from all commits. Each commit lists
+// its change severities and policy findings, which are also returned as the
+// outcome of the report.
+// Returns (nil, nil, nil) if no commits produce changes and no errors occurred.
+// Returns (nil, nil, err) if every candidate commit fails to render.
+// Returns the report when at least one commit renders successfully; failed
 // commits are logged to stderr and skipped.
-func generateMarkdownReport(commits []*model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig, includeDiff bool) ([]byte, error) {
+func generateMarkdownReport(commits []*model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig,
+	rules reportRules, includeDiff bool,
+) ([]byte, *changeOutcome, error) {
 	var sb strings.Builder
+	outcome := &changeOutcome{}
 	successCount := 0
 	var renderErrors []error
 	headerWritten := false
 	includeCommitMetadata := true
 
 	for i, commit := range commits {
-		markdown, err := renderCommitMarkdown(commit, breakingConfig)
+		markdown, commitOutcome, err := renderCommitMarkdown(commit, breakingConfig, rules)
 		if err != nil {
 			emitCommitWarning(commit, err)
 			renderErrors = append(renderErrors, wrapCommitError(commit, err))
@@ -113,15 +131,14 @@ func generateMarkdownReport(commits []*model.Commit, breakingConfig *whatChanged
 			sb.WriteString(fmt.Sprintf("## Commit %d: %s\n\n", i+1, commit.Message))
 			sb.WriteString(fmt.Sprintf("- **Hash**: %s\n", commit.Hash))
 			sb.WriteString(fmt.Sprintf("- **Author**: %s\n", commit.Author))
-			sb.WriteString(fmt.Sprintf("- **Date**: %s\n\n", commit.CommitDate.Format(time.RFC3339)))
+			sb.WriteString(fmt.Sprintf("- **Date**: %s\n", commit.CommitDate.Format(time.RFC3339)))
+			sb.WriteString(renderSquashedCommits(commit.Squashed, true, summaryStyles{}))
+			sb.WriteString("\n")
 		}
 
-		// Strip the doctor heading prefix
-		content := markdown
-		if strings.HasPrefix(content, "# What Changed Report\n\n") {
-			content = strings.TrimPrefix(content, "# What Changed Report\n\n")
-		}
-		sb.WriteString(content)
+		outcome.add(commitOutcome.severities, commitOutcome.findings)
+
+		sb.WriteString(markdown)
 		sb.WriteString("\n")
 
 		if includeDiff && len(commit.OldData) > 0 && len(commit.Data) > 0 {
@@ -140,15 +157,15 @@ func generateMarkdownReport(commits []*model.Commit, breakingConfig *whatChanged
 	}
 
 	if successCount == 0 && len(renderErrors) > 0 {
-		return nil, fmt.Errorf("all %d commits failed to render: %w", len(renderErrors), errors.Join(renderErrors...))
+		return nil, nil, fmt.Errorf("all %d commits failed to render: %w", len(renderErrors), errors.Join(renderErrors...))
 	}
 	if len(renderErrors) > 0 {
 		fmt.Fprintf(os.Stderr, "warning: %d commits failed to render\n", len(renderErrors))
 	}
 	if successCount == 0 && len(renderErrors) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
-	return []byte(sb.String()), nil
+	return []byte(sb.String()), outcome, nil
 }
 
 func GetMarkdownReportCommand() *cobra.Command {
@@ -168,9 +185,13 @@ func GetMarkdownReportCommand() *cobra.Command {
 			}
 			reportFile, _ := cmd.Flags().GetString("report-file")
 			includeDiff, _ := cmd.Flags().GetBool("include-diff")
+			failOn, err := readFailOnFlag(cmd)
+			if err != nil {
+				return err
+			}
 			styles := commandStylesFor(input.Opts.palette)
 
-			report, err := generateMarkdownReport(input.Commits, input.BreakingConfig, includeDiff)
+			report, outcome, err := generateMarkdownReport(input.Commits, input.BreakingConfig, input.Opts.rules, includeDiff)
 			if err != nil {
 				return err
 			}
@@ -179,11 +200,17 @@ func GetMarkdownReportCommand() *cobra.Command {
 				printNoChangesText()
 				return nil
 			}
-			return writeReportFile(reportFile, report, styles)
+			if err := writeReportFile(reportFile, report, styles); err != nil {
+				return err
+			}
+			return outcome.failOn(failOn)
 		},
 	}
 	addTerminalThemeFlags(cmd)
 	cmd.Flags().StringP("report-file", "", "report.md", "The name of the Markdown report file (defaults to 'report.md')")
 	cmd.Flags().Bool("include-diff", false, "Include a collapsible unified diff of the raw spec for each commit")
+	addSquashFlag(cmd)
+	addPolicyFlag(cmd)
+	addFailOnFlag(cmd)
 	return cmd
 }
@@ -24,7 +24,7 @@ func TestMarkdownReport_UnchangedLeftRight(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateMarkdownReport(commits, nil, false)
+	report, _, err := generateMarkdownReport(commits, nil, reportRules{}, false)
 	assert.NoError(t, err)
 	assert.Nil(t, report)
 }
@@ -51,7 +51,7 @@ paths: {}`
 		OldDocument: doc,
 	}
 
-	report, err := generateMarkdownReport([]*model.Commit{commit}, nil, false)
+	report, _, err := generateMarkdownReport([]*model.Commit{commit}, nil, reportRules{}, false)
 	assert.Error(t, err)
 	assert.Nil(t, report)
 	assert.Contains(t, err.Error(), "all 1 commits failed to render")
@@ -65,7 +65,7 @@ func TestMarkdownReport_LeftRightModelBuildErrorNamesFiles(t *testing.T) {
 
 	var report []byte
 	stderr := captureStderr(t, func() {
-		report, err = generateMarkdownReport(commits, nil, false)
+		report, _, err = generateMarkdownReport(commits, nil, reportRules{}, false)
 	})
 
 	require.Error(t, err)
@@ -86,7 +86,7 @@ func TestMarkdownReport_HeadingStripping(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateMarkdownReport(commits, nil, false)
+	report, _, err := generateMarkdownReport(commits, nil, reportRules{}, false)
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -103,7 +103,7 @@ func TestMarkdownReport_SingleCommitLeftRight(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateMarkdownReport(commits, nil, false)
+	report, _, err := generateMarkdownReport(commits, nil, reportRules{}, false)
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -122,7 +122,7 @@ func TestMarkdownReport_UsesDeduplicatedCounts(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateMarkdownReport(commits, nil, false)
+	report, _, err := generateMarkdownReport(commits, nil, reportRules{}, false)
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -142,7 +142,7 @@ func TestMarkdownReport_UsesDeduplicatedObjectStats(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateMarkdownReport(commits, nil, false)
+	report, _, err := generateMarkdownReport(commits, nil, reportRules{}, false)
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -165,7 +165,7 @@ func TestMarkdownReport_LeftRightOmitsSyntheticCommitMetadata(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	report, err := generateMarkdownReport(commits, nil, false)
+	report, _, err := generateMarkdownReport(commits, nil, reportRules{}, false)
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -214,7 +214,7 @@ paths: {}`
 	var report []byte
 	var reportErr error
 	stderr := captureStderr(t, func() {
-		report, reportErr = generateMarkdownReport(mixed, nil, false)
+		report, _, reportErr = generateMarkdownReport(mixed, nil, reportRules{}, false)
 	})
 	assert.NoError(t, reportErr)
 	assert.NotNil(t, report, "should return partial report with successfully rendered commits")
@@ -232,7 +232,7 @@ func TestMarkdownReport_IncludeDiffFlag(t *testing.T) {
 	require.NoError(t, err)
 
 	// Without --include-diff: no diff block
-	reportNoDiff, err := generateMarkdownReport(commits, nil, false)
+	reportNoDiff, _, err := generateMarkdownReport(commits, nil, reportRules{}, false)
 	require.NoError(t, err)
 	require.NotNil(t, reportNoDiff)
 	assert.NotContains(t, string(reportNoDiff), "<details>")
@@ -247,7 +247,7 @@ func TestMarkdownReport_IncludeDiffFlag(t *testing.T) {
 	require.NoError(t, err)
 
 	// With --include-diff: collapsible diff block present
-	reportWithDiff, err := generateMarkdownReport(commits, nil, true)
+	reportWithDiff, _, err := generateMarkdownReport(commits, nil, reportRules{}, true)
 	require.NoError(t, err)
 	require.NotNil(t, reportWithDiff)
 	assert.Contains(t, string(reportWithDiff), "<details>")
@@ -273,7 +273,7 @@ func TestMarkdownReportCommand_TooManyArgs(t *testing.T) {
 
 func TestMarkdownReportCommand_LeftRightFiles(t *testing.T) {
 	cmd := testRootCmd(GetMarkdownReportCommand(),
-		"--no-logo", "--no-color",
+		"--no-logo", "--no-color", "--fail-on", "none",
 		"--report-file", filepath.Join(t.TempDir(), "report.md"),
 		"../sample-specs/petstorev3-original.json",
 		"../sample-specs/petstorev3.json",
@@ -0,0 +1,451 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pb33f/doctor/terminal"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/changecounts"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+)
+
+const (
+	mergeSideOurs   = "ours"
+	mergeSideTheirs = "theirs"
+)
+
+// mergeChangeKey identifies the location a change applies to, independent of
+// what the change actually was.
+type mergeChangeKey struct {
+	path     string
+	property string
+}
+
+// mergeChangeSignature identifies what a change did at a location. Two sides
+// that produce the same signature for the same key made the same edit.
+type mergeChangeSignature struct {
+	kind     int
+	original string
+	new      string
+}
+
+func mergeKeyFor(change *model.HashedChange) mergeChangeKey {
+	return mergeChangeKey{path: changePath(change), property: changeProperty(change)}
+}
+
+func mergeSignatureFor(change *model.HashedChange) mergeChangeSignature {
+	original := changeOriginalEncoded(change)
+	if original == "" {
+		original = changeOriginal(change)
+	}
+	newValue := changeNewEncoded(change)
+	if newValue == "" {
+		newValue = changeNew(change)
+	}
+	return mergeChangeSignature{kind: changeKind(change), original: original, new: newValue}
+}
+
+// runMergeCheck compares ours and theirs against base and builds a three-way report.
+func runMergeCheck(base, ours, theirs string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig) (*model.MergeCheckReport, error) {
+	oursReport, err := runLeftRightReport(base, ours, opts, breakingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("comparing base against ours: %w", err)
+	}
+	theirsReport, err := runLeftRightReport(base, theirs, opts, breakingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("comparing base against theirs: %w", err)
+	}
+
+	report := buildMergeCheckReport(oursReport, theirsReport)
+	report.BasePath = sourceLabelForReport(base)
+	report.OursPath = sourceLabelForReport(ours)
+	report.TheirsPath = sourceLabelForReport(theirs)
+	report.DateGenerated = time.Now().Format(time.RFC3339)
+	return report, nil
+}
+
+// buildMergeCheckReport computes conflicts and the combined breaking set for two
+// flat reports that share a common base. Either report may be nil (no changes).
+func buildMergeCheckReport(ours, theirs *model.FlatReport) *model.MergeCheckReport {
+	var oursChanges, theirsChanges []*model.HashedChange
+	if ours != nil {
+		oursChanges = ours.Changes
+	}
+	if theirs != nil {
+		theirsChanges = theirs.Changes
+	}
+	return &model.MergeCheckReport{
+		Ours:      ours,
+		Theirs:    theirs,
+		Conflicts: findMergeConflicts(oursChanges, theirsChanges),
+		Breaking:  combineBreakingChanges(oursChanges, theirsChanges),
+	}
+}
+
+// findMergeConflicts returns every path + property that both sides changed, where
+// the two sides did not make the same change, and every removal on one side of
+// an object that the other side changed something inside. Each conflict lists
+// all of the changes each side made there that the other side did not.
+// Identical edits on both sides merge cleanly and are not reported.
+func findMergeConflicts(ours, theirs []*model.HashedChange) []*model.MergeConflict {
+	oursByKey, oursKeys := groupMergeChanges(ours)
+	theirsByKey, _ := groupMergeChanges(theirs)
+
+	byKey := make(map[mergeChangeKey]*model.MergeConflict)
+	var conflicts []*model.MergeConflict
+	add := func(key mergeChangeKey, oursChanges, theirsChanges []*model.HashedChange) {
+		conflict, ok := byKey[key]
+		if !ok {
+			conflict = &model.MergeConflict{Path: key.path, Property: key.property}
+			byKey[key] = conflict
+			conflicts = append(conflicts, conflict)
+		}
+		conflict.Ours = appendNewMergeChanges(conflict.Ours, oursChanges)
+		conflict.Theirs = appendNewMergeChanges(conflict.Theirs, theirsChanges)
+	}
+
+	for _, key := range oursKeys {
+		oursChanges := unmatchedMergeChanges(oursByKey[key], theirsByKey[key])
+		theirsChanges := unmatchedMergeChanges(theirsByKey[key], oursByKey[key])
+		if len(oursChanges) > 0 && len(theirsChanges) > 0 {
+			add(key, oursChanges, theirsChanges)
+		}
+	}
+	for _, change := range ours {
+		if inside := changesInsideRemoval(change, theirs, theirsByKey); len(inside) > 0 {
+			add(mergeKeyFor(change), []*model.HashedChange{change}, inside)
+		}
+	}
+	for _, change := range theirs {
+		if inside := changesInsideRemoval(change, ours, oursByKey); len(inside) > 0 {
+			add(mergeKeyFor(change), inside, []*model.HashedChange{change})
+		}
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].Path != conflicts[j].Path {
+			return conflicts[i].Path < conflicts[j].Path
+		}
+		return conflicts[i].Property < conflicts[j].Property
+	})
+	return conflicts
+}
+
+// appendNewMergeChanges appends the changes that are not in the list already.
+func appendNewMergeChanges(list, changes []*model.HashedChange) []*model.HashedChange {
+	for _, change := range changes {
+		if !slices.Contains(list, change) {
+			list = append(list, change)
+		}
+	}
+	return list
+}
+
+// groupMergeChanges indexes changes by key, returning the keys in the order they
+// were first seen.
+func groupMergeChanges(changes []*model.HashedChange) (map[mergeChangeKey][]*model.HashedChange, []mergeChangeKey) {
+	byKey := make(map[mergeChangeKey][]*model.HashedChange)
+	var keys []mergeChangeKey
+	for _, change := range changes {
+		if change == nil || change.Change == nil {
+			continue
+		}
+		key := mergeKeyFor(change)
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], change)
+	}
+	return byKey, keys
+}
+
+// unmatchedMergeChanges returns the changes that the other side did not also
+// make at the same key.
+func unmatchedMergeChanges(changes, other []*model.HashedChange) []*model.HashedChange {
+	signatures := make(map[mergeChangeSignature]struct{}, len(other))
+	for _, change := range other {
+		signatures[mergeSignatureFor(change)] = struct{}{}
+	}
+	var unmatched []*model.HashedChange
+	for _, change := range changes {
+		if _, ok := signatures[mergeSignatureFor(change)]; !ok {
+			unmatched = append(unmatched, change)
+		}
+	}
+	return unmatched
+}
+
+// changesInsideRemoval returns the other side's changes that lie inside the
+// object a removal took out, or nil when the change is not a removal or the
+// other side made the same removal.
+func changesInsideRemoval(removal *model.HashedChange, other []*model.HashedChange, otherByKey map[mergeChangeKey][]*model.HashedChange) []*model.HashedChange {
+	if removal == nil || removal.Change == nil {
+		return nil
+	}
+	if changecounts.CategoryOf(removal.Change) != changecounts.CategoryRemoval || changeProperty(removal) == "" {
+		return nil
+	}
+	if len(unmatchedMergeChanges([]*model.HashedChange{removal}, otherByKey[mergeKeyFor(removal)])) == 0 {
+		return nil
+	}
+	path, property := changePath(removal), changeProperty(removal)
+	prefixes := []string{path + "." + property, path + "['" + property + "']"}
+	var inside []*model.HashedChange
+	for _, change := range other {
+		if change == nil || change.Change == nil {
+			continue
+		}
+		if slices.ContainsFunc(prefixes, func(prefix string) bool { return isMergePathWithin(changePath(change), prefix) }) {
+			inside = append(inside, change)
+		}
+	}
+	return inside
+}
+
+// isMergePathWithin reports whether path is prefix itself or a path below it.
+func isMergePathWithin(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	rest := path[len(prefix):]
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
+
+// combineBreakingChanges merges the breaking changes from both sides, collapsing
+// identical changes made on both sides into a single entry.
+func combineBreakingChanges(ours, theirs []*model.HashedChange) []*model.MergeBreakingChange {
+	type breakingKey struct {
+		key       mergeChangeKey
+		signature mergeChangeSignature
+	}
+	var combined []*model.MergeBreakingChange
+	index := make(map[breakingKey]*model.MergeBreakingChange)
+
+	add := func(side string, changes []*model.HashedChange) {
+		for _, change := range changes {
+			if change == nil || change.Change == nil || !change.Breaking {
+				continue
+			}
+			key := breakingKey{key: mergeKeyFor(change), signature: mergeSignatureFor(change)}
+			if existing, ok := index[key]; ok {
+				if existing.Sides[len(existing.Sides)-1] != side {
+					existing.Sides = append(existing.Sides, side)
+				}
+				continue
+			}
+			entry := &model.MergeBreakingChange{Sides: []string{side}, Change: change}
+			index[key] = entry
+			combined = append(combined, entry)
+		}
+	}
+	add(mergeSideOurs, ours)
+	add(mergeSideTheirs, theirs)
+
+	sort.SliceStable(combined, func(i, j int) bool {
+		return compareHashedChanges(combined[i].Change, combined[j].Change) < 0
+	})
+	return combined
+}
+
+func countBreakingFlatChanges(report *model.FlatReport) (total, breaking int) {
+	if report == nil {
+		return 0, 0
+	}
+	for _, change := range report.Changes {
+		if change == nil || change.Change == nil {
+			continue
+		}
+		total++
+		if change.Breaking {
+			breaking++
+		}
+	}
+	return total, breaking
+}
+
+func describeHashedChange(change *model.HashedChange) string {
+	if change == nil || change.Change == nil {
+		return "-"
+	}
+	signature := mergeSignatureFor(change)
+	switch change.ChangeType {
+	case whatChangedModel.Modified:
+		return fmt.Sprintf("modified %q -> %q", signature.original, signature.new)
+	case whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded:
+		if signature.new != "" {
+			return fmt.Sprintf("added %q", signature.new)
+		}
+	case whatChangedModel.PropertyRemoved, whatChangedModel.ObjectRemoved:
+		if signature.original != "" {
+			return fmt.Sprintf("removed %q", signature.original)
+		}
+	}
+	return changecounts.CategoryOf(change.Change).Label()
+}
+
+// renderMergeCheck renders a merge-check report as terminal text.
+func renderMergeCheck(report *model.MergeCheckReport, styles summaryStyles) string {
+	var sb strings.Builder
+
+	sb.WriteString(styles.title.Render(fmt.Sprintf("Merge check: %s -> %s / %s", report.BasePath, report.OursPath, report.TheirsPath)))
+	sb.WriteString("\n\n")
+
+	oursTotal, oursBreaking := countBreakingFlatChanges(report.Ours)
+	theirsTotal, theirsBreaking := countBreakingFlatChanges(report.Theirs)
+	sb.WriteString(fmt.Sprintf("  Ours (%s): %s changes, %s breaking\n", report.OursPath,
+		styles.title.Render(fmt.Sprint(oursTotal)), styles.breaking.Render(fmt.Sprint(oursBreaking))))
+	sb.WriteString(fmt.Sprintf("  Theirs (%s): %s changes, %s breaking\n\n", report.TheirsPath,
+		styles.title.Render(fmt.Sprint(theirsTotal)), styles.breaking.Render(fmt.Sprint(theirsBreaking))))
+
+	if len(report.Conflicts) == 0 {
+		sb.WriteString(styles.addition.Render("No conflicting changes"))
+		sb.WriteString("\n\n")
+	} else {
+		sb.WriteString(styles.breaking.Render(fmt.Sprintf("Conflicting changes (%d)", len(report.Conflicts))))
+		sb.WriteString("\n")
+		for _, conflict := range report.Conflicts {
+			location := conflict.Path
+			if conflict.Property != "" {
+				location += " " + styles.detail.Render(conflict.Property)
+			}
+			sb.WriteString("  - " + location + "\n")
+			writeMergeConflictSide(&sb, "ours:  ", conflict.Ours)
+			writeMergeConflictSide(&sb, "theirs:", conflict.Theirs)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(report.Breaking) == 0 {
+		sb.WriteString(styles.stat.Render("No breaking changes on either side"))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+	sb.WriteString(styles.breaking.Render(fmt.Sprintf("Combined breaking changes (%d)", len(report.Breaking))))
+	sb.WriteString("\n")
+	for _, item := range report.Breaking {
+		property := changeProperty(item.Change)
+		if property != "" {
+			property = " " + styles.detail.Render(property)
+		}
+		sb.WriteString(fmt.Sprintf("  - [%s] %s%s (%s)\n", strings.Join(item.Sides, ", "),
+			changePath(item.Change), property, changecounts.CategoryOf(item.Change.Change).Label()))
+	}
+	return sb.String()
+}
+
+// mergeCheckOutcome decides whether a merge check fails: on conflicting changes,
+// and like every other command, on the changes and policy findings of either
+// side at or above the --fail-on threshold.
+func mergeCheckOutcome(report *model.MergeCheckReport, failOn string) error {
+	outcome := &changeOutcome{}
+	for _, side := range []*model.FlatReport{report.Ours, report.Theirs} {
+		if side != nil {
+			outcome.add(side.Severities, side.PolicyFindings)
+		}
+	}
+	var conflictErr error
+	if len(report.Conflicts) > 0 {
+		conflictErr = errors.New("conflicting changes discovered")
+	}
+	return errors.Join(conflictErr, outcome.failOn(failOn))
+}
+
+// writeMergeConflictSide writes the changes one side made at a conflict, one per line.
+func writeMergeConflictSide(sb *strings.Builder, label string, changes []*model.HashedChange) {
+	for i, change := range changes {
+		if i > 0 {
+			label = strings.Repeat(" ", len(label))
+		}
+		sb.WriteString("      " + label + " " + describeHashedChange(change) + "\n")
+	}
+}
+
+func printMergeCheckUsage(palette terminal.Palette) {
+	title := styleWithForeground(palette.Primary).Bold(true)
+	desc := styleWithForeground(palette.Muted)
+	cmdStyle := styleWithForeground(palette.Secondary).Bold(true)
+
+	fmt.Print(title.Render("How to use the "))
+	fmt.Print(cmdStyle.Render("merge-check"))
+	fmt.Println(title.Render(" command:"))
+	fmt.Println()
+	fmt.Println(desc.Render("The merge-check command compares two revisions against a shared base and reports\nchanges that conflict, plus the combined set of breaking changes."))
+	fmt.Println()
+	fmt.Println("  openapi-changes merge-check [options] <base> <ours> <theirs>")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes merge-check BASE:api.yaml main:api.yaml feature:api.yaml"))
+	fmt.Printf("  %s\n", cmdStyle.Render("openapi-changes merge-check ./base.yaml ./ours.yaml ./theirs.yaml"))
+	fmt.Println()
+	fmt.Println("Use --help for full flag details.")
+}
+
+// GetMergeCheckCommand returns the cobra command for three-way merge checks.
+func GetMergeCheckCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "merge-check",
+		Short:        "Check two revisions against a shared base for conflicting changes",
+		Long: "Compare ours and theirs against a common base revision and report changes that conflict " +
+			"(the same path and property changed differently on each side, or an object removed on one side and changed " +
+			"inside on the other), along with the combined breaking changes.",
+		Example: "openapi-changes merge-check BASE:api.yaml main:api.yaml feature:api.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, configFlag, err := readCommonFlags(cmd)
+			if err != nil {
+				return err
+			}
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if !jsonOutput {
+				maybePrintBanner(cmd, opts.palette)
+			}
+
+			if len(args) == 0 {
+				printMergeCheckUsage(opts.palette)
+				return nil
+			}
+			if len(args) != 3 {
+				return fmt.Errorf("merge-check requires exactly three (3) arguments: <base> <ours> <theirs>")
+			}
+
+			failOn, err := readFailOnFlag(cmd)
+			if err != nil {
+				return err
+			}
+			breakingConfig, rules, err := readReportRules(cmd, configFlag, opts.palette)
+			if err != nil {
+				return err
+			}
+			opts.rules = rules
+
+			report, err := runMergeCheck(args[0], args[1], args[2], opts, breakingConfig)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if err := printReportJSON(report); err != nil {
+					return err
+				}
+			} else {
+				fmt.Print(renderMergeCheck(report, summaryStylesForPalette(opts.palette)))
+			}
+
+			return mergeCheckOutcome(report, failOn)
+		},
+	}
+	addTerminalThemeFlags(cmd)
+	cmd.Flags().Bool("json", false, "Print the merge-check report as JSON")
+	addPolicyFlag(cmd)
+	addFailOnFlag(cmd)
+	return cmd
+}
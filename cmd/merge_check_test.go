@@ -0,0 +1,239 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/severity"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeTestChange(path, property string, changeType int, original, newValue string, breaking bool) *model.HashedChange {
+	return &model.HashedChange{Change: &wcModel.Change{
+		Path:       path,
+		Property:   property,
+		ChangeType: changeType,
+		Original:   original,
+		New:        newValue,
+		Breaking:   breaking,
+	}}
+}
+
+func TestFindMergeConflicts_DifferentEditsToSameProperty(t *testing.T) {
+	ours := []*model.HashedChange{
+		mergeTestChange("$.info", "title", wcModel.Modified, "pets", "pets api", false),
+	}
+	theirs := []*model.HashedChange{
+		mergeTestChange("$.info", "title", wcModel.Modified, "pets", "pet store", false),
+	}
+
+	conflicts := findMergeConflicts(ours, theirs)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "$.info", conflicts[0].Path)
+	assert.Equal(t, "title", conflicts[0].Property)
+	assert.Equal(t, "pets api", conflicts[0].Ours[0].New)
+	assert.Equal(t, "pet store", conflicts[0].Theirs[0].New)
+}
+
+func TestFindMergeConflicts_IdenticalEditsAreNotConflicts(t *testing.T) {
+	ours := []*model.HashedChange{
+		mergeTestChange("$.info", "version", wcModel.Modified, "1.0", "1.1", false),
+	}
+	theirs := []*model.HashedChange{
+		mergeTestChange("$.info", "version", wcModel.Modified, "1.0", "1.1", false),
+	}
+
+	assert.Empty(t, findMergeConflicts(ours, theirs))
+}
+
+func TestFindMergeConflicts_RemovedVersusModified(t *testing.T) {
+	ours := []*model.HashedChange{
+		mergeTestChange("$.paths['/pets']", "get", wcModel.ObjectRemoved, "get", "", true),
+	}
+	theirs := []*model.HashedChange{
+		mergeTestChange("$.paths['/pets']", "get", wcModel.Modified, "", "", false),
+		mergeTestChange("$.paths['/orders']", "post", wcModel.ObjectAdded, "", "post", false),
+	}
+
+	conflicts := findMergeConflicts(ours, theirs)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "$.paths['/pets']", conflicts[0].Path)
+	assert.Equal(t, wcModel.ObjectRemoved, conflicts[0].Ours[0].ChangeType)
+	assert.Equal(t, wcModel.Modified, conflicts[0].Theirs[0].ChangeType)
+}
+
+func TestFindMergeConflicts_DisjointChanges(t *testing.T) {
+	ours := []*model.HashedChange{
+		mergeTestChange("$.info", "title", wcModel.Modified, "a", "b", false),
+	}
+	theirs := []*model.HashedChange{
+		mergeTestChange("$.info", "description", wcModel.Modified, "a", "b", false),
+	}
+
+	assert.Empty(t, findMergeConflicts(ours, theirs))
+}
+
+func TestCombineBreakingChanges_DeduplicatesAcrossSides(t *testing.T) {
+	shared := mergeTestChange("$.paths['/pets']", "get", wcModel.ObjectRemoved, "get", "", true)
+	ours := []*model.HashedChange{
+		shared,
+		mergeTestChange("$.info", "title", wcModel.Modified, "a", "b", false),
+	}
+	theirs := []*model.HashedChange{
+		mergeTestChange("$.paths['/pets']", "get", wcModel.ObjectRemoved, "get", "", true),
+		mergeTestChange("$.components.schemas['Pet']", "required", wcModel.PropertyAdded, "", "name", true),
+	}
+
+	combined := combineBreakingChanges(ours, theirs)
+	require.Len(t, combined, 2)
+	assert.Equal(t, "$.components.schemas['Pet']", combined[0].Change.Path)
+	assert.Equal(t, []string{mergeSideTheirs}, combined[0].Sides)
+	assert.Equal(t, "$.paths['/pets']", combined[1].Change.Path)
+	assert.Equal(t, []string{mergeSideOurs, mergeSideTheirs}, combined[1].Sides)
+}
+
+func TestBuildMergeCheckReport_NilSides(t *testing.T) {
+	theirs := &model.FlatReport{Changes: []*model.HashedChange{
+		mergeTestChange("$.info", "title", wcModel.Modified, "a", "b", true),
+	}}
+
+	report := buildMergeCheckReport(nil, theirs)
+	assert.Empty(t, report.Conflicts)
+	require.Len(t, report.Breaking, 1)
+	assert.Equal(t, []string{mergeSideTheirs}, report.Breaking[0].Sides)
+}
+
+func TestRenderMergeCheck_ListsConflictsAndBreaking(t *testing.T) {
+	ours := &model.FlatReport{Changes: []*model.HashedChange{
+		mergeTestChange("$.info", "title", wcModel.Modified, "pets", "pets api", false),
+	}}
+	theirs := &model.FlatReport{Changes: []*model.HashedChange{
+		mergeTestChange("$.info", "title", wcModel.Modified, "pets", "pet store", false),
+		mergeTestChange("$.paths['/pets']", "get", wcModel.ObjectRemoved, "get", "", true),
+	}}
+	report := buildMergeCheckReport(ours, theirs)
+	report.BasePath = "base.yaml"
+	report.OursPath = "ours.yaml"
+	report.TheirsPath = "theirs.yaml"
+
+	output := renderMergeCheck(report, summaryStyles{})
+	assert.Contains(t, output, "Merge check: base.yaml -> ours.yaml / theirs.yaml")
+	assert.Contains(t, output, "Conflicting changes (1)")
+	assert.Contains(t, output, `ours:   modified "pets" -> "pets api"`)
+	assert.Contains(t, output, `theirs: modified "pets" -> "pet store"`)
+	assert.Contains(t, output, "Combined breaking changes (1)")
+	assert.Contains(t, output, "[theirs] $.paths['/pets'] get (removed)")
+}
+
+func TestMergeCheckCommand_RequiresThreeArguments(t *testing.T) {
+	cmd := testRootCmd(GetMergeCheckCommand(), "--no-logo", "a.yaml", "b.yaml")
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly three (3) arguments")
+}
+
+func TestFindMergeConflicts_ComparesEveryChangeAtKey(t *testing.T) {
+	ours := []*model.HashedChange{
+		mergeTestChange("$.components.schemas['Pet']", "enum", wcModel.PropertyAdded, "", "cat", false),
+		mergeTestChange("$.components.schemas['Pet']", "enum", wcModel.PropertyAdded, "", "dog", false),
+	}
+	theirs := []*model.HashedChange{
+		mergeTestChange("$.components.schemas['Pet']", "enum", wcModel.PropertyAdded, "", "cat", false),
+		mergeTestChange("$.components.schemas['Pet']", "enum", wcModel.PropertyAdded, "", "bird", false),
+	}
+
+	conflicts := findMergeConflicts(ours, theirs)
+	require.Len(t, conflicts, 1, "the shared edit merges cleanly, the other two conflict")
+	assert.Equal(t, "dog", conflicts[0].Ours[0].New)
+	assert.Equal(t, "bird", conflicts[0].Theirs[0].New)
+
+	theirs = theirs[:1]
+	assert.Empty(t, findMergeConflicts(ours, theirs), "an extra edit on one side alone is not a conflict")
+}
+
+func TestFindMergeConflicts_ListsEveryUnmatchedChangeOnBothSides(t *testing.T) {
+	ours := []*model.HashedChange{
+		mergeTestChange("$.components.schemas['Pet']", "enum", wcModel.PropertyAdded, "", "dog", false),
+	}
+	theirs := []*model.HashedChange{
+		mergeTestChange("$.components.schemas['Pet']", "enum", wcModel.PropertyAdded, "", "bird", false),
+		mergeTestChange("$.components.schemas['Pet']", "enum", wcModel.PropertyAdded, "", "fish", false),
+	}
+
+	conflicts := findMergeConflicts(ours, theirs)
+	require.Len(t, conflicts, 1)
+	require.Len(t, conflicts[0].Ours, 1)
+	assert.Equal(t, "dog", conflicts[0].Ours[0].New)
+	require.Len(t, conflicts[0].Theirs, 2, "their extra change is not dropped")
+	assert.Equal(t, "bird", conflicts[0].Theirs[0].New)
+	assert.Equal(t, "fish", conflicts[0].Theirs[1].New)
+
+	conflicts = findMergeConflicts(theirs, ours)
+	require.Len(t, conflicts, 1)
+	assert.Len(t, conflicts[0].Ours, 2)
+	assert.Len(t, conflicts[0].Theirs, 1, "our last change is not repeated")
+
+	report := &model.MergeCheckReport{Conflicts: conflicts}
+	output := renderMergeCheck(report, summaryStyles{})
+	assert.Contains(t, output, `ours:   added "bird"`)
+	assert.Contains(t, output, `        added "fish"`)
+	assert.Contains(t, output, `theirs: added "dog"`)
+}
+
+func TestFindMergeConflicts_RemovalOfAncestor(t *testing.T) {
+	ours := []*model.HashedChange{
+		mergeTestChange("$.paths['/pets']", "get", wcModel.ObjectRemoved, "get", "", true),
+	}
+	theirs := []*model.HashedChange{
+		mergeTestChange("$.paths['/pets'].get", "summary", wcModel.Modified, "a", "b", false),
+		mergeTestChange("$.paths['/pets'].getAll", "summary", wcModel.Modified, "a", "b", false),
+	}
+
+	conflicts := findMergeConflicts(ours, theirs)
+	require.Len(t, conflicts, 1)
+	require.Len(t, conflicts[0].Theirs, 1)
+	assert.Equal(t, "$.paths['/pets']", conflicts[0].Path)
+	assert.Equal(t, "get", conflicts[0].Property)
+	assert.Equal(t, wcModel.ObjectRemoved, conflicts[0].Ours[0].ChangeType)
+	assert.Equal(t, "summary", conflicts[0].Theirs[0].Property)
+
+	// the same holds the other way round, with map keys in bracket notation
+	ours = []*model.HashedChange{
+		mergeTestChange("$.paths['/pets'].get.parameters['limit']", "required", wcModel.Modified, "false", "true", true),
+	}
+	theirs = []*model.HashedChange{
+		mergeTestChange("$.paths", "/pets", wcModel.ObjectRemoved, "/pets", "", true),
+	}
+	conflicts = findMergeConflicts(ours, theirs)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "$.paths", conflicts[0].Path)
+	assert.Equal(t, "required", conflicts[0].Ours[0].Property)
+	assert.Equal(t, wcModel.ObjectRemoved, conflicts[0].Theirs[0].ChangeType)
+}
+
+func TestMergeCheckOutcome(t *testing.T) {
+	report := &model.MergeCheckReport{
+		Ours: &model.FlatReport{Severities: map[string]int{severity.Warning: 1}},
+		Theirs: &model.FlatReport{PolicyFindings: []*model.PolicyFinding{
+			{Rule: "no-removals", Severity: severity.Error},
+		}},
+	}
+	err := mergeCheckOutcome(report, severity.Error)
+	assert.ErrorIs(t, err, errPolicyViolations)
+	assert.NotErrorIs(t, err, errFailOnThreshold)
+
+	err = mergeCheckOutcome(report, severity.Warning)
+	assert.ErrorIs(t, err, errFailOnThreshold, "the changes of either side count toward --fail-on")
+
+	report.Theirs = nil
+	assert.NoError(t, mergeCheckOutcome(report, severity.Error))
+
+	report.Conflicts = []*model.MergeConflict{{Path: "$.info", Property: "title"}}
+	assert.EqualError(t, mergeCheckOutcome(report, severity.None), "conflicting changes discovered")
+}
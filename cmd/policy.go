@@ -0,0 +1,298 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/openapi-changes/internal/policy"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+)
+
+const DefaultPolicyFileName = "changes-policy.yaml"
+
+var errPolicyViolations = errors.New("policy violations discovered")
+
+func addPolicyFlag(cmd *cobra.Command) {
+	cmd.Flags().String("policy", "", "Path to a policy file of expression rules evaluated against every change (default: "+
+		DefaultPolicyFileName+" in the current directory or ~/.config, when present)")
+}
+
+// readPolicyFlag loads the policy for commands that accept --policy. Commands
+// without the flag never load a policy.
+func readPolicyFlag(cmd *cobra.Command) (*policy.Policy, error) {
+	if cmd.Flags().Lookup("policy") == nil {
+		return nil, nil
+	}
+	policyFlag, _ := cmd.Flags().GetString("policy")
+	return LoadPolicy(policyFlag)
+}
+
+// LoadPolicy loads a policy file from the specified path. If policyPath is
+// empty, it searches the same default locations as the breaking rules config
+// and returns nil when no policy file is found.
+func LoadPolicy(policyPath string) (*policy.Policy, error) {
+	if policyPath != "" {
+		expandedPath, err := expandUserPath(policyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand policy path '%s': %w", policyPath, err)
+		}
+		return policy.Load(expandedPath)
+	}
+	for _, path := range getDefaultPolicyPaths() {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		return policy.Load(path)
+	}
+	return nil, nil
+}
+
+func getDefaultPolicyPaths() []string {
+	paths := make([]string, 0, 2)
+	if cwd, err := os.Getwd(); err == nil {
+		paths = append(paths, filepath.Join(cwd, DefaultPolicyFileName))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", DefaultPolicyFileName))
+	}
+	return paths
+}
+
+// evaluateCommitPolicy evaluates a policy against the flattened changes of a
+// commit, using the commit's documents to resolve operation and document context.
+func evaluateCommitPolicy(p *policy.Policy, commit *model.Commit, changes []*model.HashedChange) ([]*model.PolicyFinding, error) {
+	if p == nil || commit == nil {
+		return nil, nil
+	}
+	newModel := buildPolicyModel(commit.Document)
+	oldModel := buildPolicyModel(commit.OldDocument)
+
+	input := &policy.Input{
+		Commit: policy.Commit{
+			Hash:    commit.Hash,
+			Message: commit.Message,
+			Author:  commit.Author,
+			Date:    commit.CommitDate,
+		},
+		Changes: changes,
+		Resolve: func(change *model.HashedChange) (*policy.Operation, string) {
+			pathKey, method, location, ok := operationForChange(change)
+			if !ok {
+				return nil, ""
+			}
+			if operation := policyOperation(newModel, pathKey, method); operation != nil {
+				return operation, location
+			}
+			if operation := policyOperation(oldModel, pathKey, method); operation != nil {
+				return operation, location
+			}
+			return &policy.Operation{Path: pathKey, Method: method}, location
+		},
+	}
+	documentModel := newModel
+	if documentModel == nil {
+		documentModel = oldModel
+	}
+	if documentModel != nil {
+		if documentModel.Info != nil {
+			input.Document.Title = documentModel.Info.Title
+			input.Document.Version = documentModel.Info.Version
+		}
+		input.Document.Extensions = decodeExtensions(documentModel.Extensions)
+	}
+
+	findings, err := p.Evaluate(input)
+	if err != nil {
+		return nil, wrapCommitError(commit, err)
+	}
+	return findings, nil
+}
+
+// evaluateFlatReportPolicy attaches policy findings to a flattened report.
+func evaluateFlatReportPolicy(p *policy.Policy, commit *model.Commit, flat *model.FlatReport) error {
+	if p == nil || flat == nil {
+		return nil
+	}
+	findings, err := evaluateCommitPolicy(p, commit, flat.Changes)
+	if err != nil {
+		return err
+	}
+	flat.PolicyFindings = findings
+	return nil
+}
+
+// policyForResult flattens a changerator result and evaluates the policy
+// against it. Along with the findings it returns the severities of the result's
+// changes, with the ones set by allow rules in place of the configured ones.
+func policyForResult(rules reportRules, commit *model.Commit, result *changeratorResult) ([]*model.PolicyFinding, changeSeverities, error) {
+	if rules.policy == nil || result == nil {
+		return nil, rules.severities, nil
+	}
+	// flatten from a copy, so the caller's commit keeps whatever changes it already holds
+	reportCommit := *commit
+	reportCommit.Changes = result.DocChanges
+	flat := FlattenReportWithParameterNames(createResultReport(&reportCommit, result), result.Changerator.ParameterNames)
+	findings, err := evaluateCommitPolicy(rules.policy, &reportCommit, flat.Changes)
+	if err != nil {
+		return nil, nil, err
+	}
+	severities := policySeverities{config: rules.severities, allowed: make(map[string]string)}
+	for _, change := range flat.Changes {
+		if change == nil || change.Change == nil || change.Severity == "" {
+			continue
+		}
+		path := change.RawPath
+		if path == "" {
+			path = change.Path
+		}
+		severities.allowed[allowedSeverityKey(path, change.Change)] = change.Severity
+	}
+	return findings, severities, nil
+}
+
+func buildPolicyModel(doc libopenapi.Document) *v3.Document {
+	if doc == nil {
+		return nil
+	}
+	built, err := doc.BuildV3Model()
+	if err != nil || built == nil {
+		return nil
+	}
+	return &built.Model
+}
+
+func policyOperation(doc *v3.Document, pathKey, method string) *policy.Operation {
+	if doc == nil || doc.Paths == nil || doc.Paths.PathItems == nil {
+		return nil
+	}
+	pathItem, ok := doc.Paths.PathItems.Get(pathKey)
+	if !ok || pathItem == nil {
+		return nil
+	}
+	if method == "" {
+		return &policy.Operation{Path: pathKey, Extensions: decodeExtensions(pathItem.Extensions)}
+	}
+	operation, ok := pathItem.GetOperations().Get(method)
+	if !ok || operation == nil {
+		return nil
+	}
+	return &policy.Operation{
+		Path:        pathKey,
+		Method:      method,
+		OperationID: operation.OperationId,
+		Tags:        operation.Tags,
+		Deprecated:  operation.Deprecated != nil && *operation.Deprecated,
+		Extensions:  decodeExtensions(operation.Extensions),
+	}
+}
+
+func decodeExtensions(extensions *orderedmap.Map[string, *yaml.Node]) map[string]any {
+	decoded := make(map[string]any)
+	for name, node := range extensions.FromOldest() {
+		if node == nil {
+			continue
+		}
+		var value any
+		if err := node.Decode(&value); err == nil {
+			decoded[name] = value
+		}
+	}
+	return decoded
+}
+
+func policyFindingLocation(finding *model.PolicyFinding) string {
+	return strings.TrimSpace(finding.Path + " " + finding.Property)
+}
+
+// renderPolicyFindings renders the policy findings of a commit for the summary command.
+func renderPolicyFindings(findings []*model.PolicyFinding, markdown bool, styles summaryStyles) string {
+	if len(findings) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	if markdown {
+		sb.WriteString(fmt.Sprintf("- **Policy findings**: _%d_\n", len(findings)))
+		for _, finding := range findings {
+			sb.WriteString(fmt.Sprintf("  - %s **%s** `%s`: %s", severityIcon(finding.Severity),
+				finding.Severity, finding.Rule, finding.Message))
+			if location := policyFindingLocation(finding); location != "" {
+				sb.WriteString(fmt.Sprintf(" (`%s`)", location))
+			}
+			sb.WriteString("\n")
+		}
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("  Policy findings: %s\n", styles.title.Render(fmt.Sprint(len(findings)))))
+	for _, finding := range findings {
+		style := styles.stat
+		switch finding.Severity {
+		case policy.SeverityError:
+			style = styles.breaking
+		case policy.SeverityWarning:
+			style = styles.modification
+		}
+		sb.WriteString(fmt.Sprintf("    %s %s: %s", style.Render("["+finding.Severity+"]"), finding.Rule, finding.Message))
+		if location := policyFindingLocation(finding); location != "" {
+			sb.WriteString(" " + styles.detail.Render(location))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// renderPolicyFindingsMarkdown renders the policy findings of a commit as a markdown table.
+func renderPolicyFindingsMarkdown(findings []*model.PolicyFinding) string {
+	if len(findings) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("### Policy Findings\n\n")
+	sb.WriteString("| Severity | Rule | Message | Location |\n")
+	sb.WriteString("|----------|------|---------|----------|\n")
+	for _, finding := range findings {
+		location := policyFindingLocation(finding)
+		if location != "" {
+			location = "`" + location + "`"
+		}
+		sb.WriteString(fmt.Sprintf("| %s %s | `%s` | %s | %s |\n", severityIcon(finding.Severity), finding.Severity,
+			finding.Rule, escapeMarkdownTableCell(finding.Message), escapeMarkdownTableCell(location)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func escapeMarkdownTableCell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", "\\|"), "\n", " ")
+}
+
+// renderPolicyFindingsHTML renders the policy findings of a commit as an HTML
+// section that is placed ahead of the change report.
+func renderPolicyFindingsHTML(findings []*model.PolicyFinding) string {
+	if len(findings) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(`<div class="policy-findings"><h2>Policy Findings</h2><table><thead><tr>`)
+	sb.WriteString(`<th>Severity</th><th>Rule</th><th>Message</th><th>Location</th></tr></thead><tbody>`)
+	for _, finding := range findings {
+		sb.WriteString(fmt.Sprintf(`<tr class="policy-%s"><td>%s %s</td><td><code>%s</code></td><td>%s</td><td><code>%s</code></td></tr>`,
+			html.EscapeString(finding.Severity), severityIcon(finding.Severity), html.EscapeString(finding.Severity),
+			html.EscapeString(finding.Rule), html.EscapeString(finding.Message),
+			html.EscapeString(policyFindingLocation(finding))))
+	}
+	sb.WriteString(`</tbody></table></div>`)
+	return sb.String()
+}
@@ -0,0 +1,273 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/policy"
+	"github.com/pb33f/openapi-changes/internal/severity"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const policyTestSpecOld = `openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      responses:
+        "201":
+          description: created
+  /internal/jobs:
+    get:
+      responses:
+        "200":
+          description: ok
+        "404":
+          description: missing
+`
+
+const policyTestSpecNew = `openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.1.0
+x-stage: beta
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      tags: [orders]
+      x-beta: true
+      responses:
+        "201":
+          description: created
+  /internal/jobs:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+const policyTestRules = `rules:
+  - name: beta-only
+    severity: warning
+    message: change to a beta operation
+    when: operation.extensions["x-beta"] == true && "orders" in operation.tags
+  - name: internal-removal
+    severity: info
+    when: change.type == "removed" && operation.path.glob("/internal/**") && change.location == "responses"
+  - name: beta-document
+    scope: commit
+    severity: error
+    message: beta documents cannot be released
+    when: document.extensions["x-stage"] == "beta" && document.version == "1.1.0"
+`
+
+func writePolicyFile(t *testing.T, dir, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, DefaultPolicyFileName)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+func policyTestCommit(t *testing.T) *model.Commit {
+	t.Helper()
+	oldDoc, err := libopenapi.NewDocument([]byte(policyTestSpecOld))
+	require.NoError(t, err)
+	newDoc, err := libopenapi.NewDocument([]byte(policyTestSpecNew))
+	require.NoError(t, err)
+	return &model.Commit{Hash: "abc1234", Message: "beta orders", Author: "jane", OldDocument: oldDoc, Document: newDoc}
+}
+
+func TestLoadPolicy_ExplicitPath(t *testing.T) {
+	path := writePolicyFile(t, t.TempDir(), policyTestRules)
+	p, err := LoadPolicy(path)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Len(t, p.Rules, 3)
+
+	_, err = LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLoadPolicy_DefaultPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+
+	p, err := LoadPolicy("")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	writePolicyFile(t, dir, policyTestRules)
+	p, err = LoadPolicy("")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Len(t, p.Rules, 3)
+
+	writePolicyFile(t, dir, "rules:\n  - name: broken\n    when: change.path ==\n")
+	_, err = LoadPolicy("")
+	assert.ErrorContains(t, err, "broken")
+}
+
+func TestEvaluateCommitPolicy_ResolvesDocumentContext(t *testing.T) {
+	p, err := policy.Parse([]byte(policyTestRules))
+	require.NoError(t, err)
+
+	betaChange := mergeTestChange("$.paths['/orders'].post", "x-beta", wcModel.PropertyAdded, "", "true", false)
+	removedResponse := mergeTestChange("$.paths['/internal/jobs'].get.responses", "404", wcModel.PropertyRemoved, "404", "", true)
+	infoChange := mergeTestChange("$.info", "version", wcModel.Modified, "1.0.0", "1.1.0", false)
+
+	findings, err := evaluateCommitPolicy(p, policyTestCommit(t), []*model.HashedChange{betaChange, removedResponse, infoChange})
+	require.NoError(t, err)
+	require.Len(t, findings, 3)
+
+	assert.Equal(t, "beta-only", findings[0].Rule)
+	assert.Equal(t, "$.paths['/orders'].post", findings[0].Path)
+	assert.Equal(t, "internal-removal", findings[1].Rule)
+	assert.Equal(t, "404", findings[1].Property)
+	assert.Equal(t, "beta-document", findings[2].Rule)
+	assert.Equal(t, "abc1234", findings[2].Commit)
+	outcome := &changeOutcome{}
+	outcome.add(map[string]int{severity.Info: 3}, findings)
+	assert.ErrorIs(t, outcome.failOn(severity.Error), errPolicyViolations)
+	assert.NotErrorIs(t, outcome.failOn(severity.Error), errFailOnThreshold)
+}
+
+func TestEvaluateCommitPolicy_FallsBackToOriginalDocument(t *testing.T) {
+	p, err := policy.Parse([]byte(`rules:
+  - name: removed-operation
+    when: change.type == "removed" && operation.operationId == "listJobs"
+`))
+	require.NoError(t, err)
+
+	oldDoc, err := libopenapi.NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: Jobs
+  version: 1.0.0
+paths:
+  /jobs:
+    get:
+      operationId: listJobs
+      responses:
+        "200":
+          description: ok
+`))
+	require.NoError(t, err)
+	newDoc, err := libopenapi.NewDocument([]byte("openapi: 3.1.0\ninfo:\n  title: Jobs\n  version: 1.0.0\npaths: {}\n"))
+	require.NoError(t, err)
+
+	removed := mergeTestChange("$.paths['/jobs']", "get", wcModel.PropertyRemoved, "get", "", true)
+	findings, err := evaluateCommitPolicy(p, &model.Commit{OldDocument: oldDoc, Document: newDoc}, []*model.HashedChange{removed})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "removed-operation", findings[0].Rule)
+}
+
+func TestApplyFlatReportRules_AllowRulesSetSeverities(t *testing.T) {
+	p, err := policy.Parse([]byte(`rules:
+  - name: response-removal
+    when: change.type == "removed"
+  - name: internal-removal
+    action: allow
+    when: change.type == "removed" && operation.path.glob("/internal/**")
+`))
+	require.NoError(t, err)
+
+	removed := mergeTestChange("$.paths['/internal/jobs'].get.responses", "404", wcModel.PropertyRemoved, "404", "", true)
+	flat := &model.FlatReport{Changes: []*model.HashedChange{removed}}
+	require.NoError(t, applyFlatReportRules(reportRules{policy: p}, policyTestCommit(t), flat))
+
+	assert.Empty(t, flat.PolicyFindings)
+	assert.Equal(t, severity.Ignore, removed.Severity)
+	assert.Equal(t, map[string]int{severity.Ignore: 1}, flat.Severities)
+	outcome := &changeOutcome{}
+	outcome.add(flat.Severities, flat.PolicyFindings)
+	assert.NoError(t, outcome.failOn(severity.Info))
+}
+
+func TestPolicySeverities(t *testing.T) {
+	removed := &wcModel.Change{Path: "$.paths['/internal/jobs'].get.responses", Property: "404",
+		ChangeType: wcModel.PropertyRemoved, Original: "404", Breaking: true}
+	other := &wcModel.Change{Path: "$.paths['/orders'].get.responses", Property: "404",
+		ChangeType: wcModel.PropertyRemoved, Original: "404", Breaking: true}
+	severities := policySeverities{allowed: map[string]string{
+		allowedSeverityKey(removed.Path, removed): severity.Warning,
+	}}
+
+	assert.Equal(t, severity.Warning, severities.Of(removed))
+	assert.Equal(t, severity.Error, severities.Of(other))
+	assert.Equal(t, map[string]int{severity.Warning: 1, severity.Error: 1}, severities.Counts([]*wcModel.Change{removed, other}))
+}
+
+func TestRenderPolicyFindings(t *testing.T) {
+	findings := []*model.PolicyFinding{
+		{Rule: "no-removals", Severity: policy.SeverityError, Message: "removals | need review", Path: "$.paths['/a']", Property: "get"},
+		{Rule: "large-commit", Severity: policy.SeverityWarning, Message: "<too> many changes"},
+	}
+
+	text := renderPolicyFindings(findings, false, summaryStylesForPalette(commandPaletteForTheme("")))
+	assert.Contains(t, text, "Policy findings")
+	assert.Contains(t, text, "no-removals: removals | need review")
+	assert.Contains(t, text, "$.paths['/a'] get")
+
+	markdown := renderPolicyFindings(findings, true, summaryStyles{})
+	assert.Contains(t, markdown, "- **Policy findings**: _2_")
+	assert.Contains(t, markdown, "❌ **error** `no-removals`")
+
+	table := renderPolicyFindingsMarkdown(findings)
+	assert.Contains(t, table, "### Policy Findings")
+	assert.Contains(t, table, "removals \\| need review")
+
+	htmlSection := renderPolicyFindingsHTML(findings)
+	assert.Contains(t, htmlSection, "&lt;too&gt; many changes")
+	assert.NotContains(t, htmlSection, "<too>")
+
+	assert.Empty(t, renderPolicyFindings(nil, false, summaryStyles{}))
+	assert.Empty(t, renderPolicyFindingsMarkdown(nil))
+	assert.Empty(t, renderPolicyFindingsHTML(nil))
+}
+
+func TestReportCommand_PolicyViolationsFailAfterPrintingReport(t *testing.T) {
+	dir := t.TempDir()
+	left := filepath.Join(dir, "left.yaml")
+	right := filepath.Join(dir, "right.yaml")
+	require.NoError(t, os.WriteFile(left, []byte(policyTestSpecOld), 0o644))
+	require.NoError(t, os.WriteFile(right, []byte(policyTestSpecNew), 0o644))
+	policyPath := writePolicyFile(t, dir, policyTestRules)
+
+	cmd := testRootCmd(GetReportCommand(), "--no-logo", "--no-color", "--policy", policyPath, left, right)
+	var execErr error
+	output := captureStdout(t, func() {
+		execErr = cmd.Execute()
+	})
+	assert.ErrorIs(t, execErr, errPolicyViolations)
+
+	var report model.FlatReport
+	require.NoError(t, json.Unmarshal([]byte(output), &report))
+	require.NotEmpty(t, report.PolicyFindings)
+	rules := make(map[string]bool)
+	for _, finding := range report.PolicyFindings {
+		rules[finding.Rule] = true
+	}
+	assert.True(t, rules["beta-document"])
+}
+
+func TestReportCommand_InvalidPolicyFails(t *testing.T) {
+	policyPath := writePolicyFile(t, t.TempDir(), "rules:\n  - name: bad\n    severity: fatal\n    when: change.breaking\n")
+	cmd := testRootCmd(GetReportCommand(), "--no-logo", "--policy", policyPath,
+		"../sample-specs/petstorev3-original.json", "../sample-specs/petstorev3.json")
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "unknown severity 'fatal'")
+}
@@ -0,0 +1,295 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	// DefaultProjectConfigFileName is the default name for the project config file
+	DefaultProjectConfigFileName = ".openapi-changes.yaml"
+
+	// EnvPrefix prefixes the environment variables that override flags,
+	// e.g. OPENAPI_CHANGES_LIMIT for --limit.
+	EnvPrefix = "OPENAPI_CHANGES_"
+)
+
+// projectConfig and projectConfigErr hold the project config discovered by initConfig.
+var (
+	projectConfig    *ProjectConfig
+	projectConfigErr error
+)
+
+// FlagValues holds flag values by flag name. Lists set a flag once per item.
+type FlagValues map[string]FlagValue
+
+// FlagValue is the value of a single flag, read from a scalar or a list.
+type FlagValue []string
+
+func (v *FlagValue) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*v = FlagValue{node.Value}
+		return nil
+	case yaml.SequenceNode:
+		values := make(FlagValue, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: flag list items must be scalars", item.Line)
+			}
+			values = append(values, item.Value)
+		}
+		*v = values
+		return nil
+	}
+	return fmt.Errorf("line %d: flag values must be a scalar or a list", node.Line)
+}
+
+// ProjectSettings holds flag defaults for every command and for individual commands.
+type ProjectSettings struct {
+	Flags    FlagValues            `yaml:"flags,omitempty"`
+	Commands map[string]FlagValues `yaml:"commands,omitempty"`
+}
+
+// ProjectConfig is a `.openapi-changes.yaml` project config: flag defaults,
+// plus named profiles that are layered on top of them with --profile.
+type ProjectConfig struct {
+	ProjectSettings `yaml:",inline"`
+	Profiles        map[string]*ProjectSettings `yaml:"profiles,omitempty"`
+	Path            string                      `yaml:"-"`
+}
+
+// LoadProjectConfig loads a project config from the specified path.
+// If configPath is empty, it searches the same default locations as the breaking
+// rules config (current directory, then ~/.config) and returns nil if none is found.
+func LoadProjectConfig(configPath string) (*ProjectConfig, error) {
+	if configPath != "" {
+		return loadProjectConfigFromPath(configPath, true)
+	}
+	for _, path := range getDefaultProjectConfigPaths() {
+		config, err := loadProjectConfigFromPath(path, false)
+		if err != nil || config != nil {
+			return config, err
+		}
+	}
+	return nil, nil
+}
+
+func loadProjectConfigFromPath(path string, mustExist bool) (*ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !mustExist {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read project config '%s': %w", path, err)
+	}
+	config := &ProjectConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse project config '%s': %w", path, err)
+	}
+	config.Path = path
+	return config, nil
+}
+
+func getDefaultProjectConfigPaths() []string {
+	paths := make([]string, 0, 2)
+	if cwd, err := os.Getwd(); err == nil {
+		paths = append(paths, filepath.Join(cwd, DefaultProjectConfigFileName))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", DefaultProjectConfigFileName))
+	}
+	return paths
+}
+
+// commandFlagValues layers the flag values for a command: shared flags, then the
+// command's flags, then the same again from the profile.
+func (c *ProjectConfig) commandFlagValues(commandName, profile string) (FlagValues, error) {
+	values := make(FlagValues)
+	if c == nil {
+		if profile != "" {
+			return nil, fmt.Errorf("profile '%s' requires a %s project config", profile, DefaultProjectConfigFileName)
+		}
+		return values, nil
+	}
+	layers := []*ProjectSettings{&c.ProjectSettings}
+	if profile != "" {
+		settings, ok := c.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("unknown profile '%s' in '%s' (available: %s)", profile, c.Path, c.profileNames())
+		}
+		layers = append(layers, settings)
+	}
+	for _, settings := range layers {
+		if settings == nil {
+			continue
+		}
+		for name, value := range settings.Flags {
+			values[name] = value
+		}
+		for name, value := range settings.Commands[commandName] {
+			values[name] = value
+		}
+	}
+	return values, nil
+}
+
+func (c *ProjectConfig) profileNames() string {
+	if len(c.Profiles) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// validateCommands checks that every flag configured in the project config exists.
+// Problems with the flags configured for the running command, in the config or the
+// selected profile, are returned as an error. Problems anywhere else in the config
+// are returned as warnings, so that one bad entry does not stop every other
+// command from running.
+func (c *ProjectConfig) validateCommands(cmd *cobra.Command, selectedProfile string) ([]error, error) {
+	if c == nil {
+		return nil, nil
+	}
+	root := cmd.Root()
+	running := commandConfigName(cmd)
+	settings := map[string]*ProjectSettings{"": &c.ProjectSettings}
+	for name, profile := range c.Profiles {
+		settings[name] = profile
+	}
+	var warnings, errs []error
+	for profile, s := range settings {
+		if s == nil {
+			continue
+		}
+		for name := range s.Flags {
+			if !anyCommandHasFlag(root, name) {
+				warnings = append(warnings, fmt.Errorf("%sunknown flag '%s'", profileContext(profile), name))
+			}
+		}
+		for commandName, flags := range s.Commands {
+			command, _, err := root.Find(strings.Fields(commandName))
+			if err != nil || command == root {
+				warnings = append(warnings, fmt.Errorf("%sunknown command '%s'", profileContext(profile), commandName))
+				continue
+			}
+			applies := commandConfigName(command) == running && (profile == "" || profile == selectedProfile)
+			for name := range flags {
+				if command.Flags().Lookup(name) != nil || command.InheritedFlags().Lookup(name) != nil {
+					continue
+				}
+				problem := fmt.Errorf("%sunknown flag '%s' for command '%s'", profileContext(profile), name, commandName)
+				if applies {
+					errs = append(errs, problem)
+				} else {
+					warnings = append(warnings, problem)
+				}
+			}
+		}
+	}
+	sortErrors(warnings)
+	for i, warning := range warnings {
+		warnings[i] = fmt.Errorf("project config '%s': %w", c.Path, warning)
+	}
+	if len(errs) == 0 {
+		return warnings, nil
+	}
+	sortErrors(errs)
+	return warnings, fmt.Errorf("invalid project config '%s': %w", c.Path, errors.Join(errs...))
+}
+
+func sortErrors(errs []error) {
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+}
+
+func anyCommandHasFlag(command *cobra.Command, name string) bool {
+	if command.Flags().Lookup(name) != nil || command.PersistentFlags().Lookup(name) != nil {
+		return true
+	}
+	for _, child := range command.Commands() {
+		if anyCommandHasFlag(child, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func profileContext(profile string) string {
+	if profile == "" {
+		return ""
+	}
+	return fmt.Sprintf("profile '%s': ", profile)
+}
+
+// envFlagName returns the environment variable that overrides a flag.
+func envFlagName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// commandConfigName is the key of a command in a project config, e.g. "summary".
+func commandConfigName(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if root := cmd.Root(); root != cmd {
+		path = strings.TrimPrefix(path, root.Name()+" ")
+	}
+	return path
+}
+
+// applyProjectConfig sets the flags of a command that were not given on the command
+// line from the project config and profile, then from OPENAPI_CHANGES_* variables.
+func applyProjectConfig(cmd *cobra.Command, config *ProjectConfig, lookupEnv func(string) (string, bool)) error {
+	profile, _ := cmd.Flags().GetString("profile")
+	if !cmd.Flags().Changed("profile") {
+		if value, ok := lookupEnv(envFlagName("profile")); ok && value != "" {
+			profile = value
+		}
+	}
+	warnings, err := config.validateCommands(cmd, profile)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+	if err != nil {
+		return err
+	}
+	values, err := config.commandFlagValues(commandConfigName(cmd), profile)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed || flag.Name == "profile" {
+			return
+		}
+		value, ok := values[flag.Name]
+		source := "project config"
+		if env, found := lookupEnv(envFlagName(flag.Name)); found && env != "" {
+			value, ok, source = FlagValue{env}, true, envFlagName(flag.Name)
+		}
+		if !ok {
+			return
+		}
+		for _, item := range value {
+			if err := cmd.Flags().Set(flag.Name, item); err != nil {
+				errs = append(errs, fmt.Errorf("invalid value '%s' for --%s from %s: %w", item, flag.Name, source, err))
+				return
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
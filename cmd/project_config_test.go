@@ -0,0 +1,172 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const projectConfigTestYAML = `flags:
+  limit: 10
+  no-logo: true
+commands:
+  summary:
+    fail-on: warning
+    markdown: true
+profiles:
+  ci:
+    flags:
+      no-color: true
+    commands:
+      summary:
+        limit: 20
+`
+
+func writeProjectConfig(t *testing.T, contents string) *ProjectConfig {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultProjectConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	config, err := LoadProjectConfig(path)
+	require.NoError(t, err)
+	return config
+}
+
+func envLookup(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+// parsedSummaryCommand returns a summary command attached to a test root with its args parsed.
+func parsedSummaryCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	summary := GetSummaryCommand()
+	testRootCmd(summary)
+	require.NoError(t, summary.ParseFlags(args))
+	return summary
+}
+
+func TestApplyProjectConfig_Layers(t *testing.T) {
+	config := writeProjectConfig(t, projectConfigTestYAML)
+
+	summary := parsedSummaryCommand(t)
+	require.NoError(t, applyProjectConfig(summary, config, envLookup(nil)))
+	limit, _ := summary.Flags().GetInt("limit")
+	failOn, _ := summary.Flags().GetString("fail-on")
+	markdown, _ := summary.Flags().GetBool("markdown")
+	noColor, _ := summary.Flags().GetBool("no-color")
+	assert.Equal(t, 10, limit)
+	assert.Equal(t, "warning", failOn)
+	assert.True(t, markdown)
+	assert.False(t, noColor)
+
+	summary = parsedSummaryCommand(t, "--profile", "ci")
+	require.NoError(t, applyProjectConfig(summary, config, envLookup(nil)))
+	limit, _ = summary.Flags().GetInt("limit")
+	noColor, _ = summary.Flags().GetBool("no-color")
+	assert.Equal(t, 20, limit)
+	assert.True(t, noColor)
+
+	summary = parsedSummaryCommand(t)
+	require.NoError(t, applyProjectConfig(summary, config, envLookup(map[string]string{"OPENAPI_CHANGES_PROFILE": "ci"})))
+	limit, _ = summary.Flags().GetInt("limit")
+	assert.Equal(t, 20, limit)
+}
+
+func TestApplyProjectConfig_EnvironmentAndCommandLine(t *testing.T) {
+	config := writeProjectConfig(t, projectConfigTestYAML)
+	env := envLookup(map[string]string{
+		"OPENAPI_CHANGES_LIMIT":   "7",
+		"OPENAPI_CHANGES_FAIL_ON": "info",
+		"OPENAPI_CHANGES_BASE":    "",
+	})
+
+	summary := parsedSummaryCommand(t, "--fail-on", "none")
+	require.NoError(t, applyProjectConfig(summary, config, env))
+	limit, _ := summary.Flags().GetInt("limit")
+	failOn, _ := summary.Flags().GetString("fail-on")
+	base, _ := summary.Flags().GetString("base")
+	assert.Equal(t, 7, limit, "environment variables override the project config")
+	assert.Equal(t, "none", failOn, "the command line overrides everything")
+	assert.Empty(t, base)
+
+	// no project config at all still honors the environment
+	summary = parsedSummaryCommand(t)
+	require.NoError(t, applyProjectConfig(summary, nil, env))
+	limit, _ = summary.Flags().GetInt("limit")
+	assert.Equal(t, 7, limit)
+}
+
+func TestApplyProjectConfig_Errors(t *testing.T) {
+	config := writeProjectConfig(t, projectConfigTestYAML)
+
+	err := applyProjectConfig(parsedSummaryCommand(t, "--profile", "nightly"), config, envLookup(nil))
+	assert.ErrorContains(t, err, "unknown profile 'nightly'")
+	assert.ErrorContains(t, err, "(available: ci)")
+
+	err = applyProjectConfig(parsedSummaryCommand(t, "--profile", "ci"), nil, envLookup(nil))
+	assert.ErrorContains(t, err, "profile 'ci' requires a .openapi-changes.yaml project config")
+
+	err = applyProjectConfig(parsedSummaryCommand(t), config, envLookup(map[string]string{"OPENAPI_CHANGES_LIMIT": "lots"}))
+	assert.ErrorContains(t, err, "invalid value 'lots' for --limit from OPENAPI_CHANGES_LIMIT")
+
+	invalid := writeProjectConfig(t, `flags:
+  limt: 3
+commands:
+  summary:
+    report-file: out.md
+  sumary:
+    limit: 3
+profiles:
+  ci:
+    commands:
+      summary:
+        include-diff: true
+`)
+	stderr := captureStderr(t, func() {
+		err = applyProjectConfig(parsedSummaryCommand(t), invalid, envLookup(nil))
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown flag 'report-file' for command 'summary'")
+	assert.NotContains(t, err.Error(), "limt", "entries for other commands only warn")
+	assert.NotContains(t, err.Error(), "include-diff", "profiles that are not selected only warn")
+	assert.Contains(t, stderr, "warning: project config '"+invalid.Path+"': unknown flag 'limt'")
+	assert.Contains(t, stderr, "unknown command 'sumary'")
+	assert.Contains(t, stderr, "profile 'ci': unknown flag 'include-diff' for command 'summary'")
+
+	err = applyProjectConfig(parsedSummaryCommand(t, "--profile", "ci"), invalid, envLookup(nil))
+	assert.ErrorContains(t, err, "profile 'ci': unknown flag 'include-diff' for command 'summary'")
+
+	report := GetReportCommand()
+	testRootCmd(GetSummaryCommand()).AddCommand(report)
+	require.NoError(t, report.ParseFlags(nil))
+	stderr = captureStderr(t, func() {
+		err = applyProjectConfig(report, invalid, envLookup(nil))
+	})
+	require.NoError(t, err, "a bad entry for summary does not stop report")
+	assert.Contains(t, stderr, "unknown flag 'report-file' for command 'summary'")
+}
+
+func TestLoadProjectConfig(t *testing.T) {
+	config := writeProjectConfig(t, projectConfigTestYAML)
+	assert.Equal(t, FlagValue{"10"}, config.Flags["limit"])
+	assert.Equal(t, FlagValue{"warning"}, config.Commands["summary"]["fail-on"])
+	require.Contains(t, config.Profiles, "ci")
+	assert.Equal(t, FlagValue{"true"}, config.Profiles["ci"].Flags["no-color"])
+
+	_, err := LoadProjectConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read project config")
+
+	path := filepath.Join(t.TempDir(), DefaultProjectConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte("flags:\n  limit: {nested: true}\n"), 0o644))
+	_, err = LoadProjectConfig(path)
+	assert.ErrorContains(t, err, "flag values must be a scalar or a list")
+}
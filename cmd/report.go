@@ -7,9 +7,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
@@ -32,17 +36,27 @@ func changerateCommit(commit *model.Commit, breakingConfig *whatChangedModel.Bre
 }
 
 type historicalFlattenResult struct {
-	Reports               []*model.FlatReport
+	ReportCount           int
 	SkippedCommits        []string
 	SuccessfulComparisons int
 }
 
-func changerateAndFlattenHistory(commits []*model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig) (*historicalFlattenResult, error) {
-	reports := make([]*model.FlatReport, 0, len(commits))
+// reportSink receives each commit's report as soon as the commit has been
+// changerated and its comparison released, so that histories can be streamed.
+// The history itself is loaded before the first report is sent; what streaming
+// saves is holding every report, and each commit's documents, until the end.
+type reportSink func(*model.FlatReport) error
+
+// changerateAndFlattenHistory changerates each commit in turn and hands its
+// flattened report to emit, along with the commit it was built from.
+func changerateAndFlattenHistory(commits []*model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig,
+	emit func(*model.Commit, *model.FlatReport) error,
+) (*historicalFlattenResult, error) {
 	var renderErrors []error
 	var skippedCommits []string
 	skippedSeen := make(map[string]struct{})
 	processedComparables := 0
+	reportCount := 0
 
 	for _, commit := range commits {
 		if commit == nil {
@@ -63,23 +77,30 @@ func changerateAndFlattenHistory(commits []*model.Commit, breakingConfig *whatCh
 			continue
 		}
 		commit.Changes = result.DocChanges
-		reports = append(reports, FlattenReportWithParameterNames(createReport(commit), result.Changerator.ParameterNames))
+		flat := FlattenReportWithParameterNames(createResultReport(commit, result), result.Changerator.ParameterNames)
 		result.Release()
+		if err := emit(commit, flat); err != nil {
+			return nil, err
+		}
+		reportCount++
 	}
 	if len(renderErrors) > 0 {
-		if len(reports) == 0 && processedComparables == 0 {
+		if reportCount == 0 && processedComparables == 0 {
 			return nil, fmt.Errorf("all %d commits failed to render report data: %w", len(renderErrors), errors.Join(renderErrors...))
 		}
 		fmt.Fprintf(os.Stderr, "warning: %d commits failed to render report data\n", len(renderErrors))
 	}
 	return &historicalFlattenResult{
-		Reports:               reports,
+		ReportCount:           reportCount,
 		SkippedCommits:        skippedCommits,
 		SuccessfulComparisons: processedComparables,
 	}, nil
 }
 
 func runLeftRightReport(left, right string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig) (*model.FlatReport, error) {
+	if opts.squash {
+		return nil, errSquashLeftRight
+	}
 	commit, err := buildLeftRightCommitAndSources(left, right, opts)
 	if err != nil {
 		return nil, err
@@ -92,7 +113,10 @@ func runLeftRightReport(left, right string, opts summaryOpts, breakingConfig *wh
 		return nil, nil
 	}
 	defer result.Release()
-	flat := FlattenReportWithParameterNames(createReport(commit), result.Changerator.ParameterNames)
+	flat := FlattenReportWithParameterNames(createResultReport(commit, result), result.Changerator.ParameterNames)
+	if err := applyFlatReportRules(opts.rules, commit, flat); err != nil {
+		return nil, err
+	}
 	flat.Commit = nil
 	flat.OriginalPath = sourceLabelForReport(left)
 	flat.ModifiedPath = sourceLabelForReport(right)
@@ -100,32 +124,62 @@ func runLeftRightReport(left, right string, opts summaryOpts, breakingConfig *wh
 }
 
 func runGitHistoryReport(gitPath, filePath string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig) (*model.FlatHistoricalReport, error) {
+	return streamGitHistoryReport(gitPath, filePath, opts, breakingConfig, nil)
+}
+
+// streamGitHistoryReport builds the report of a local git history. With a sink,
+// each commit's report goes to the sink instead of into the returned report.
+func streamGitHistoryReport(gitPath, filePath string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig,
+	sink reportSink,
+) (*model.FlatHistoricalReport, error) {
 	loaded, err := loadGitHistoryCommitsDetailed(gitPath, filePath, opts, breakingConfig)
 	if err != nil {
 		return nil, err
 	}
-	return buildHistoricalReport(gitPath, filePath, loaded, breakingConfig)
+	return buildHistoricalReport(gitPath, filePath, loaded, breakingConfig, opts.rules, sink)
 }
 
 func runGithubHistoryReport(rawURL string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig) (*model.FlatHistoricalReport, error) {
-	specURL, err := url.Parse(rawURL)
-	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %w", err)
-	}
-	user, repo, filePath, err := ExtractGithubDetailsFromURL(specURL)
+	return streamGithubHistoryReport(rawURL, opts, breakingConfig, nil)
+}
+
+// streamGithubHistoryReport builds the report of a GitHub file history, streaming
+// each commit's report to the sink when there is one.
+func streamGithubHistoryReport(rawURL string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig,
+	sink reportSink,
+) (*model.FlatHistoricalReport, error) {
+	repoPath, filePath, err := githubHistoryLocation(rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("error extracting github details: %w", err)
+		return nil, err
 	}
 
 	loaded, err := loadGitHubCommitsDetailed(rawURL, opts, breakingConfig)
 	if err != nil {
 		return nil, err
 	}
-	return buildHistoricalReport(fmt.Sprintf("%s/%s", user, repo), filePath, loaded, breakingConfig)
+	return buildHistoricalReport(repoPath, filePath, loaded, breakingConfig, opts.rules, sink)
+}
+
+// githubHistoryLocation returns the "user/repo" and file path that a historical
+// report of a GitHub file URL is labelled with.
+func githubHistoryLocation(rawURL string) (repoPath, filePath string, err error) {
+	specURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid URL: %w", err)
+	}
+	user, repo, filePath, err := ExtractGithubDetailsFromURL(specURL)
+	if err != nil {
+		return "", "", fmt.Errorf("error extracting github details: %w", err)
+	}
+	return fmt.Sprintf("%s/%s", user, repo), filePath, nil
 }
 
+// buildHistoricalReport changerates a loaded history and applies the report rules
+// to each commit's report. Reports are collected into the historical report, or
+// handed to the sink (when there is one) and then dropped, and the commit they
+// were built from releases its documents and changes.
 func buildHistoricalReport(repoPath, filePath string, loaded *loadedHistoryResult,
-	breakingConfig *whatChangedModel.BreakingRulesConfig,
+	breakingConfig *whatChangedModel.BreakingRulesConfig, rules reportRules, sink reportSink,
 ) (*model.FlatHistoricalReport, error) {
 	if loaded == nil {
 		return nil, nil
@@ -134,21 +188,33 @@ func buildHistoricalReport(repoPath, filePath string, loaded *loadedHistoryResul
 		return nil, nil
 	}
 
-	history, err := changerateAndFlattenHistory(loaded.Commits, breakingConfig)
-	if err != nil {
-		return nil, err
-	}
-	skippedCommits := mergeSkippedCommitHashes(loaded.SkippedCommits, history.SkippedCommits)
-	if len(history.Reports) == 0 && history.SuccessfulComparisons == 0 && len(skippedCommits) > 0 {
-		return nil, fmt.Errorf("all %d candidate commits were skipped or failed to render", len(skippedCommits))
-	}
-
 	report := &model.FlatHistoricalReport{
 		GitRepoPath:   repoPath,
 		GitFilePath:   filePath,
 		Filename:      path.Base(filePath),
 		DateGenerated: time.Now().Format(time.RFC3339),
-		Reports:       history.Reports,
+	}
+	if sink == nil {
+		report.Reports = make([]*model.FlatReport, 0, len(loaded.Commits))
+	}
+	history, err := changerateAndFlattenHistory(loaded.Commits, breakingConfig, func(commit *model.Commit, flat *model.FlatReport) error {
+		if err := applyFlatReportRules(rules, flat.Commit, flat); err != nil {
+			return err
+		}
+		if sink == nil {
+			report.Reports = append(report.Reports, flat)
+			return nil
+		}
+		err := sink(flat)
+		releaseCommitComparison(commit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	skippedCommits := mergeSkippedCommitHashes(loaded.SkippedCommits, history.SkippedCommits)
+	if history.ReportCount == 0 && history.SuccessfulComparisons == 0 && len(skippedCommits) > 0 {
+		return nil, fmt.Errorf("all %d candidate commits were skipped or failed to render", len(skippedCommits))
 	}
 	if len(skippedCommits) > 0 {
 		report.MetaData = &model.HistoricalReportMetaData{
@@ -159,6 +225,14 @@ func buildHistoricalReport(repoPath, filePath string, loaded *loadedHistoryResul
 	return report, nil
 }
 
+// releaseCommitComparison drops the documents, contents and changes a commit
+// holds for its comparison, once nothing reads them again.
+func releaseCommitComparison(commit *model.Commit) {
+	commit.Changes = nil
+	commit.Document, commit.OldDocument = nil, nil
+	commit.Data, commit.OldData = nil, nil
+}
+
 func addSkippedCommitHash(skippedCommits *[]string, seen map[string]struct{}, hash string) {
 	if hash == "" {
 		return
@@ -202,7 +276,7 @@ func GetReportCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			reproducible, err := cmd.Flags().GetBool("reproducible")
+			output, err := readReportOutputFlags(cmd)
 			if err != nil {
 				return err
 			}
@@ -219,72 +293,323 @@ func GetReportCommand() *cobra.Command {
 				return fmt.Errorf("too many arguments provided, expecting at most two (2)")
 			}
 
-			breakingConfig, err := LoadBreakingRulesConfig(configFlag)
+			breakingConfig, rules, err := readReportRules(cmd, configFlag, opts.palette)
 			if err != nil {
-				PrintConfigError(err, opts.palette)
 				return err
 			}
+			opts.rules = rules
 
 			if len(args) == 1 {
 				if err := validateGitHubURL(args[0]); err != nil {
 					return err
 				}
 
-				flat, reportErr := runGithubHistoryReport(args[0], opts, breakingConfig)
-				if reportErr != nil {
-					return reportErr
-				}
-				if reproducible {
-					makeReportOutputReproducible(flat)
-				}
-				return printReportJSON(flat)
+				return output.writeHistory(func(sink reportSink) (*model.FlatHistoricalReport, error) {
+					return streamGithubHistoryReport(args[0], opts, breakingConfig, sink)
+				})
 			}
 
 			if !isHTTPURL(args[0]) {
 				if _, _, ok := parseGitRef(args[0]); ok {
-					return printReportJSONOrNoChanges(args, opts, breakingConfig, reproducible)
+					return printReportJSONOrNoChanges(args, opts, breakingConfig, output)
 				}
 				f, statErr := os.Stat(args[0])
 				if statErr == nil && f.IsDir() {
-					flat, reportErr := runGitHistoryReport(args[0], args[1], opts, breakingConfig)
-					if reportErr != nil {
-						return reportErr
-					}
-					if flat == nil {
-						printNoChangesJSON()
-						return nil
-					}
-					if reproducible {
-						makeReportOutputReproducible(flat)
-					}
-					return printReportJSON(flat)
+					return output.writeHistory(func(sink reportSink) (*model.FlatHistoricalReport, error) {
+						return streamGitHistoryReport(args[0], args[1], opts, breakingConfig, sink)
+					})
 				}
 				if _, _, ok := parseGitRef(args[1]); ok {
-					return printReportJSONOrNoChanges(args, opts, breakingConfig, reproducible)
+					return printReportJSONOrNoChanges(args, opts, breakingConfig, output)
 				}
 			}
 
-			return printReportJSONOrNoChanges(args, opts, breakingConfig, reproducible)
+			return printReportJSONOrNoChanges(args, opts, breakingConfig, output)
 		},
 	}
 	addTerminalThemeFlags(cmd)
 	cmd.Flags().Bool("reproducible", false, "Omit generated timestamps from report JSON")
+	addReportVersionFlag(cmd)
+	cmd.Flags().String("format", reportFormatJSON, "Output format: "+reportFormatList())
+	addSquashFlag(cmd)
+	addPolicyFlag(cmd)
+	addFailOnFlag(cmd)
 	return cmd
 }
 
-func printReportJSONOrNoChanges(args []string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig, reproducible bool) error {
+func printReportJSONOrNoChanges(args []string, opts summaryOpts, breakingConfig *whatChangedModel.BreakingRulesConfig, output reportOutput) error {
 	flat, reportErr := runLeftRightReport(args[0], args[1], opts, breakingConfig)
 	if reportErr != nil {
 		return reportErr
 	}
+	return output.writeComparison(flat)
+}
+
+const (
+	reportFormatJSON          = "json"
+	reportFormatNDJSON        = "ndjson"
+	reportFormatNDJSONChanges = "ndjson-changes"
+	reportFormatCSV           = "csv"
+	reportFormatGitHub        = "github-annotations"
+	reportFormatGitLab        = "gitlab-codequality"
+)
+
+// reportFormat is an output format of the report command.
+type reportFormat struct {
+	name        string
+	description string
+}
+
+// reportFormats lists the output formats of the report command.
+var reportFormats = []reportFormat{
+	{reportFormatJSON, "a single JSON document"},
+	{reportFormatNDJSON, "one JSON line per commit report, streamed as each commit is compared"},
+	{reportFormatNDJSONChanges, "one JSON line per change, streamed as each commit is compared"},
+	{reportFormatCSV, "one spreadsheet row per change, across every commit"},
+	{reportFormatGitHub, "GitHub Actions annotations at the changed line of each spec file"},
+	{reportFormatGitLab, "a GitLab Code Quality report"},
+}
+
+func reportFormatList() string {
+	descriptions := make([]string, len(reportFormats))
+	for i, format := range reportFormats {
+		descriptions[i] = format.name + " (" + format.description + ")"
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// reportOutput controls how report JSON is written, and collects the outcome of
+// the reports it writes for the --fail-on threshold.
+type reportOutput struct {
+	reproducible bool
+	version      int
+	format       string
+	failOn       string
+	outcome      *changeOutcome
+}
+
+func addReportVersionFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("report-version", model.CurrentReportVersion,
+		fmt.Sprintf("Report JSON layout version to write (%s); pin an older version to keep its layout during upgrades",
+			reportVersionList()))
+}
+
+func reportVersionList() string {
+	versions := make([]string, len(model.ReportVersions))
+	for i, version := range model.ReportVersions {
+		versions[i] = strconv.Itoa(version)
+	}
+	return strings.Join(versions, ", ")
+}
+
+func readReportOutputFlags(cmd *cobra.Command) (reportOutput, error) {
+	reproducible, err := cmd.Flags().GetBool("reproducible")
+	if err != nil {
+		return reportOutput{}, err
+	}
+	version, err := cmd.Flags().GetInt("report-version")
+	if err != nil {
+		return reportOutput{}, err
+	}
+	if !slices.Contains(model.ReportVersions, version) {
+		return reportOutput{}, fmt.Errorf("unsupported report version %d (supported: %s)", version, reportVersionList())
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return reportOutput{}, err
+	}
+	if !slices.ContainsFunc(reportFormats, func(f reportFormat) bool { return f.name == format }) {
+		names := make([]string, len(reportFormats))
+		for i, f := range reportFormats {
+			names[i] = f.name
+		}
+		return reportOutput{}, fmt.Errorf("unknown report format '%s' (expected %s)", format, strings.Join(names, ", "))
+	}
+	failOn, err := readFailOnFlag(cmd)
+	if err != nil {
+		return reportOutput{}, err
+	}
+	return reportOutput{
+		reproducible: reproducible,
+		version:      version,
+		format:       format,
+		failOn:       failOn,
+		outcome:      &changeOutcome{},
+	}, nil
+}
+
+// record adds a report to the outcome; it runs before the report is written,
+// since older layouts leave out its severities and findings.
+func (o reportOutput) record(flat *model.FlatReport) {
+	if flat != nil && o.outcome != nil {
+		o.outcome.add(flat.Severities, flat.PolicyFindings)
+	}
+}
+
+// annotates reports whether the format annotates lines of the spec in CI.
+func (o reportOutput) annotates() bool {
+	return o.format == reportFormatGitHub || o.format == reportFormatGitLab
+}
+
+// streaming reports whether reports are written one commit at a time.
+func (o reportOutput) streaming() bool {
+	return o.format != reportFormatJSON
+}
+
+// reportStreamWriter writes the reports of a streaming format as they arrive.
+type reportStreamWriter interface {
+	writeReport(flat *model.FlatReport) error
+	// finish completes the output. history is nil for a left/right comparison.
+	finish(history *model.FlatHistoricalReport) error
+}
+
+func (o reportOutput) newStreamWriter(w io.Writer) reportStreamWriter {
+	switch o.format {
+	case reportFormatCSV:
+		return newCSVReportWriter(w)
+	case reportFormatGitHub:
+		return newGitHubAnnotationsWriter(w)
+	case reportFormatGitLab:
+		return newGitLabCodeQualityWriter(w)
+	}
+	return newNDJSONReportWriter(w, o)
+}
+
+// writeComparison writes the report of a left/right comparison, which is nil
+// when there is nothing to compare.
+func (o reportOutput) writeComparison(flat *model.FlatReport) error {
+	if o.streaming() {
+		w := o.newStreamWriter(os.Stdout)
+		if flat != nil {
+			o.record(flat)
+			if err := w.writeReport(flat); err != nil {
+				return err
+			}
+		}
+		if err := w.finish(nil); err != nil {
+			return err
+		}
+		return o.outcome.failOn(o.failOn)
+	}
 	if flat == nil {
 		printNoChangesJSON()
 		return nil
 	}
-	if reproducible {
-		makeReportOutputReproducible(flat)
+	o.record(flat)
+	if err := o.write(flat); err != nil {
+		return err
+	}
+	return o.outcome.failOn(o.failOn)
+}
+
+// writeHistory runs a history report and writes it. Streaming formats write each
+// commit's report as soon as it is ready instead of holding the whole history.
+func (o reportOutput) writeHistory(run func(reportSink) (*model.FlatHistoricalReport, error)) error {
+	if o.annotates() {
+		// every commit of a history has its own line numbers, which only match the
+		// spec as checked out for the newest one
+		return fmt.Errorf("--format %s annotates the lines of a single comparison and cannot be used with a history; "+
+			"compare two revisions instead, such as 'origin/main:openapi.yaml openapi.yaml'", o.format)
+	}
+	if o.streaming() {
+		w := o.newStreamWriter(os.Stdout)
+		history, err := run(func(flat *model.FlatReport) error {
+			o.record(flat)
+			return w.writeReport(flat)
+		})
+		if err != nil {
+			return err
+		}
+		if err := w.finish(history); err != nil {
+			return err
+		}
+		return o.outcome.failOn(o.failOn)
+	}
+	history, err := run(nil)
+	if err != nil {
+		return err
+	}
+	if history == nil {
+		printNoChangesJSON()
+		return nil
+	}
+	for _, flat := range history.Reports {
+		o.record(flat)
+	}
+	if err := o.write(history); err != nil {
+		return err
+	}
+	return o.outcome.failOn(o.failOn)
+}
+
+// write prints a report in the requested layout.
+func (o reportOutput) write(report any) error {
+	jsonBytes, err := o.encode(report)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jsonBytes))
+	return nil
+}
+
+// encode lays a report out as JSON in the requested layout.
+func (o reportOutput) encode(report any) ([]byte, error) {
+	if o.reproducible {
+		makeReportOutputReproducible(report)
+	}
+	applyReportVersion(report, o.version)
+	jsonBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return jsonBytes, nil
+}
+
+// applyReportVersion stamps a report with its layout version, removing whatever
+// that layout does not have. Only the top-level report carries the version.
+func applyReportVersion(report any, version int) {
+	switch typed := report.(type) {
+	case *model.FlatReport:
+		if typed == nil {
+			return
+		}
+		downgradeFlatReport(typed, version)
+		if version >= model.ReportVersion2 {
+			typed.ReportVersion = version
+		}
+	case *model.FlatHistoricalReport:
+		if typed == nil {
+			return
+		}
+		for _, item := range typed.Reports {
+			downgradeFlatReport(item, version)
+		}
+		if version >= model.ReportVersion2 {
+			typed.ReportVersion = version
+		}
+	}
+}
+
+func downgradeFlatReport(report *model.FlatReport, version int) {
+	if report == nil {
+		return
+	}
+	report.ReportVersion = 0
+	if version >= model.ReportVersion2 {
+		return
+	}
+	report.Severities = nil
+	report.PolicyFindings = nil
+	report.Suppressions = nil
+	for _, change := range report.Changes {
+		change.Severity = ""
+	}
+	if report.Commit != nil && len(report.Commit.Squashed) > 0 {
+		// the commit may still be read elsewhere, so its squashed commits are only left out of a copy
+		commit := *report.Commit
+		commit.Squashed = nil
+		report.Commit = &commit
 	}
-	return printReportJSON(flat)
 }
 
 func makeReportOutputReproducible(report any) {
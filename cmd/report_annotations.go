@@ -0,0 +1,314 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/openapi-changes/git"
+	"github.com/pb33f/openapi-changes/internal/severity"
+	"github.com/pb33f/openapi-changes/model"
+)
+
+// annotation is a change as CI systems annotate it: at a line of a repo-relative file.
+type annotation struct {
+	level    string // severity.Error, severity.Warning or severity.Info
+	breaking bool
+	title    string
+	message  string
+	file     string
+	line     int
+	column   int
+	hash     string
+}
+
+// annotationLocator resolves where a change belongs in the repository. Changes in
+// $ref'd files carry their repo-relative DocumentLocation; changes in the root
+// document carry no location, so the report's own spec path is used.
+type annotationLocator struct {
+	topLevels map[string]string
+}
+
+func newAnnotationLocator() *annotationLocator {
+	return &annotationLocator{topLevels: make(map[string]string)}
+}
+
+// annotations returns the annotations of a report's changes. Ignored changes are left out.
+func (l *annotationLocator) annotations(flat *model.FlatReport) []annotation {
+	rootFile := l.rootFile(flat)
+	var commitHash string
+	if flat.Commit != nil {
+		commitHash = flat.Commit.Hash
+	}
+	var annotations []annotation
+	for _, change := range flat.Changes {
+		if change == nil || change.Change == nil || change.Severity == severity.Ignore {
+			continue
+		}
+		a := annotation{
+			level:    annotationLevel(change),
+			breaking: change.Breaking,
+			title:    "Change",
+			message:  fmt.Sprintf("%s %s at %s", change.Property, describeHashedChange(change), change.Path),
+			file:     rootFile,
+			hash:     change.ChangeHash,
+		}
+		if change.Breaking {
+			a.title = "Breaking change"
+		}
+		if commitHash != "" {
+			a.title += " in " + commitHash
+		}
+		if context := change.Context; context != nil {
+			if context.DocumentLocation != "" {
+				a.file = l.repoRelative(context.DocumentLocation)
+			}
+			// removed objects have no line in the modified document, so they annotate the file
+			if context.NewLine != nil {
+				a.line = *context.NewLine
+				if context.NewColumn != nil {
+					a.column = *context.NewColumn
+				}
+			}
+		}
+		annotations = append(annotations, a)
+	}
+	return annotations
+}
+
+// fingerprint identifies an annotation by its file and change hash. It leaves out
+// the commit, so GitLab can match an issue across pipelines, and the same change
+// is only annotated once.
+func (a annotation) fingerprint() string {
+	sum := sha256.Sum256([]byte(a.file + "\x00" + a.hash))
+	return hex.EncodeToString(sum[:])
+}
+
+// annotationLevel returns the level of a change's annotation: its severity when
+// it has one, which already accounts for whether it is breaking, otherwise error
+// for breaking changes and info for the rest.
+func annotationLevel(change *model.HashedChange) string {
+	switch change.Severity {
+	case severity.Error, severity.Warning, severity.Info:
+		return change.Severity
+	}
+	if change.Breaking {
+		return severity.Error
+	}
+	return severity.Info
+}
+
+// rootFile returns the repo-relative path of the report's modified spec.
+func (l *annotationLocator) rootFile(flat *model.FlatReport) string {
+	if commit := flat.Commit; commit != nil {
+		if commit.RepoDirectory == "" {
+			// GitHub histories are already addressed by their path in the repository
+			return filepath.ToSlash(commit.FilePath)
+		}
+		return l.localFile(filepath.Join(commit.RepoDirectory, commit.FilePath))
+	}
+	source := flat.ModifiedPath
+	if isHTTPURL(source) {
+		return source
+	}
+	if _, filePath, ok := parseGitRef(source); ok {
+		source = filePath
+	}
+	return l.localFile(source)
+}
+
+func (l *annotationLocator) localFile(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return l.repoRelative(absPath)
+}
+
+// repoRelative makes an absolute path relative to the root of the git repository
+// that holds it, or to the working directory outside of one. Relative paths have
+// already been made repo-relative by the document path rewriters.
+func (l *annotationLocator) repoRelative(path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+	canonical, err := git.CanonicalizePath(path)
+	if err != nil {
+		canonical = path
+	}
+	// a file may only exist at an older revision, so ask git from its nearest existing directory
+	dir := filepath.Dir(canonical)
+	for {
+		if info, err := os.Stat(dir); (err == nil && info.IsDir()) || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	root, ok := l.topLevels[dir]
+	if !ok {
+		if topLevel, err := git.GetTopLevel(dir); err == nil {
+			root, _ = git.CanonicalizePath(topLevel)
+		}
+		l.topLevels[dir] = root
+	}
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+	if rel, err := filepath.Rel(root, canonical); err == nil && rel != ".." &&
+		!strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// githubAnnotationsWriter writes GitHub Actions workflow commands, which annotate
+// the spec inline on a pull request's diff. Annotations already written are not repeated.
+type githubAnnotationsWriter struct {
+	out     io.Writer
+	locator *annotationLocator
+	seen    map[string]struct{}
+}
+
+func newGitHubAnnotationsWriter(w io.Writer) *githubAnnotationsWriter {
+	return &githubAnnotationsWriter{out: w, locator: newAnnotationLocator(), seen: make(map[string]struct{})}
+}
+
+var githubAnnotationCommands = map[string]string{
+	severity.Error:   "error",
+	severity.Warning: "warning",
+	severity.Info:    "notice",
+}
+
+func (w *githubAnnotationsWriter) writeReport(flat *model.FlatReport) error {
+	for _, a := range w.locator.annotations(flat) {
+		fingerprint := a.fingerprint()
+		if _, ok := w.seen[fingerprint]; ok {
+			continue
+		}
+		w.seen[fingerprint] = struct{}{}
+
+		var properties []string
+		if a.file != "" {
+			properties = append(properties, "file="+escapeWorkflowProperty(a.file))
+		}
+		if a.line > 0 {
+			properties = append(properties, "line="+strconv.Itoa(a.line))
+			if a.column > 0 {
+				properties = append(properties, "col="+strconv.Itoa(a.column))
+			}
+		}
+		properties = append(properties, "title="+escapeWorkflowProperty(a.title))
+		if _, err := fmt.Fprintf(w.out, "::%s %s::%s\n", githubAnnotationCommands[a.level],
+			strings.Join(properties, ","), escapeWorkflowData(a.message)); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	return nil
+}
+
+func (w *githubAnnotationsWriter) finish(*model.FlatHistoricalReport) error {
+	return nil
+}
+
+// escapeWorkflowData escapes the message of a workflow command.
+func escapeWorkflowData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeWorkflowProperty escapes a property value of a workflow command.
+func escapeWorkflowProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
+
+// codeQualityIssue is an issue in GitLab's Code Quality report format.
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+var codeQualitySeverities = map[string]string{
+	severity.Error:   "major",
+	severity.Warning: "minor",
+	severity.Info:    "info",
+}
+
+// gitlabCodeQualityWriter writes a GitLab Code Quality report, which shows changes
+// in the merge request widget and inline on its diff. Issues are streamed into a
+// single JSON array, and issues already written are not repeated.
+type gitlabCodeQualityWriter struct {
+	out     io.Writer
+	locator *annotationLocator
+	issues  int
+	seen    map[string]struct{}
+}
+
+func newGitLabCodeQualityWriter(w io.Writer) *gitlabCodeQualityWriter {
+	return &gitlabCodeQualityWriter{out: w, locator: newAnnotationLocator(), seen: make(map[string]struct{})}
+}
+
+func (w *gitlabCodeQualityWriter) writeReport(flat *model.FlatReport) error {
+	for _, a := range w.locator.annotations(flat) {
+		fingerprint := a.fingerprint()
+		if _, ok := w.seen[fingerprint]; ok {
+			continue
+		}
+		w.seen[fingerprint] = struct{}{}
+
+		checkName := "openapi-changes/change"
+		if a.breaking {
+			checkName = "openapi-changes/breaking-change"
+		}
+		issue, err := json.Marshal(codeQualityIssue{
+			Description: a.title + ": " + a.message,
+			CheckName:   checkName,
+			Fingerprint: fingerprint,
+			Severity:    codeQualitySeverities[a.level],
+			Location:    codeQualityLocation{Path: a.file, Lines: codeQualityLines{Begin: max(a.line, 1)}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		separator := ",\n  "
+		if w.issues == 0 {
+			separator = "[\n  "
+		}
+		if _, err := fmt.Fprintf(w.out, "%s%s", separator, issue); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		w.issues++
+	}
+	return nil
+}
+
+// finish closes the array, which is empty when there were no changes.
+func (w *gitlabCodeQualityWriter) finish(*model.FlatHistoricalReport) error {
+	closing := "\n]\n"
+	if w.issues == 0 {
+		closing = "[]\n"
+	}
+	if _, err := io.WriteString(w.out, closing); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// annotationTestReport is the golden report, as a commit of a GitHub history.
+func annotationTestReport(t *testing.T) *model.FlatReport {
+	t.Helper()
+	flat := goldenFlatReport(t)
+	flat.Commit = &model.Commit{Hash: "abc1234", FilePath: "specs/openapi.yaml"}
+	flat.Changes[1].Breaking = true
+	flat.Changes[1].Severity = "error"
+	return flat
+}
+
+func TestGitHubAnnotationsWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := newGitHubAnnotationsWriter(&buf)
+	require.NoError(t, w.writeReport(annotationTestReport(t)))
+	// the same changes are not annotated twice
+	require.NoError(t, w.writeReport(annotationTestReport(t)))
+	require.NoError(t, w.finish(nil))
+
+	assert.Equal(t, `::notice file=specs/openapi.yaml,line=12,title=Change in abc1234::post added "summary: <create> & store\n" at $.paths['/pets']
+::error file=openapi.yaml,line=8,col=11,title=Breaking change in abc1234::required modified "false" -> "true" at $.paths['/pets'].get.parameters['limit']
+`, buf.String())
+}
+
+func TestGitHubAnnotationsWriter_LevelsAndIgnoredChanges(t *testing.T) {
+	var buf bytes.Buffer
+	w := newGitHubAnnotationsWriter(&buf)
+	flat := annotationTestReport(t)
+	flat.Changes[0].Severity = "ignore"
+	flat.Changes[1].Breaking = false
+	flat.Changes[1].Severity = "warning"
+	require.NoError(t, w.writeReport(flat))
+
+	assert.Equal(t, `::warning file=openapi.yaml,line=8,col=11,title=Change in abc1234::required modified "false" -> "true" at $.paths['/pets'].get.parameters['limit']
+`, buf.String())
+}
+
+func TestAnnotationLevel(t *testing.T) {
+	change := annotationTestReport(t).Changes[1]
+	assert.Equal(t, "error", annotationLevel(change))
+
+	change.Severity = "warning"
+	assert.Equal(t, "warning", annotationLevel(change), "a configured severity wins over breaking")
+	change.Severity = "info"
+	assert.Equal(t, "info", annotationLevel(change))
+
+	change.Severity = ""
+	assert.Equal(t, "error", annotationLevel(change), "breaking changes without a severity are errors")
+	change.Breaking = false
+	assert.Equal(t, "info", annotationLevel(change))
+}
+
+func TestEscapeWorkflowCommands(t *testing.T) {
+	assert.Equal(t, "100%25 done%0Anext: a, b", escapeWorkflowData("100% done\nnext: a, b"))
+	assert.Equal(t, "C%3A/specs/a%2Cb.yaml", escapeWorkflowProperty("C:/specs/a,b.yaml"))
+}
+
+func TestGitLabCodeQualityWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := newGitLabCodeQualityWriter(&buf)
+	require.NoError(t, w.writeReport(annotationTestReport(t)))
+	// the same changes in a later commit are not reported twice
+	require.NoError(t, w.writeReport(annotationTestReport(t)))
+	require.NoError(t, w.finish(nil))
+
+	var issues []codeQualityIssue
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &issues), buf.String())
+	require.Len(t, issues, 2)
+	assert.Equal(t, "openapi-changes/change", issues[0].CheckName)
+	assert.Equal(t, "info", issues[0].Severity)
+	assert.Equal(t, codeQualityLocation{Path: "specs/openapi.yaml", Lines: codeQualityLines{Begin: 12}}, issues[0].Location)
+	assert.Equal(t, "openapi-changes/breaking-change", issues[1].CheckName)
+	assert.Equal(t, "major", issues[1].Severity)
+	assert.Equal(t, `Breaking change in abc1234: required modified "false" -> "true" at $.paths['/pets'].get.parameters['limit']`,
+		issues[1].Description)
+	assert.Len(t, issues[1].Fingerprint, 64)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+}
+
+func TestGitLabCodeQualityWriter_NoChanges(t *testing.T) {
+	var buf bytes.Buffer
+	w := newGitLabCodeQualityWriter(&buf)
+	require.NoError(t, w.writeReport(&model.FlatReport{}))
+	require.NoError(t, w.finish(nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestAnnotationLocator_RepoRelativeFiles(t *testing.T) {
+	repoDir := t.TempDir()
+	runGitInDir(t, repoDir, "init")
+	specsDir := filepath.Join(repoDir, "specs")
+	require.NoError(t, os.MkdirAll(specsDir, 0o755))
+	chdirForTest(t, specsDir)
+
+	locator := newAnnotationLocator()
+	history := &model.FlatReport{Commit: &model.Commit{RepoDirectory: ".", FilePath: "openapi.yaml"}}
+	assert.Equal(t, "specs/openapi.yaml", locator.rootFile(history))
+
+	comparison := &model.FlatReport{ModifiedPath: "HEAD:openapi.yaml"}
+	assert.Equal(t, "specs/openapi.yaml", locator.rootFile(comparison))
+	comparison.ModifiedPath = "https://example.com/openapi.yaml"
+	assert.Equal(t, "https://example.com/openapi.yaml", locator.rootFile(comparison))
+
+	assert.Equal(t, "specs/common/pet.yaml", locator.repoRelative(filepath.Join(specsDir, "common", "pet.yaml")))
+	assert.Equal(t, "specs/common/pet.yaml", locator.repoRelative("specs/common/pet.yaml"),
+		"rewritten document locations are already repo-relative")
+}
+
+func TestReportCommand_AnnotationsRejectHistory(t *testing.T) {
+	repoDir := createGitSpecRepoForFile(t, "openapi.yaml")
+	for _, format := range []string{reportFormatGitHub, reportFormatGitLab} {
+		t.Run(format, func(t *testing.T) {
+			err := testRootCmd(GetReportCommand(), "--format", format, repoDir, "openapi.yaml").Execute()
+			assert.ErrorContains(t, err, "annotates the lines of a single comparison")
+		})
+	}
+}
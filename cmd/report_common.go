@@ -16,6 +16,14 @@ func createReport(commit *model.Commit) *model.Report {
 	return &model.Report{Summary: report.ChangeReport, Commit: commit}
 }
 
+// createResultReport creates the report of a commit holding the changes of a
+// changerator result, along with the breaking changes the result suppressed.
+func createResultReport(commit *model.Commit, result *changeratorResult) *model.Report {
+	report := createReport(commit)
+	report.Suppressions = result.Suppressions
+	return report
+}
+
 func writeReportFile(reportFile string, report []byte, styles commandStyles) error {
 	err := os.WriteFile(reportFile, report, 0644)
 	if err != nil {
@@ -0,0 +1,143 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pb33f/openapi-changes/model"
+)
+
+// csvColumns is the header row of CSV report output.
+var csvColumns = []string{
+	"commit", "date", "author", "path", "parameter", "property", "type", "change",
+	"breaking", "severity", "original", "new", "originalLine", "newLine", "changeHash",
+}
+
+// utf8BOM lets spreadsheet applications detect that the CSV is UTF-8.
+const utf8BOM = "\ufeff"
+
+// csvReportWriter writes one row per change, with every commit in a single sheet.
+// Rows are quoted the way spreadsheet applications expect: CRLF line endings,
+// a byte order mark, and values that would be read as formulas escaped.
+type csvReportWriter struct {
+	out     io.Writer
+	writer  *csv.Writer
+	started bool
+}
+
+func newCSVReportWriter(w io.Writer) *csvReportWriter {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+	return &csvReportWriter{out: w, writer: writer}
+}
+
+func (w *csvReportWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	if _, err := io.WriteString(w.out, utf8BOM); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return w.writer.Write(csvColumns)
+}
+
+// writeReport writes a row for each of a commit's changes, in report order.
+func (w *csvReportWriter) writeReport(flat *model.FlatReport) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	var hash, date, author string
+	if commit := flat.Commit; commit != nil {
+		hash, author = commit.Hash, commit.Author
+		if !commit.CommitDate.IsZero() {
+			date = commit.CommitDate.UTC().Format(time.RFC3339)
+		}
+	}
+	for _, change := range flat.Changes {
+		if change == nil || change.Change == nil {
+			continue
+		}
+		var originalLine, newLine string
+		if context := change.Context; context != nil {
+			originalLine, newLine = csvLine(context.OriginalLine), csvLine(context.NewLine)
+		}
+		row := []string{
+			hash, date, author, change.Path, changeParameterName(change.Path), change.Property, change.Type,
+			change.ChangeText(), strconv.FormatBool(change.Breaking), change.Severity,
+			change.Original, change.New, originalLine, newLine, change.ChangeHash,
+		}
+		for i := range row {
+			row[i] = csvCell(row[i])
+		}
+		if err := w.writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	return w.flush()
+}
+
+// finish writes the header when there were no reports, so the output is always a valid sheet.
+func (w *csvReportWriter) finish(*model.FlatHistoricalReport) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+func (w *csvReportWriter) flush() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+func csvLine(line *int) string {
+	if line == nil {
+		return ""
+	}
+	return strconv.Itoa(*line)
+}
+
+// csvCell escapes values that spreadsheet applications would evaluate as a
+// formula, by prefixing them with a quote. Numbers are left as they are.
+func csvCell(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return "'" + value
+}
+
+// changeParameterName returns the name of the parameter a normalized path points
+// into, such as limit for $.paths['/pets'].get.parameters['limit'].required.
+func changeParameterName(path string) string {
+	idx := strings.LastIndex(path, "parameters['")
+	if idx < 0 {
+		return ""
+	}
+	rest := path[idx+len("parameters['"):]
+	var name strings.Builder
+	for i := 0; i < len(rest); i++ {
+		switch {
+		case rest[i] == '\\' && i+1 < len(rest) && rest[i+1] == '\'':
+			name.WriteByte('\'')
+			i++
+		case rest[i] == '\'':
+			return name.String()
+		default:
+			name.WriteByte(rest[i])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVReportWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := newCSVReportWriter(&buf)
+	flat := goldenFlatReport(t)
+	flat.Commit = &model.Commit{Hash: "abc1234", Author: "Jane Doe",
+		CommitDate: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	flat.Changes[0].Original = "=HYPERLINK(\"http://example.com\")"
+	flat.Changes[1].New = "-1"
+	require.NoError(t, w.writeReport(flat))
+	require.NoError(t, w.writeReport(&model.FlatReport{Commit: &model.Commit{Hash: "def5678"}}))
+	require.NoError(t, w.finish(nil))
+
+	output := buf.String()
+	require.True(t, strings.HasPrefix(output, utf8BOM))
+	assert.Contains(t, output, "\r\n")
+	rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(output, utf8BOM))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, csvColumns, rows[0])
+
+	// flattened changes are sorted, which puts the added operation first; the
+	// required parameter is suppressed, so it is no longer breaking
+	assert.Equal(t, []string{"abc1234", "2026-01-02T03:04:05Z", "Jane Doe", "$.paths['/pets']", "", "post", "",
+		"object_added", "false", "info", "'=HYPERLINK(\"http://example.com\")", "post", "", "12",
+		flat.Changes[0].ChangeHash}, rows[1])
+	assert.Equal(t, []string{"abc1234", "2026-01-02T03:04:05Z", "Jane Doe",
+		"$.paths['/pets'].get.parameters['limit']", "limit", "required", "", "modified", "false", "warning",
+		"false", "-1", "7", "8", flat.Changes[1].ChangeHash}, rows[2])
+}
+
+func TestCSVReportWriter_EmptyHistoryWritesHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w := newCSVReportWriter(&buf)
+	require.NoError(t, w.finish(&model.FlatHistoricalReport{}))
+	assert.Equal(t, utf8BOM+strings.Join(csvColumns, ",")+"\r\n", buf.String())
+}
+
+func TestCSVCell(t *testing.T) {
+	assert.Equal(t, "", csvCell(""))
+	assert.Equal(t, "$.paths", csvCell("$.paths"))
+	assert.Equal(t, "-1.5", csvCell("-1.5"))
+	assert.Equal(t, "'=1+2", csvCell("=1+2"))
+	assert.Equal(t, "'+cmd", csvCell("+cmd"))
+	assert.Equal(t, "'@SUM(A1)", csvCell("@SUM(A1)"))
+	assert.Equal(t, "'-x", csvCell("-x"))
+}
+
+func TestChangeParameterName(t *testing.T) {
+	assert.Equal(t, "limit", changeParameterName("$.paths['/pets'].get.parameters['limit']"))
+	assert.Equal(t, "it's", changeParameterName("$.paths['/pets'].get.parameters['it\\'s'].schema"))
+	assert.Equal(t, "", changeParameterName("$.paths['/pets'].get.parameters[0]"))
+	assert.Equal(t, "", changeParameterName("$.paths['/pets']"))
+}
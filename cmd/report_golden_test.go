@@ -0,0 +1,194 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/libopenapi/what-changed/reports"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateGolden rewrites the golden report files: go test ./cmd -run Golden -update
+var updateGolden = flag.Bool("update", false, "update golden report files")
+
+// goldenFlatReport builds a report that exercises every field of the report layout.
+func goldenFlatReport(t *testing.T) *model.FlatReport {
+	t.Helper()
+	required := &wcModel.Change{Path: "$.paths['/pets'].get.parameters[0]", Property: "required",
+		ChangeType: wcModel.Modified, Original: "false", New: "true", Breaking: true,
+		Context: &wcModel.ChangeContext{DocumentLocation: "openapi.yaml", OriginalLine: intPtr(7),
+			OriginalColumn: intPtr(11), NewLine: intPtr(8), NewColumn: intPtr(11)}}
+	added := &wcModel.Change{Path: "$.paths['/pets']", Property: "post", ChangeType: wcModel.ObjectAdded,
+		New: "post", NewEncoded: "summary: <create> & store\n", Context: &wcModel.ChangeContext{NewLine: intPtr(12)}}
+	commit := suppressionTestCommit(required, added)
+	commit.CommitDate = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	suppressions := suppressBreakingChanges(commit, commit.Changes)
+	report := createReport(commit)
+	report.Suppressions = suppressions
+
+	flat := FlattenReportWithParameterNames(report,
+		map[string]string{"$.paths['/pets'].get.parameters[0]": "limit"})
+	flat.Commit = nil
+	flat.OriginalPath, flat.ModifiedPath = "a.yaml", "b.yaml"
+	flat.DateGenerated = "2026-01-02T03:04:05Z"
+	// flattened changes are sorted, which puts the added operation first
+	flat.Changes[0].Severity = "info"
+	flat.Changes[1].Severity = "warning"
+	flat.Severities = map[string]int{"warning": 1, "info": 1}
+	flat.PolicyFindings = []*model.PolicyFinding{{Rule: "no-required-params", Severity: "error",
+		Message: "required parameters break clients", ChangeHash: flat.Changes[1].ChangeHash,
+		Path: flat.Changes[1].Path, Property: flat.Changes[1].Property}}
+	return flat
+}
+
+func goldenHistoricalReport(t *testing.T) *model.FlatHistoricalReport {
+	return &model.FlatHistoricalReport{
+		GitRepoPath:   ".",
+		GitFilePath:   "specs/openapi.yaml",
+		Filename:      "openapi.yaml",
+		DateGenerated: "2026-01-02T03:04:05Z",
+		MetaData:      &model.HistoricalReportMetaData{Partial: true, SkippedCommits: []string{"def5678"}},
+		Reports:       []*model.FlatReport{goldenFlatReport(t)},
+	}
+}
+
+// goldenSquashedHistoricalReport is a history report whose commit was squashed
+// from two revisions, which the version 1 layout does not list.
+func goldenSquashedHistoricalReport() *model.FlatHistoricalReport {
+	date := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &model.FlatHistoricalReport{
+		GitRepoPath:   ".",
+		GitFilePath:   "specs/openapi.yaml",
+		Filename:      "openapi.yaml",
+		DateGenerated: "2026-01-02T03:04:05Z",
+		Reports: []*model.FlatReport{{
+			Summary: map[string]*reports.Changed{"post": {Total: 1}},
+			Changes: []*model.HashedChange{{Change: &wcModel.Change{Path: "$.paths['/pets']", Property: "post",
+				ChangeType: wcModel.ObjectAdded, New: "post", Context: &wcModel.ChangeContext{}}, ChangeHash: "pets-post"}},
+			DateGenerated: "2026-01-02T03:04:05Z",
+			Commit: &model.Commit{Hash: "def5678", Message: "add pets", Author: "jane",
+				AuthorEmail: "jane@example.com", CommitDate: date, Squashed: []*model.SquashedCommit{
+					{Hash: "abc1234", Message: "add pets", Author: "jane", CommitDate: date},
+					{Hash: "def5678", Message: "document pets", Author: "jane", CommitDate: date},
+				}},
+		}},
+	}
+}
+
+func assertGolden(t *testing.T, name string, report any) {
+	t.Helper()
+	actual, err := json.MarshalIndent(report, "", "  ")
+	require.NoError(t, err)
+	actual = append(actual, '\n')
+
+	path := filepath.Join("test_files", "golden", name)
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual),
+		"the layout of %s changed; if that is intended, add a report version and run with -update", name)
+}
+
+func TestReportGolden(t *testing.T) {
+	for _, version := range model.ReportVersions {
+		flat := goldenFlatReport(t)
+		applyReportVersion(flat, version)
+		assertGolden(t, "report-v"+strconv.Itoa(version)+".json", flat)
+
+		historical := goldenHistoricalReport(t)
+		applyReportVersion(historical, version)
+		assertGolden(t, "historical-report-v"+strconv.Itoa(version)+".json", historical)
+	}
+}
+
+// TestReportGolden_SquashedV1MatchesBaseline checks a version 1 report of a
+// squashed history against the output of the release before report versions,
+// which had no squashed commits to write.
+func TestReportGolden_SquashedV1MatchesBaseline(t *testing.T) {
+	historical := goldenSquashedHistoricalReport()
+	commit := historical.Reports[0].Commit
+	applyReportVersion(historical, model.ReportVersion1)
+	assertGolden(t, "historical-report-squashed-v1.json", historical)
+	assert.Len(t, commit.Squashed, 2, "the commit itself keeps its squashed commits")
+
+	historical = goldenSquashedHistoricalReport()
+	applyReportVersion(historical, model.CurrentReportVersion)
+	data, err := json.Marshal(historical)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"squashedCommits"`)
+}
+
+// TestHashedChange_MarshalJSONMatchesLibopenapi guards the frozen change layout
+// against the JSON libopenapi writes for a change, which it used to be built from.
+func TestHashedChange_MarshalJSONMatchesLibopenapi(t *testing.T) {
+	for _, change := range goldenFlatReport(t).Changes {
+		libopenapiJSON, err := change.Change.MarshalJSON()
+		require.NoError(t, err)
+		var expected map[string]any
+		require.NoError(t, json.Unmarshal(libopenapiJSON, &expected))
+		expected["changeHash"] = change.ChangeHash
+		if change.RawPath != "" {
+			expected["rawPath"] = change.RawPath
+		}
+		if change.Severity != "" {
+			expected["severity"] = change.Severity
+		}
+
+		actual, err := json.Marshal(change)
+		require.NoError(t, err)
+		expectedJSON, err := json.Marshal(expected)
+		require.NoError(t, err)
+		assert.Equal(t, string(expectedJSON), string(actual))
+	}
+}
+
+func TestApplyReportVersion(t *testing.T) {
+	flat := goldenFlatReport(t)
+	applyReportVersion(flat, model.ReportVersion1)
+	assert.Zero(t, flat.ReportVersion)
+	assert.Nil(t, flat.Severities)
+	assert.Nil(t, flat.PolicyFindings)
+	assert.Nil(t, flat.Suppressions)
+	assert.Empty(t, flat.Changes[1].Severity)
+
+	historical := goldenHistoricalReport(t)
+	applyReportVersion(historical, model.CurrentReportVersion)
+	assert.Equal(t, model.CurrentReportVersion, historical.ReportVersion)
+	assert.Zero(t, historical.Reports[0].ReportVersion, "nested reports are not versioned")
+	assert.NotNil(t, historical.Reports[0].Severities)
+}
+
+func TestReportOutputEncode_AppliesReportVersion(t *testing.T) {
+	data, err := reportOutput{version: model.CurrentReportVersion}.encode(goldenFlatReport(t))
+	require.NoError(t, err)
+	var encoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &encoded))
+	assert.EqualValues(t, model.CurrentReportVersion, encoded["reportVersion"])
+
+	data, err = reportOutput{version: model.ReportVersion1}.encode(goldenFlatReport(t))
+	require.NoError(t, err)
+	encoded = nil
+	require.NoError(t, json.Unmarshal(data, &encoded))
+	assert.NotContains(t, encoded, "reportVersion")
+	assert.NotContains(t, encoded, "severities")
+}
+
+func TestReportCommand_RejectsUnknownReportVersion(t *testing.T) {
+	err := testRootCmd(GetReportCommand(), "--report-version", "3",
+		"../sample-specs/petstorev3-original.json", "../sample-specs/petstorev3.json").Execute()
+	assert.EqualError(t, err, "unsupported report version 3 (supported: 1, 2)")
+}
@@ -0,0 +1,150 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pb33f/openapi-changes/model"
+)
+
+// Line types of NDJSON report output. Every line is a JSON object with a type.
+const (
+	ndjsonTypeReport        = "report"
+	ndjsonTypeChange        = "change"
+	ndjsonTypePolicyFinding = "policyFinding"
+	ndjsonTypeSuppression   = "suppression"
+	ndjsonTypeMetadata      = "metadata"
+)
+
+type ndjsonReportLine struct {
+	Type string `json:"type"`
+	*model.FlatReport
+}
+
+// ndjsonChangeLine nests the change, as a change writes its own JSON.
+type ndjsonChangeLine struct {
+	Type       string              `json:"type"`
+	CommitHash string              `json:"commitHash,omitempty"`
+	Change     *model.HashedChange `json:"change"`
+}
+
+type ndjsonPolicyFindingLine struct {
+	Type string `json:"type"`
+	*model.PolicyFinding
+}
+
+type ndjsonSuppressionLine struct {
+	Type       string `json:"type"`
+	CommitHash string `json:"commitHash,omitempty"`
+	*model.Suppression
+}
+
+// ndjsonMetadata is the trailing line of NDJSON output, written once every
+// report has been streamed.
+type ndjsonMetadata struct {
+	Type           string   `json:"type"`
+	ReportVersion  int      `json:"reportVersion,omitempty"`
+	GitRepoPath    string   `json:"gitRepoPath,omitempty"`
+	GitFilePath    string   `json:"gitFilePath,omitempty"`
+	Filename       string   `json:"filename,omitempty"`
+	DateGenerated  string   `json:"dateGenerated,omitempty"`
+	Reports        int      `json:"reports"`
+	Changes        int      `json:"changes"`
+	Partial        bool     `json:"partial"`
+	SkippedCommits []string `json:"skippedCommits,omitempty"`
+}
+
+// historyMetadata returns the metadata line for a streamed history report.
+func historyMetadata(history *model.FlatHistoricalReport) ndjsonMetadata {
+	if history == nil {
+		return ndjsonMetadata{}
+	}
+	metadata := ndjsonMetadata{
+		GitRepoPath:   history.GitRepoPath,
+		GitFilePath:   history.GitFilePath,
+		Filename:      history.Filename,
+		DateGenerated: history.DateGenerated,
+	}
+	if history.MetaData != nil {
+		metadata.Partial = history.MetaData.Partial
+		metadata.SkippedCommits = history.MetaData.SkippedCommits
+	}
+	return metadata
+}
+
+// ndjsonReportWriter writes reports as newline-delimited JSON, one line per
+// commit report or one per change, as each report arrives.
+type ndjsonReportWriter struct {
+	encoder *json.Encoder
+	output  reportOutput
+	reports int
+	changes int
+}
+
+func newNDJSONReportWriter(w io.Writer, output reportOutput) *ndjsonReportWriter {
+	return &ndjsonReportWriter{encoder: json.NewEncoder(w), output: output}
+}
+
+func (w *ndjsonReportWriter) line(v any) error {
+	if err := w.encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// writeReport writes a commit's report in the requested layout.
+func (w *ndjsonReportWriter) writeReport(flat *model.FlatReport) error {
+	w.reports++
+	w.changes += len(flat.Changes)
+	if w.output.reproducible {
+		makeFlatReportReproducible(flat)
+	}
+	applyReportVersion(flat, w.output.version)
+
+	if w.output.format != reportFormatNDJSONChanges {
+		return w.line(ndjsonReportLine{Type: ndjsonTypeReport, FlatReport: flat})
+	}
+	var commitHash string
+	if flat.Commit != nil {
+		commitHash = flat.Commit.Hash
+	}
+	for _, change := range flat.Changes {
+		if err := w.line(ndjsonChangeLine{Type: ndjsonTypeChange, CommitHash: commitHash, Change: change}); err != nil {
+			return err
+		}
+	}
+	for _, finding := range flat.PolicyFindings {
+		if err := w.line(ndjsonPolicyFindingLine{Type: ndjsonTypePolicyFinding, PolicyFinding: finding}); err != nil {
+			return err
+		}
+	}
+	for _, suppression := range flat.Suppressions {
+		if err := w.line(ndjsonSuppressionLine{Type: ndjsonTypeSuppression, CommitHash: commitHash, Suppression: suppression}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish writes the trailing metadata line.
+func (w *ndjsonReportWriter) finish(history *model.FlatHistoricalReport) error {
+	metadata := historyMetadata(history)
+	metadata.Type = ndjsonTypeMetadata
+	metadata.Reports = w.reports
+	metadata.Changes = w.changes
+	if w.output.version >= model.ReportVersion2 {
+		metadata.ReportVersion = w.output.version
+	}
+	switch {
+	case w.output.reproducible:
+		metadata.DateGenerated = ""
+	case metadata.DateGenerated == "":
+		metadata.DateGenerated = time.Now().Format(time.RFC3339)
+	}
+	return w.line(metadata)
+}
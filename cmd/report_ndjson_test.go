@@ -0,0 +1,135 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ndjsonLines(t *testing.T, output string) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(nil, 1<<24)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line), scanner.Text())
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func TestNDJSONReportWriter_Reports(t *testing.T) {
+	var buf bytes.Buffer
+	w := newNDJSONReportWriter(&buf, reportOutput{reproducible: true, version: model.CurrentReportVersion, format: reportFormatNDJSON})
+	require.NoError(t, w.writeReport(goldenFlatReport(t)))
+	require.NoError(t, w.finish(goldenHistoricalReport(t)))
+
+	lines := ndjsonLines(t, buf.String())
+	require.Len(t, lines, 2)
+	assert.Equal(t, "report", lines[0]["type"])
+	assert.EqualValues(t, model.CurrentReportVersion, lines[0]["reportVersion"])
+	assert.Len(t, lines[0]["changes"], 2)
+	assert.NotContains(t, lines[0], "dateGenerated")
+
+	assert.Equal(t, map[string]any{
+		"type":           "metadata",
+		"reportVersion":  float64(model.CurrentReportVersion),
+		"gitRepoPath":    ".",
+		"gitFilePath":    "specs/openapi.yaml",
+		"filename":       "openapi.yaml",
+		"reports":        float64(1),
+		"changes":        float64(2),
+		"partial":        true,
+		"skippedCommits": []any{"def5678"},
+	}, lines[1])
+}
+
+func TestNDJSONReportWriter_Changes(t *testing.T) {
+	var buf bytes.Buffer
+	w := newNDJSONReportWriter(&buf, reportOutput{version: model.CurrentReportVersion, format: reportFormatNDJSONChanges})
+	flat := goldenFlatReport(t)
+	flat.Commit = &model.Commit{Hash: "abc1234"}
+	require.NoError(t, w.writeReport(flat))
+	require.NoError(t, w.finish(nil))
+
+	lines := ndjsonLines(t, buf.String())
+	types := make([]string, len(lines))
+	for i, line := range lines {
+		types[i] = line["type"].(string)
+	}
+	assert.Equal(t, []string{"change", "change", "policyFinding", "suppression", "metadata"}, types)
+	assert.Equal(t, "abc1234", lines[0]["commitHash"])
+	assert.Equal(t, "post", lines[0]["change"].(map[string]any)["property"])
+	assert.Equal(t, "no-required-params", lines[2]["rule"])
+	assert.Equal(t, "abc1234", lines[3]["commitHash"])
+	assert.NotEmpty(t, lines[4]["dateGenerated"])
+	assert.Equal(t, false, lines[4]["partial"])
+}
+
+func TestNDJSONReportWriter_VersionOne(t *testing.T) {
+	var buf bytes.Buffer
+	output := reportOutput{reproducible: true, version: model.ReportVersion1, format: reportFormatNDJSON,
+		outcome: &changeOutcome{}}
+	w := newNDJSONReportWriter(&buf, output)
+	flat := goldenFlatReport(t)
+	output.record(flat)
+	require.NoError(t, w.writeReport(flat))
+	require.NoError(t, w.finish(nil))
+
+	lines := ndjsonLines(t, buf.String())
+	require.Len(t, lines, 2)
+	assert.NotContains(t, lines[0], "reportVersion")
+	assert.NotContains(t, lines[0], "policyFindings")
+	assert.NotContains(t, lines[1], "reportVersion")
+	require.Len(t, output.outcome.findings, 1, "findings still decide the exit status of a v1 report")
+}
+
+func TestReportCommand_NDJSONHistory(t *testing.T) {
+	repoDir := createGitSpecRepoForFile(t, "openapi.yaml")
+
+	output := captureStdout(t, func() {
+		require.NoError(t, testRootCmd(GetReportCommand(), "--format", "ndjson", "--reproducible",
+			repoDir, "openapi.yaml").Execute())
+	})
+
+	lines := ndjsonLines(t, output)
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines[:len(lines)-1] {
+		assert.Equal(t, "report", line["type"])
+		assert.NotEmpty(t, line["commitDetails"])
+	}
+	metadata := lines[len(lines)-1]
+	assert.Equal(t, "metadata", metadata["type"])
+	assert.EqualValues(t, len(lines)-1, metadata["reports"])
+	assert.Equal(t, "openapi.yaml", metadata["gitFilePath"])
+}
+
+func TestReportCommand_NDJSONChangesComparison(t *testing.T) {
+	output := captureStdout(t, func() {
+		require.NoError(t, testRootCmd(GetReportCommand(), "--format", "ndjson-changes", "--fail-on", "none",
+			"../sample-specs/petstorev3-original.json", "../sample-specs/petstorev3.json").Execute())
+	})
+
+	lines := ndjsonLines(t, output)
+	require.Greater(t, len(lines), 1)
+	assert.Equal(t, "change", lines[0]["type"])
+	assert.Contains(t, lines[0]["change"], "changeHash")
+	assert.Equal(t, "metadata", lines[len(lines)-1]["type"])
+}
+
+func TestReportCommand_RejectsUnknownFormat(t *testing.T) {
+	err := testRootCmd(GetReportCommand(), "--format", "xml",
+		"../sample-specs/petstorev3-original.json", "../sample-specs/petstorev3.json").Execute()
+	assert.EqualError(t, err, "unknown report format 'xml' (expected json, ndjson, ndjson-changes, csv, github-annotations, gitlab-codequality)")
+}
@@ -172,6 +172,7 @@ func TestReportCommand_ReproducibleOutputIsStableAcrossRuns(t *testing.T) {
 		"--no-logo",
 		"--no-color",
 		"--reproducible",
+		"--fail-on", "none",
 		"../sample-specs/petstorev3-original.json",
 		"../sample-specs/petstorev3.json",
 	}
@@ -509,7 +510,7 @@ func TestReportCommand_GitRefUsesLeftRightMode(t *testing.T) {
 	repoRoot := filepath.Clean(filepath.Join(wd, ".."))
 	chdirForTest(t, repoRoot)
 
-	cmd := testRootCmd(GetReportCommand(),
+	cmd := testRootCmd(GetReportCommand(), "--fail-on", "none",
 		"HEAD:sample-specs/petstorev3-original.json",
 		"sample-specs/petstorev3.json",
 	)
@@ -24,6 +24,12 @@ var (
 	it can compare between two files, or a single file, over time.
 
 	The comparison and reporting commands use the current doctor-based engine.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if projectConfigErr != nil {
+				return projectConfigErr
+			}
+			return applyProjectConfig(cmd, projectConfig, os.LookupEnv)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts, _, err := readCommonFlags(cmd)
 			if err != nil {
@@ -71,7 +77,13 @@ func init() {
 	rootCmd.AddCommand(GetConsoleCommand())
 	rootCmd.AddCommand(GetHTMLReportCommand())
 	rootCmd.AddCommand(GetMarkdownReportCommand())
+	rootCmd.AddCommand(GetMergeCheckCommand())
+	rootCmd.AddCommand(GetBlameCommand())
+	rootCmd.AddCommand(GetChangelogCommand())
+	rootCmd.AddCommand(GetDeprecationsCommand())
 	rootCmd.AddCommand(GetReportCommand())
+	rootCmd.AddCommand(GetRulesCommand())
+	rootCmd.AddCommand(GetSchemaCommand())
 	rootCmd.AddCommand(GetSummaryCommand())
 	rootCmd.AddCommand(GetVersionCommand())
 	rootCmd.PersistentFlags().BoolP("top", "t", false, "Only show latest changes (last git revision against HEAD)")
@@ -84,8 +96,11 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("remote", "r", true, "Allow remote reference (URLs and files) to be auto resolved, without a base URL or path (default is on)")
 	rootCmd.PersistentFlags().BoolP("ext-refs", "", false, "Turn on $ref lookups and resolving for extensions (x-) objects")
 	rootCmd.PersistentFlags().StringP("config", "c", "", "Path to breaking rules config file (default: ./changes-rules.yaml or ~/.config/changes-rules.yaml)")
+	rootCmd.PersistentFlags().String("profile", "", "Apply a named profile from the .openapi-changes.yaml project config")
 }
 
+// initConfig discovers the .openapi-changes.yaml project config. Its flag defaults are
+// applied to the running command before it runs, along with OPENAPI_CHANGES_* overrides.
 func initConfig() {
-
+	projectConfig, projectConfigErr = LoadProjectConfig("")
 }
@@ -0,0 +1,155 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+)
+
+const noRulesConfigMessage = "No breaking rules config found; the default rules apply"
+
+// rulesConfigForArgs loads the rules config named by the optional argument, then
+// --config, then the default locations.
+func rulesConfigForArgs(cmd *cobra.Command, args []string) (*RulesConfig, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	if len(args) == 1 {
+		configPath = args[0]
+	}
+	return LoadRulesConfig(configPath)
+}
+
+// renderRulesConfigYAML renders a rules config as YAML, with its sources as a
+// leading comment. The effective config is layered on top of the default rules.
+func renderRulesConfigYAML(config *RulesConfig, effective bool) ([]byte, error) {
+	breaking := &whatChangedModel.BreakingRulesConfig{}
+	if effective {
+		breaking = whatChangedModel.GenerateDefaultBreakingRules()
+	}
+	if config != nil {
+		breaking.Merge(config.Breaking)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(breaking); err != nil {
+		return nil, fmt.Errorf("failed to encode breaking rules: %w", err)
+	}
+	if config != nil && len(config.Severities) > 0 {
+		var severities yaml.Node
+		if err := severities.Encode(config.Severities); err != nil {
+			return nil, fmt.Errorf("failed to encode severities: %w", err)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "severities"}, &severities)
+	}
+
+	var buf bytes.Buffer
+	for _, source := range rulesConfigSources(config, effective) {
+		buf.WriteString("# " + source + "\n")
+	}
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to write rules config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write rules config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func rulesConfigSources(config *RulesConfig, effective bool) []string {
+	var sources []string
+	if effective {
+		sources = append(sources, "default rules")
+	}
+	if config != nil {
+		sources = append(sources, config.Extends...)
+		sources = append(sources, config.Path)
+	}
+	return sources
+}
+
+func getRulesPrintCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "print [config]",
+		Short:        "Print the breaking rules config with everything it extends merged in",
+		Long: "Print the breaking rules config, with the files it extends merged underneath it. " +
+			"With --effective, the result is layered on top of the default rules, exactly as comparisons use it.",
+		Example: "openapi-changes rules print --effective ./changes-rules.yaml",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := rulesConfigForArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+			effective, _ := cmd.Flags().GetBool("effective")
+			if config == nil && !effective {
+				fmt.Println(noRulesConfigMessage)
+				return nil
+			}
+			out, err := renderRulesConfigYAML(config, effective)
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(out))
+			return nil
+		},
+	}
+	cmd.Flags().Bool("effective", false, "Layer the config on top of the default rules")
+	return cmd
+}
+
+func getRulesValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "validate [config]",
+		Short:        "Validate the breaking rules config without running a comparison",
+		Long:         "Validate the breaking rules config and every file it extends, without running a comparison.",
+		Example:      "openapi-changes rules validate ./changes-rules.yaml",
+		Args:         cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, _, err := readCommonFlags(cmd)
+			if err != nil {
+				return err
+			}
+			config, err := rulesConfigForArgs(cmd, args)
+			if err != nil {
+				PrintConfigError(err, opts.palette)
+				return err
+			}
+			if config == nil {
+				fmt.Println(noRulesConfigMessage)
+				return nil
+			}
+			styles := commandStylesFor(opts.palette)
+			fmt.Println(styles.success.Render(fmt.Sprintf("breaking rules config '%s' is valid", config.Path)))
+			if len(config.Extends) > 0 {
+				fmt.Printf("  extends: %s\n", strings.Join(config.Extends, ", "))
+			}
+			return nil
+		},
+	}
+	addTerminalThemeFlags(cmd)
+	return cmd
+}
+
+// GetRulesCommand returns the cobra command for inspecting the breaking rules config.
+func GetRulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "rules",
+		Short:        "Inspect and validate the breaking rules config",
+		Long:         "Inspect and validate the breaking rules config (changes-rules.yaml), including the files it extends.",
+		Example:      "openapi-changes rules validate ./changes-rules.yaml",
+	}
+	cmd.AddCommand(getRulesPrintCommand())
+	cmd.AddCommand(getRulesValidateCommand())
+	return cmd
+}
@@ -0,0 +1,136 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/openapi-changes/internal/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRulesTree writes config files into a temporary directory and returns its path.
+func writeRulesTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	}
+	return dir
+}
+
+var extendsTree = map[string]string{
+	"org/base.yaml": `pathItem:
+  get:
+    removed: false
+  post:
+    removed: false
+severities:
+  schema:
+    modified: warning
+`,
+	"org/strict.yaml": `extends: base.yaml
+parameter:
+  required:
+    modified: true
+`,
+	"team/changes-rules.yaml": `extends:
+  - ../org/strict.yaml
+pathItem:
+  post:
+    removed: true
+severities:
+  schema:
+    removed: error
+`,
+}
+
+func TestLoadRulesConfig_Extends(t *testing.T) {
+	dir := writeRulesTree(t, extendsTree)
+	config, err := LoadRulesConfig(filepath.Join(dir, "team", "changes-rules.yaml"))
+	require.NoError(t, err)
+
+	assert.False(t, *config.Breaking.PathItem.Get.Removed, "inherited from the organization base")
+	assert.True(t, *config.Breaking.PathItem.Post.Removed, "overridden by the team")
+	assert.True(t, *config.Breaking.Parameter.Required.Modified, "inherited from the strict ruleset")
+	assert.Equal(t, &severity.Rule{Modified: severity.Warning, Removed: severity.Error}, config.Severities["schema"].Default)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "org", "base.yaml"),
+		filepath.Join(dir, "org", "strict.yaml"),
+	}, config.Extends)
+	assert.Equal(t, filepath.Join(dir, "team", "changes-rules.yaml"), config.Path)
+}
+
+func TestLoadRulesConfig_ExtendsErrors(t *testing.T) {
+	dir := writeRulesTree(t, map[string]string{
+		"a.yaml":       "extends: b.yaml\n",
+		"b.yaml":       "extends: [a.yaml]\n",
+		"missing.yaml": "extends: nowhere.yaml\n",
+		"bad.yaml":     "extends: {path: a.yaml}\n",
+		"invalid.yaml": "extends: nested.yaml\n",
+		"nested.yaml":  "# base\nschema:\n  discriminator:\n    propertyName:\n      modified: false\n",
+	})
+
+	_, err := LoadRulesConfig(filepath.Join(dir, "a.yaml"))
+	var parseErr *ConfigParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.ErrorContains(t, err, "extends cycle: ")
+	assert.ErrorContains(t, err, filepath.Join(dir, "a.yaml")+" -> "+filepath.Join(dir, "b.yaml")+" -> "+filepath.Join(dir, "a.yaml"))
+
+	_, err = LoadRulesConfig(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "extended config file not found: "+filepath.Join(dir, "nowhere.yaml"))
+
+	_, err = LoadRulesConfig(filepath.Join(dir, "bad.yaml"))
+	assert.ErrorContains(t, err, "extends: line 1: expected a path or a list of paths")
+
+	_, err = LoadRulesConfig(filepath.Join(dir, "invalid.yaml"))
+	var validationErr *ConfigValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, filepath.Join(dir, "nested.yaml"), validationErr.FilePath)
+	assert.Equal(t, 4, validationErr.Result.Errors[0].Line)
+}
+
+func TestRulesPrint(t *testing.T) {
+	dir := writeRulesTree(t, extendsTree)
+	configPath := filepath.Join(dir, "team", "changes-rules.yaml")
+
+	output := captureStdout(t, func() {
+		require.NoError(t, testRootCmd(GetRulesCommand(), "print", configPath).Execute())
+	})
+	assert.Contains(t, output, "# "+filepath.Join(dir, "org", "base.yaml")+"\n")
+	assert.Contains(t, output, "pathItem:\n  get:\n    removed: false\n  post:\n    removed: true\n")
+	assert.Contains(t, output, "severities:\n  schema:\n")
+	assert.NotContains(t, output, "openapi:")
+	assert.NotContains(t, output, "extends")
+
+	output = captureStdout(t, func() {
+		require.NoError(t, testRootCmd(GetRulesCommand(), "print", "--effective", configPath).Execute())
+	})
+	assert.Contains(t, output, "# default rules\n")
+	assert.Contains(t, output, "openapi:\n  added: true\n")
+	assert.Contains(t, output, "  post:\n    added: false\n    modified: false\n    removed: true\n")
+}
+
+func TestRulesValidate(t *testing.T) {
+	dir := writeRulesTree(t, extendsTree)
+	output := captureStdout(t, func() {
+		require.NoError(t, testRootCmd(GetRulesCommand(), "validate", "--no-color",
+			filepath.Join(dir, "team", "changes-rules.yaml")).Execute())
+	})
+	assert.Contains(t, output, "is valid")
+	assert.Contains(t, output, "extends: "+filepath.Join(dir, "org", "base.yaml")+", "+filepath.Join(dir, "org", "strict.yaml"))
+
+	invalid := writeRulesConfig(t, "schema:\n  discriminator:\n    propertyName:\n      modified: false\n")
+	output = captureStdout(t, func() {
+		err := testRootCmd(GetRulesCommand(), "validate", "--no-color", invalid).Execute()
+		var validationErr *ConfigValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+	assert.Contains(t, output, "Line 3")
+}
@@ -0,0 +1,254 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	htmlReport "github.com/pb33f/openapi-changes/html-report"
+	"github.com/pb33f/openapi-changes/internal/jsonschema"
+	"github.com/pb33f/openapi-changes/internal/severity"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+)
+
+// schemaBaseURL is where the generated schemas are published, under schemas/ in the repository.
+const schemaBaseURL = "https://raw.githubusercontent.com/pb33f/openapi-changes/main/schemas/"
+
+// schemaDefinition is a JSON Schema that the schema command can print.
+type schemaDefinition struct {
+	Name        string
+	File        string
+	Description string
+	build       func(id string) *jsonschema.Schema
+}
+
+// schemaDefinitions lists the published schemas in the order the schema command lists them.
+var schemaDefinitions = []schemaDefinition{
+	{
+		Name:        "rules",
+		File:        "changes-rules.schema.json",
+		Description: "The breaking rules config (changes-rules.yaml), including extends and severities",
+		build:       rulesConfigSchema,
+	},
+	{
+		Name:        "report",
+		File:        "report.schema.json",
+		Description: "The JSON written by `report` for a left/right comparison",
+		build: func(id string) *jsonschema.Schema {
+			return versionedReportSchema(reportSchemaGenerator().Generate(&model.FlatReport{}, id, "openapi-changes report",
+				"The JSON report of the changes between two OpenAPI specifications."))
+		},
+	},
+	{
+		Name:        "historical-report",
+		File:        "historical-report.schema.json",
+		Description: "The JSON written by `report` for a git history, with one report per commit",
+		build: func(id string) *jsonschema.Schema {
+			return versionedReportSchema(reportSchemaGenerator().Generate(&model.FlatHistoricalReport{}, id,
+				"openapi-changes historical report",
+				"The JSON report of the changes to an OpenAPI specification across its git history."))
+		},
+	},
+	{
+		Name:        "html-report",
+		File:        "html-report.schema.json",
+		Description: "The payload embedded in the HTML report for its frontend",
+		build: func(id string) *jsonschema.Schema {
+			return reportSchemaGenerator().Generate(&htmlReport.ReportPayload{}, id, "openapi-changes HTML report payload",
+				"The JSON payload embedded in the HTML report and read by its frontend.")
+		},
+	},
+}
+
+func lookupSchema(name string) (*schemaDefinition, error) {
+	names := make([]string, 0, len(schemaDefinitions))
+	for i := range schemaDefinitions {
+		if schemaDefinitions[i].Name == name {
+			return &schemaDefinitions[i], nil
+		}
+		names = append(names, schemaDefinitions[i].Name)
+	}
+	return nil, fmt.Errorf("unknown schema '%s' (available: %s)", name, strings.Join(names, ", "))
+}
+
+// Generate builds the schema, identified by its published URL.
+func (d *schemaDefinition) Generate() *jsonschema.Schema {
+	return d.build(schemaBaseURL + d.File)
+}
+
+// renderSchema renders a schema as indented JSON with a trailing newline, as it is published.
+func renderSchema(schema *jsonschema.Schema) ([]byte, error) {
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render schema: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// reportSchemaGenerator returns a generator that knows the report types that marshal themselves.
+func reportSchemaGenerator() *jsonschema.Generator {
+	generator := jsonschema.NewGenerator()
+	generator.Override(&model.HashedChange{}, hashedChangeSchema())
+	generator.Override(&whatChangedModel.DocumentChanges{}, &jsonschema.Schema{
+		Type:        jsonschema.Types{"object"},
+		Description: "The full tree of document changes, as produced by libopenapi.",
+	})
+	return generator
+}
+
+// versionedReportSchema requires the current report version at the top level of a
+// report. Reports nested in a historical report do not carry a version of their own.
+func versionedReportSchema(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties["reportVersion"] = &jsonschema.Schema{
+		Type:        jsonschema.Types{"integer"},
+		Description: "The version of the report layout. Older layouts are written with --report-version.",
+		Enum:        []any{model.CurrentReportVersion},
+	}
+	schema.Required = append([]string{"reportVersion"}, schema.Required...)
+	if nested, ok := schema.Defs["FlatReport"]; ok {
+		delete(nested.Properties, "reportVersion")
+	}
+	return schema
+}
+
+// hashedChangeSchema mirrors the frozen field set HashedChange.MarshalJSON writes:
+// the change as libopenapi describes it, plus its hash, raw path and severity.
+func hashedChangeSchema() *jsonschema.Schema {
+	str := func(description string) *jsonschema.Schema {
+		return &jsonschema.Schema{Type: jsonschema.Types{"string"}, Description: description}
+	}
+	line := &jsonschema.Schema{Type: jsonschema.Types{"integer"}}
+	return &jsonschema.Schema{
+		Type:        jsonschema.Types{"object"},
+		Description: "A single change between the original and modified specification.",
+		Properties: map[string]*jsonschema.Schema{
+			"change": {Type: jsonschema.Types{"integer"}, Description: "The change type: 1 modified, 2 property added, " +
+				"3 object added, 4 object removed, 5 property removed.",
+				Enum: []any{whatChangedModel.Modified, whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded,
+					whatChangedModel.ObjectRemoved, whatChangedModel.PropertyRemoved}},
+			"changeText": {Type: jsonschema.Types{"string"}, Description: "The change type as text.",
+				Enum: []any{"modified", "property_added", "object_added", "object_removed", "property_removed"}},
+			"property":        str("The property that changed."),
+			"breaking":        {Type: jsonschema.Types{"boolean"}, Description: "Whether the change breaks clients."},
+			"original":        str("The original value."),
+			"new":             str("The new value."),
+			"originalEncoded": str("The original value serialized as YAML, for objects and arrays."),
+			"newEncoded":      str("The new value serialized as YAML, for objects and arrays."),
+			"type":            str("The type of object that changed."),
+			"path":            str("The JSON path of the object that changed."),
+			"changeHash":      str("A stable hash identifying the change."),
+			"rawPath":         str("The path before parameter names were substituted for indexes."),
+			"severity": {Type: jsonschema.Types{"string"}, Description: "The severity assigned to the change.",
+				Enum: stringsToAny(severity.Levels)},
+			"context": {
+				Type:        jsonschema.Types{"object"},
+				Description: "Where the change is in the original and modified documents.",
+				Properties: map[string]*jsonschema.Schema{
+					"document":       str("The document the change was found in."),
+					"originalLine":   line,
+					"originalColumn": line,
+					"newLine":        line,
+					"newColumn":      line,
+				},
+				AdditionalProperties: jsonschema.False(),
+			},
+		},
+		Required:             []string{"change", "changeText", "property", "breaking", "changeHash"},
+		AdditionalProperties: jsonschema.False(),
+	}
+}
+
+// rulesConfigSchema describes changes-rules.yaml: the breaking rules, plus the
+// extends and severities sections that the loader splits out before validating them.
+func rulesConfigSchema(id string) *jsonschema.Schema {
+	schema := jsonschema.NewGenerator().Generate(&whatChangedModel.BreakingRulesConfig{}, id,
+		"openapi-changes breaking rules config",
+		"Overrides for the rules that decide which changes are breaking (changes-rules.yaml).")
+
+	components := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		components = append(components, name)
+	}
+	sort.Strings(components)
+
+	schema.Properties["extends"] = &jsonschema.Schema{
+		Description: "Rules configs to extend, relative to this file. Later files and this file override earlier ones.",
+		AnyOf: []*jsonschema.Schema{
+			{Type: jsonschema.Types{"string"}},
+			{Type: jsonschema.Types{"array"}, Items: &jsonschema.Schema{Type: jsonschema.Types{"string"}}},
+		},
+	}
+
+	level := &jsonschema.Schema{Ref: "#/$defs/SeverityLevel"}
+	schema.Defs["SeverityLevel"] = &jsonschema.Schema{Type: jsonschema.Types{"string"}, Enum: stringsToAny(severity.Levels)}
+	schema.Defs["SeverityRule"] = &jsonschema.Schema{
+		Type:                 jsonschema.Types{"object"},
+		Properties:           map[string]*jsonschema.Schema{"added": level, "modified": level, "removed": level},
+		AdditionalProperties: jsonschema.False(),
+	}
+	schema.Defs["ComponentSeverities"] = &jsonschema.Schema{
+		Type: jsonschema.Types{"object"},
+		Description: "added, modified and removed set the severities of the whole component; " +
+			"any other key sets them for a single property.",
+		Properties:           map[string]*jsonschema.Schema{"added": level, "modified": level, "removed": level},
+		AdditionalProperties: &jsonschema.Schema{Ref: "#/$defs/SeverityRule"},
+	}
+	componentSeverities := &jsonschema.Schema{Ref: "#/$defs/ComponentSeverities"}
+	severities := &jsonschema.Schema{
+		Type:                 jsonschema.Types{"object"},
+		Description:          "Severities of changes by component and property.",
+		Properties:           make(map[string]*jsonschema.Schema, len(components)),
+		AdditionalProperties: componentSeverities,
+	}
+	for _, component := range components {
+		severities.Properties[component] = componentSeverities
+	}
+	schema.Properties["severities"] = severities
+	return schema
+}
+
+func stringsToAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, value := range values {
+		out[i] = value
+	}
+	return out
+}
+
+// GetSchemaCommand returns the cobra command that prints the JSON Schemas of the
+// rules config and the report formats.
+func GetSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		SilenceUsage: true,
+		Use:          "schema [name]",
+		Short:        "Print the JSON Schema of the rules config or a report format",
+		Long: "Print the JSON Schema (draft 2020-12) of the breaking rules config or a report format. " +
+			"Without a name, the available schemas are listed.",
+		Example: "openapi-changes schema report > report.schema.json",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				for _, definition := range schemaDefinitions {
+					fmt.Printf("%-18s %s\n", definition.Name, definition.Description)
+				}
+				return nil
+			}
+			definition, err := lookupSchema(args[0])
+			if err != nil {
+				return err
+			}
+			out, err := renderSchema(definition.Generate())
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(out))
+			return nil
+		},
+	}
+}
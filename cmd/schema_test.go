@@ -0,0 +1,164 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	htmlReport "github.com/pb33f/openapi-changes/html-report"
+	"github.com/pb33f/openapi-changes/internal/jsonschema"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func generatedSchema(t *testing.T, name string) *jsonschema.Schema {
+	t.Helper()
+	definition, err := lookupSchema(name)
+	require.NoError(t, err)
+	return definition.Generate()
+}
+
+func assertValidJSON(t *testing.T, schema *jsonschema.Schema, value any) {
+	t.Helper()
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	errs, err := jsonschema.ValidateJSON(schema, data)
+	require.NoError(t, err)
+	assert.Empty(t, errs, string(data))
+}
+
+// yamlErrors validates a YAML document against a schema, the way editors validate changes-rules.yaml.
+func yamlErrors(t *testing.T, schema *jsonschema.Schema, contents string) []error {
+	t.Helper()
+	var value any
+	require.NoError(t, yaml.Unmarshal([]byte(contents), &value))
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	errs, err := jsonschema.ValidateJSON(schema, data)
+	require.NoError(t, err)
+	return errs
+}
+
+func TestSchemaFiles_UpToDate(t *testing.T) {
+	for _, definition := range schemaDefinitions {
+		expected, err := renderSchema(definition.Generate())
+		require.NoError(t, err)
+		published, err := os.ReadFile(filepath.Join("..", "schemas", definition.File))
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(published),
+			"schemas/%s is out of date; run `make schemas`", definition.File)
+	}
+}
+
+func TestRulesConfigSchema(t *testing.T) {
+	schema := generatedSchema(t, "rules")
+	for name, contents := range extendsTree {
+		assert.Empty(t, yamlErrors(t, schema, contents), name)
+	}
+	assert.Empty(t, yamlErrors(t, schema, "$self:\n  added: true\nschema:\n  type:\n    modified: false\n"))
+
+	errs := yamlErrors(t, schema, `extends: {path: base.yaml}
+schema:
+  discriminator:
+    propertyName:
+      modified: false
+  type:
+    modified: "no"
+severities:
+  schema:
+    modified: fatal
+    type:
+      removed: warning
+`)
+	require.Len(t, errs, 4)
+	assert.EqualError(t, errs[0], "/extends: value does not match any of the allowed schemas")
+	assert.EqualError(t, errs[1], "/schema/discriminator/propertyName: unknown property 'propertyName'")
+	assert.EqualError(t, errs[2], "/schema/type/modified: expected boolean, got string")
+	assert.EqualError(t, errs[3], "/severities/schema/modified: value fatal is not one of [error warning info ignore]")
+}
+
+func TestReportSchemas(t *testing.T) {
+	change := &wcModel.Change{Path: "$.paths['/pets'].get.parameters[0]", Property: "required",
+		ChangeType: wcModel.Modified, Original: "false", New: "true", Breaking: true,
+		Context: &wcModel.ChangeContext{OriginalLine: intPtr(7), NewLine: intPtr(8)}}
+	added := &wcModel.Change{Path: "$.paths['/pets']", Property: "post", ChangeType: wcModel.ObjectAdded,
+		Context: &wcModel.ChangeContext{NewLine: intPtr(12)}}
+	commit := suppressionTestCommit(change, added)
+	commit.CommitDate = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	suppressions := suppressBreakingChanges(commit, commit.Changes)
+	report := createReport(commit)
+	report.Suppressions = suppressions
+
+	flat := FlattenReportWithParameterNames(report,
+		map[string]string{"$.paths['/pets'].get.parameters[0]": "limit"})
+	flat.OriginalPath, flat.ModifiedPath = "a.yaml", "b.yaml"
+	flat.Changes[0].Severity = "warning"
+	flat.Severities = map[string]int{"warning": 1, "info": 1}
+	flat.PolicyFindings = []*model.PolicyFinding{{Rule: "no-required", Severity: "error", Message: "no",
+		ChangeHash: flat.Changes[0].ChangeHash}}
+	require.Len(t, flat.Suppressions, 1)
+	applyReportVersion(flat, model.CurrentReportVersion)
+	assertValidJSON(t, generatedSchema(t, "report"), flat)
+
+	historical := &model.FlatHistoricalReport{
+		GitRepoPath: ".", GitFilePath: "openapi.yaml", Filename: "openapi.yaml",
+		MetaData: &model.HistoricalReportMetaData{Partial: true, SkippedCommits: []string{"abc"}},
+		Reports:  []*model.FlatReport{flat, {}},
+	}
+	applyReportVersion(historical, model.CurrentReportVersion)
+	assertValidJSON(t, generatedSchema(t, "historical-report"), historical)
+
+	errs, err := jsonschema.ValidateJSON(generatedSchema(t, "report"),
+		[]byte(`{"reportVersion": 2, "reportSummary": {}, "changes": [{"change": 9, "changeText": "modified", "property": "x", "breaking": true}]}`))
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "/changes/0: missing required property 'changeHash'")
+	assert.EqualError(t, errs[1], "/changes/0/change: value 9 is not one of [1 2 3 4 5]")
+}
+
+func TestHTMLReportSchema(t *testing.T) {
+	items := []*htmlReport.ReportItem{{
+		ChangeId:            "abc1234",
+		Graph:               &htmlReport.GraphData{Mode: "standard", Nodes: json.RawMessage(`[{"id":"root"}]`), Edges: json.RawMessage(`[]`)},
+		Summary:             &htmlReport.SummaryData{ChangeId: "abc1234", TotalChanges: 2, Additions: 1},
+		OriginalSpec:        "openapi: 3.1.0",
+		ModifiedSpec:        "openapi: 3.1.1",
+		ModifiedHighlighted: map[int]string{1: "<span>openapi</span>"},
+		Commit:              &htmlReport.CommitInfo{Hash: "abc1234", Date: "2026-01-02"},
+		Severities:          map[string]int{"info": 2},
+	}}
+	assertValidJSON(t, generatedSchema(t, "html-report"), &htmlReport.ReportPayload{
+		Version:       1,
+		DateGenerated: "2026-01-02T03:04:05Z",
+		Items:         items,
+		History:       htmlReport.BuildHistoryData(items),
+	})
+}
+
+func TestSchemaCommand(t *testing.T) {
+	output := captureStdout(t, func() {
+		require.NoError(t, testRootCmd(GetSchemaCommand()).Execute())
+	})
+	for _, definition := range schemaDefinitions {
+		assert.Contains(t, output, definition.Name)
+	}
+
+	output = captureStdout(t, func() {
+		require.NoError(t, testRootCmd(GetSchemaCommand(), "report").Execute())
+	})
+	var schema jsonschema.Schema
+	require.NoError(t, json.Unmarshal([]byte(output), &schema))
+	assert.Equal(t, schemaBaseURL+"report.schema.json", schema.ID)
+	assert.Contains(t, schema.Defs, "HashedChange")
+
+	err := testRootCmd(GetSchemaCommand(), "rulez").Execute()
+	assert.EqualError(t, err, "unknown schema 'rulez' (available: rules, report, historical-report, html-report)")
+}
@@ -0,0 +1,288 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/pb33f/doctor/terminal"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/changecounts"
+	"github.com/pb33f/openapi-changes/internal/policy"
+	"github.com/pb33f/openapi-changes/internal/severity"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+)
+
+var errFailOnThreshold = errors.New("changes at or above the --fail-on severity discovered")
+
+// reportRules holds the user rules applied to each comparison on top of the
+// breaking rules: the configured change severities and the optional policy.
+type reportRules struct {
+	severities severity.Config
+	policy     *policy.Policy
+}
+
+// readReportRules loads the breaking rules config along with the rules that
+// are applied to its comparisons. Config errors are printed before they are returned.
+func readReportRules(cmd *cobra.Command, configFlag string, palette terminal.Palette) (*whatChangedModel.BreakingRulesConfig, reportRules, error) {
+	var rules reportRules
+	config, err := LoadRulesConfig(configFlag)
+	if err != nil {
+		PrintConfigError(err, palette)
+		return nil, rules, err
+	}
+	var breakingConfig *whatChangedModel.BreakingRulesConfig
+	if config != nil {
+		breakingConfig = config.Breaking
+		rules.severities = config.Severities
+	}
+	rules.policy, err = readPolicyFlag(cmd)
+	return breakingConfig, rules, err
+}
+
+// addFailOnFlag adds --fail-on, which has the same default for every command:
+// the run fails on any change or policy finding of error severity.
+func addFailOnFlag(cmd *cobra.Command) {
+	cmd.Flags().String("fail-on", severity.Error,
+		"Exit non-zero when a change or policy finding has this severity or higher (error, warning, info or none)")
+}
+
+// addErrorOnDiffFlag adds the deprecated --error-on-diff flag summary has always taken.
+func addErrorOnDiffFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP("error-on-diff", "", false, "Treat any differences as errors")
+	_ = cmd.Flags().MarkDeprecated("error-on-diff", "use --fail-on info instead")
+}
+
+// readFailOnFlag returns the --fail-on threshold; the deprecated --error-on-diff
+// flag is the same as --fail-on info.
+func readFailOnFlag(cmd *cobra.Command) (string, error) {
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	threshold, err := severity.ParseThreshold(failOn)
+	if err != nil {
+		return "", err
+	}
+	if errorOnDiff, _ := cmd.Flags().GetBool("error-on-diff"); errorOnDiff && !cmd.Flags().Changed("fail-on") {
+		return severity.Info, nil
+	}
+	return threshold, nil
+}
+
+// changeOutcome collects the change severities and policy findings of a run,
+// which together decide whether it fails.
+type changeOutcome struct {
+	severities map[string]int
+	findings   []*model.PolicyFinding
+}
+
+func (o *changeOutcome) add(severities map[string]int, findings []*model.PolicyFinding) {
+	if o.severities == nil {
+		o.severities = make(map[string]int)
+	}
+	for level, count := range severities {
+		o.severities[level] += count
+	}
+	o.findings = append(o.findings, findings...)
+}
+
+// failOn returns an error when any change or policy finding is at or above the threshold.
+func (o *changeOutcome) failOn(threshold string) error {
+	if o == nil {
+		return nil
+	}
+	var errs []error
+	changes := 0
+	for level, count := range o.severities {
+		if severity.AtLeast(level, threshold) {
+			changes += count
+		}
+	}
+	if changes > 0 {
+		errs = append(errs, fmt.Errorf("%w: %d at '%s' or higher", errFailOnThreshold, changes, threshold))
+	}
+	findings := 0
+	for _, finding := range o.findings {
+		if severity.AtLeast(finding.Severity, threshold) {
+			findings++
+		}
+	}
+	if findings > 0 {
+		errs = append(errs, fmt.Errorf("%w: %d at '%s' or higher", errPolicyViolations, findings, threshold))
+	}
+	return errors.Join(errs...)
+}
+
+// changeSeverities gives the severity of a change. It is either the configured
+// severity.Config, or a policySeverities that layers policy allow rules over it.
+type changeSeverities interface {
+	Of(change *whatChangedModel.Change) string
+	Counts(changes []*whatChangedModel.Change) map[string]int
+}
+
+// policySeverities gives each change the severity a policy allow rule set for
+// it, and every other change its configured severity.
+type policySeverities struct {
+	config  severity.Config
+	allowed map[string]string
+}
+
+// allowedSeverityKey identifies a change by its unnormalized path, so the
+// changes of a flattened report can be matched back to the changerator's.
+func allowedSeverityKey(path string, change *whatChangedModel.Change) string {
+	return fmt.Sprintf("%s\x00%s\x00%d\x00%s\x00%s", path, change.Property, change.ChangeType, change.Original, change.New)
+}
+
+func (s policySeverities) Of(change *whatChangedModel.Change) string {
+	if change != nil {
+		if level, ok := s.allowed[allowedSeverityKey(change.Path, change)]; ok {
+			return level
+		}
+	}
+	return s.config.Of(change)
+}
+
+func (s policySeverities) Counts(changes []*whatChangedModel.Change) map[string]int {
+	counts := make(map[string]int)
+	for _, change := range changes {
+		if change != nil {
+			counts[s.Of(change)]++
+		}
+	}
+	return counts
+}
+
+// applyFlatReportSeverities assigns a severity to every change in a flattened report.
+func applyFlatReportSeverities(severities severity.Config, flat *model.FlatReport) {
+	if flat == nil {
+		return
+	}
+	flat.Severities = make(map[string]int)
+	for _, change := range flat.Changes {
+		if change == nil || change.Change == nil {
+			continue
+		}
+		change.Severity = severities.Of(change.Change)
+		flat.Severities[change.Severity]++
+	}
+}
+
+// applyFlatReportRules assigns severities and evaluates the policy for a
+// flattened report. Severities are counted after the policy has run, so they
+// include the ones set by allow rules.
+func applyFlatReportRules(rules reportRules, commit *model.Commit, flat *model.FlatReport) error {
+	applyFlatReportSeverities(rules.severities, flat)
+	if err := evaluateFlatReportPolicy(rules.policy, commit, flat); err != nil {
+		return err
+	}
+	if flat != nil && rules.policy != nil {
+		flat.Severities = make(map[string]int)
+		for _, change := range flat.Changes {
+			if change != nil && change.Change != nil {
+				flat.Severities[change.Severity]++
+			}
+		}
+	}
+	return nil
+}
+
+func severityIcon(level string) string {
+	switch level {
+	case severity.Error:
+		return "❌"
+	case severity.Warning:
+		return "⚠️"
+	case severity.Ignore:
+		return "🔇"
+	}
+	return "ℹ️"
+}
+
+// severityRow is a change with a severity of warning or higher, listed in reports.
+type severityRow struct {
+	level  string
+	change *whatChangedModel.Change
+}
+
+// notableSeverities returns the changes with a severity of warning or higher, errors first.
+func notableSeverities(severities changeSeverities, changes []*whatChangedModel.Change) []severityRow {
+	var errorRows, warningRows []severityRow
+	for _, change := range changes {
+		if change == nil {
+			continue
+		}
+		switch level := severities.Of(change); level {
+		case severity.Error:
+			errorRows = append(errorRows, severityRow{level, change})
+		case severity.Warning:
+			warningRows = append(warningRows, severityRow{level, change})
+		}
+	}
+	return append(errorRows, warningRows...)
+}
+
+func severityCountsText(counts map[string]int) string {
+	parts := make([]string, 0, len(severity.Levels))
+	for _, level := range severity.Levels {
+		if counts[level] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[level], level))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderSeveritiesMarkdown renders the change severities of a commit as a markdown section.
+func renderSeveritiesMarkdown(severities changeSeverities, changes []*whatChangedModel.Change) string {
+	if severities == nil {
+		return ""
+	}
+	counts := severities.Counts(changes)
+	if len(counts) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("### Change Severities\n\n")
+	sb.WriteString(fmt.Sprintf("%s\n\n", severityCountsText(counts)))
+	rows := notableSeverities(severities, changes)
+	if len(rows) == 0 {
+		return sb.String()
+	}
+	sb.WriteString("| Severity | Change | Location |\n")
+	sb.WriteString("|----------|--------|----------|\n")
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf("| %s %s | %s `%s` | `%s` |\n", severityIcon(row.level), row.level,
+			changecounts.CategoryOf(row.change).Label(), escapeMarkdownTableCell(row.change.Property),
+			escapeMarkdownTableCell(row.change.Path)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// renderSeveritiesHTML renders the change severities of a commit as an HTML
+// section that is placed ahead of the change report.
+func renderSeveritiesHTML(severities changeSeverities, changes []*whatChangedModel.Change) string {
+	if severities == nil {
+		return ""
+	}
+	counts := severities.Counts(changes)
+	if len(counts) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(`<div class="change-severities"><h2>Change Severities</h2>`)
+	sb.WriteString(fmt.Sprintf(`<p>%s</p>`, html.EscapeString(severityCountsText(counts))))
+	if rows := notableSeverities(severities, changes); len(rows) > 0 {
+		sb.WriteString(`<table><thead><tr><th>Severity</th><th>Change</th><th>Location</th></tr></thead><tbody>`)
+		for _, row := range rows {
+			sb.WriteString(fmt.Sprintf(`<tr class="severity-%s"><td>%s %s</td><td>%s <code>%s</code></td><td><code>%s</code></td></tr>`,
+				row.level, severityIcon(row.level), row.level, changecounts.CategoryOf(row.change).Label(),
+				html.EscapeString(row.change.Property), html.EscapeString(row.change.Path)))
+		}
+		sb.WriteString(`</tbody></table>`)
+	}
+	sb.WriteString(`</div>`)
+	return sb.String()
+}
@@ -0,0 +1,208 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/severity"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const severityTestRules = `pathItem:
+  get:
+    removed: false
+severities:
+  schema:
+    modified: warning
+    description:
+      modified: ignore
+  operation:
+    parameters:
+      added: error
+schema:
+  enum:
+    removed: false
+`
+
+func writeRulesConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+func TestLoadRulesConfig_SeveritiesSection(t *testing.T) {
+	config, err := LoadRulesConfig(writeRulesConfig(t, severityTestRules))
+	require.NoError(t, err)
+	require.NotNil(t, config)
+
+	require.NotNil(t, config.Breaking.PathItem)
+	assert.False(t, *config.Breaking.PathItem.Get.Removed)
+	require.NotNil(t, config.Breaking.Schema)
+	assert.False(t, *config.Breaking.Schema.Enum.Removed)
+
+	require.NotNil(t, config.Severities["schema"])
+	assert.Equal(t, severity.Warning, config.Severities["schema"].Default.Modified)
+	assert.Equal(t, severity.Ignore, config.Severities["schema"].Properties["description"].Modified)
+	assert.Equal(t, severity.Error, config.Severities["operation"].Properties["parameters"].Added)
+
+	breaking, err := LoadBreakingRulesConfig(writeRulesConfig(t, severityTestRules))
+	require.NoError(t, err)
+	assert.NotNil(t, breaking.PathItem)
+}
+
+func TestLoadRulesConfig_ValidationKeepsLineNumbers(t *testing.T) {
+	_, err := LoadRulesConfig(writeRulesConfig(t, `severities:
+  schema:
+    modified: warning
+schema:
+  discriminator:
+    propertyName:
+      modified: false
+`))
+	var validationErr *ConfigValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.NotEmpty(t, validationErr.Result.Errors)
+	assert.Equal(t, 6, validationErr.Result.Errors[0].Line)
+}
+
+func TestLoadRulesConfig_InvalidSeverity(t *testing.T) {
+	_, err := LoadRulesConfig(writeRulesConfig(t, "severities:\n  schema:\n    type:\n      modified: fatal\n"))
+	var parseErr *ConfigParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.ErrorContains(t, err, "severities.schema.type.modified: unknown severity 'fatal'")
+}
+
+func TestChangeOutcome_FailOn(t *testing.T) {
+	outcome := &changeOutcome{}
+	outcome.add(map[string]int{severity.Warning: 2, severity.Info: 3, severity.Ignore: 4}, nil)
+	outcome.add(nil, []*model.PolicyFinding{{Rule: "large-commit", Severity: severity.Warning}})
+
+	assert.NoError(t, outcome.failOn(severity.Error))
+	assert.NoError(t, outcome.failOn(severity.None))
+
+	err := outcome.failOn(severity.Warning)
+	assert.ErrorIs(t, err, errFailOnThreshold)
+	assert.ErrorIs(t, err, errPolicyViolations)
+	assert.ErrorContains(t, err, "2 at 'warning' or higher")
+
+	err = outcome.failOn(severity.Info)
+	assert.ErrorContains(t, err, "5 at 'info' or higher")
+
+	ignored := &changeOutcome{}
+	ignored.add(map[string]int{severity.Ignore: 1}, nil)
+	assert.NoError(t, ignored.failOn(severity.Info))
+
+	var none *changeOutcome
+	assert.NoError(t, none.failOn(severity.Info))
+}
+
+func TestReadFailOnFlag(t *testing.T) {
+	cmd := GetSummaryCommand()
+	threshold, err := readFailOnFlag(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, severity.Error, threshold)
+
+	require.NoError(t, cmd.Flags().Set("error-on-diff", "true"))
+	threshold, err = readFailOnFlag(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, severity.Info, threshold)
+
+	require.NoError(t, cmd.Flags().Set("fail-on", "warning"))
+	threshold, err = readFailOnFlag(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, severity.Warning, threshold)
+
+	require.NoError(t, cmd.Flags().Set("fail-on", "ignore"))
+	_, err = readFailOnFlag(cmd)
+	assert.ErrorContains(t, err, "unknown severity threshold 'ignore'")
+}
+
+func TestFailOnFlag_SameDefaultForEveryCommand(t *testing.T) {
+	for _, cmd := range []*cobra.Command{
+		GetSummaryCommand(), GetReportCommand(), GetMarkdownReportCommand(), GetHTMLReportCommand(), GetMergeCheckCommand(),
+	} {
+		threshold, err := readFailOnFlag(cmd)
+		require.NoError(t, err, cmd.Name())
+		assert.Equal(t, severity.Error, threshold, cmd.Name())
+	}
+	assert.Nil(t, GetReportCommand().Flags().Lookup("error-on-diff"), "--error-on-diff is only kept on summary")
+}
+
+func TestApplyFlatReportSeverities(t *testing.T) {
+	config, err := LoadRulesConfig(writeRulesConfig(t, severityTestRules))
+	require.NoError(t, err)
+
+	flat := &model.FlatReport{Changes: []*model.HashedChange{
+		mergeTestChange("$.components.schemas['Pet']", "description", wcModel.Modified, "a", "b", false),
+		mergeTestChange("$.components.schemas['Pet']", "type", wcModel.Modified, "string", "integer", true),
+		mergeTestChange("$.paths['/pets']", "get", wcModel.PropertyRemoved, "get", "", true),
+		mergeTestChange("$.info", "title", wcModel.Modified, "a", "b", false),
+	}}
+	applyFlatReportSeverities(config.Severities, flat)
+
+	assert.Equal(t, severity.Ignore, flat.Changes[0].Severity)
+	assert.Equal(t, severity.Warning, flat.Changes[1].Severity)
+	assert.Equal(t, severity.Error, flat.Changes[2].Severity)
+	assert.Equal(t, severity.Info, flat.Changes[3].Severity)
+	assert.Equal(t, map[string]int{severity.Ignore: 1, severity.Warning: 1, severity.Error: 1, severity.Info: 1}, flat.Severities)
+
+	encoded, err := json.Marshal(flat.Changes[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"severity":"warning"`)
+}
+
+func TestRenderSeverities(t *testing.T) {
+	config, err := LoadRulesConfig(writeRulesConfig(t, severityTestRules))
+	require.NoError(t, err)
+	changes := []*wcModel.Change{
+		{Path: "$.components.schemas['Pet']", Property: "description", ChangeType: wcModel.Modified},
+		{Path: "$.components.schemas['Pet']", Property: "type", ChangeType: wcModel.Modified, Breaking: true},
+		{Path: "$.paths['/pets']", Property: "get", ChangeType: wcModel.PropertyRemoved, Breaking: true},
+		{Path: "$.paths['/<pets>']", Property: "summary", ChangeType: wcModel.Modified},
+	}
+
+	markdown := renderSeveritiesMarkdown(config.Severities, changes)
+	assert.Contains(t, markdown, "### Change Severities")
+	assert.Contains(t, markdown, "1 error, 1 warning, 1 info, 1 ignore")
+	assert.Contains(t, markdown, "| ❌ error | removed `get` | `$.paths['/pets']` |")
+	assert.Contains(t, markdown, "| ⚠️ warning | modified `type` |")
+	assert.NotContains(t, markdown, "description")
+
+	htmlSection := renderSeveritiesHTML(config.Severities, changes)
+	assert.Contains(t, htmlSection, `<tr class="severity-error">`)
+	assert.NotContains(t, htmlSection, "<pets>")
+
+	assert.Empty(t, renderSeveritiesMarkdown(nil, nil))
+	assert.Empty(t, renderSeveritiesHTML(nil, nil))
+}
+
+func TestRenderElementSummaryTable_Severity(t *testing.T) {
+	config, err := LoadRulesConfig(writeRulesConfig(t, severityTestRules))
+	require.NoError(t, err)
+	summaries := buildElementSummaries([]*wcModel.Change{
+		{Path: "$.components.schemas['Pet']", Property: "type", ChangeType: wcModel.Modified, Breaking: true},
+		{Path: "$.components.schemas['Pet']", Property: "description", ChangeType: wcModel.Modified},
+		{Path: "$.paths['/pets']", Property: "get", ChangeType: wcModel.PropertyRemoved, Breaking: true},
+	}, config.Severities)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, elementSummary{name: "components", total: 2, breaking: 1, severity: severity.Warning}, summaries[0])
+	assert.Equal(t, elementSummary{name: "paths", total: 1, breaking: 1, severity: severity.Error}, summaries[1])
+
+	markdown := renderElementSummaryTable(summaries, true, summaryStyles{})
+	assert.Contains(t, markdown, "| Document Element | Total Changes | Breaking Changes | Severity |")
+	assert.Contains(t, markdown, "| components | 2 | 1 | ⚠️ warning |")
+
+	text := renderElementSummaryTable(summaries, false, summaryStyles{})
+	assert.Contains(t, text, "│ Document Element │ Total Changes │ Breaking Changes │ Severity │")
+	assert.Contains(t, text, "│ paths            │             1 │                1 │ error    │")
+}
@@ -0,0 +1,120 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+)
+
+func addSquashFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("squash", false, "Collapse git history into a single comparison between the oldest and newest revisions")
+}
+
+// errSquashLeftRight is returned when --squash is used for a comparison of two
+// files or revisions, which has no history to collapse.
+var errSquashLeftRight = errors.New("--squash collapses git history and cannot be used when comparing two files or revisions")
+
+// squashCommits collapses a newest-first commit history into a single synthetic
+// commit that compares the oldest comparable baseline against the newest document.
+// Changes that were made and then reverted inside the window cancel out.
+//
+// The returned slice is the input unchanged when there is nothing to squash.
+func squashCommits(commits []*model.Commit) []*model.Commit {
+	newestIdx := -1
+	oldestIdx := -1
+	for i, commit := range commits {
+		if commit == nil || commit.Document == nil || commit.OldDocument == nil {
+			continue
+		}
+		if newestIdx < 0 {
+			newestIdx = i
+		}
+		oldestIdx = i
+	}
+	if newestIdx < 0 || newestIdx == oldestIdx {
+		return commits
+	}
+
+	newest := commits[newestIdx]
+	oldest := commits[oldestIdx]
+
+	squashed := make([]*model.SquashedCommit, 0, oldestIdx-newestIdx+1)
+	for _, commit := range commits[newestIdx : oldestIdx+1] {
+		if commit == nil || commit.Document == nil || commit.OldDocument == nil {
+			continue
+		}
+		squashed = append(squashed, &model.SquashedCommit{
+			Hash:        commit.Hash,
+			Message:     commit.Message,
+			Author:      commit.Author,
+			AuthorEmail: commit.AuthorEmail,
+			CommitDate:  commit.CommitDate,
+		})
+	}
+
+	return []*model.Commit{{
+		Hash:              newest.Hash,
+		Message:           fmt.Sprintf("Squashed %d commits (%s..%s)", len(squashed), oldest.Hash, newest.Hash),
+		Author:            newest.Author,
+		AuthorEmail:       newest.AuthorEmail,
+		CommitDate:        newest.CommitDate,
+		Data:              newest.Data,
+		OldData:           oldest.OldData,
+		Document:          newest.Document,
+		OldDocument:       oldest.OldDocument,
+		RepoDirectory:     newest.RepoDirectory,
+		FilePath:          newest.FilePath,
+		DocumentRewriters: newest.DocumentRewriters,
+		Squashed:          squashed,
+	}}
+}
+
+// renderSquashedCommits lists the commits that contributed to a squashed
+// comparison, as summary text or markdown.
+func renderSquashedCommits(squashed []*model.SquashedCommit, markdown bool, styles summaryStyles) string {
+	if len(squashed) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	if markdown {
+		sb.WriteString("- **Squashed commits**:\n")
+		for _, commit := range squashed {
+			sb.WriteString(fmt.Sprintf("  - %s %s (%s, %s)\n", commit.Hash, commit.Message,
+				commit.Author, commit.CommitDate.Format(time.RFC3339)))
+		}
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("  Squashed commits: %s\n", styles.title.Render(fmt.Sprint(len(squashed)))))
+	for _, commit := range squashed {
+		sb.WriteString(fmt.Sprintf("    %s %s %s\n", styles.stat.Render(shortCommitHash(commit.Hash)), commit.Message,
+			styles.detail.Render(fmt.Sprintf("(%s, %s)", commit.Author, commit.CommitDate.Format(time.RFC3339)))))
+	}
+	return sb.String()
+}
+
+// renderSquashedCommitsHTML lists the commits that contributed to a squashed
+// comparison as an HTML section that is placed ahead of the change report.
+func renderSquashedCommitsHTML(squashed []*model.SquashedCommit) string {
+	if len(squashed) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(`<div class="squashed-commits"><h2>Squashed Commits</h2><table><thead><tr>`)
+	sb.WriteString(`<th>Commit</th><th>Message</th><th>Author</th><th>Date</th></tr></thead><tbody>`)
+	for _, commit := range squashed {
+		sb.WriteString(fmt.Sprintf(`<tr><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+			html.EscapeString(commit.Hash), html.EscapeString(commit.Message), html.EscapeString(commit.Author),
+			html.EscapeString(commit.CommitDate.Format(time.RFC3339))))
+	}
+	sb.WriteString(`</tbody></table></div>`)
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func squashTestDocument(t *testing.T, version string) libopenapi.Document {
+	t.Helper()
+	doc, err := libopenapi.NewDocument([]byte("openapi: 3.0.0\ninfo:\n  title: test\n  version: " + version + "\npaths: {}\n"))
+	require.NoError(t, err)
+	return doc
+}
+
+func TestSquashCommits_NothingComparable(t *testing.T) {
+	commits := []*model.Commit{{Hash: "a"}, {Hash: "b"}}
+	assert.Equal(t, commits, squashCommits(commits))
+	assert.Empty(t, squashCommits(nil))
+}
+
+func TestSquashCommits_SingleComparableCommit(t *testing.T) {
+	v1 := squashTestDocument(t, "1.0.0")
+	v2 := squashTestDocument(t, "2.0.0")
+	commits := []*model.Commit{
+		{Hash: "b", Document: v2, OldDocument: v1},
+		{Hash: "a", Document: v1},
+	}
+
+	squashed := squashCommits(commits)
+	assert.Equal(t, commits, squashed)
+	assert.Empty(t, squashed[0].Squashed)
+}
+
+func TestSquashCommits_CollapsesHistory(t *testing.T) {
+	v1 := squashTestDocument(t, "1.0.0")
+	v2 := squashTestDocument(t, "2.0.0")
+	v3 := squashTestDocument(t, "3.0.0")
+	now := time.Now()
+
+	commits := []*model.Commit{
+		{Hash: "ccc", Message: "third", Author: "carol", CommitDate: now, Data: []byte("v3"), OldData: []byte("v2"), Document: v3, OldDocument: v2, FilePath: "openapi.yaml"},
+		{Hash: "bbb", Message: "second", Author: "bob", CommitDate: now.Add(-time.Hour), Data: []byte("v2"), OldData: []byte("v1"), Document: v2, OldDocument: v1},
+		{Hash: "aaa", Message: "first", Author: "alice", CommitDate: now.Add(-2 * time.Hour), Data: []byte("v1"), Document: v1},
+	}
+
+	squashed := squashCommits(commits)
+	require.Len(t, squashed, 1)
+
+	commit := squashed[0]
+	assert.Equal(t, "ccc", commit.Hash)
+	assert.Equal(t, "Squashed 2 commits (bbb..ccc)", commit.Message)
+	assert.Equal(t, "carol", commit.Author)
+	assert.Equal(t, now, commit.CommitDate)
+	assert.Equal(t, []byte("v3"), commit.Data)
+	assert.Equal(t, []byte("v1"), commit.OldData)
+	assert.Same(t, v3, commit.Document)
+	assert.Same(t, v1, commit.OldDocument)
+	assert.Equal(t, "openapi.yaml", commit.FilePath)
+	assert.False(t, commit.Synthetic)
+
+	require.Len(t, commit.Squashed, 2)
+	assert.Equal(t, "ccc", commit.Squashed[0].Hash)
+	assert.Equal(t, "third", commit.Squashed[0].Message)
+	assert.Equal(t, "bbb", commit.Squashed[1].Hash)
+	assert.Equal(t, "bob", commit.Squashed[1].Author)
+}
+
+func TestRenderSquashedCommits(t *testing.T) {
+	date := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	squashed := []*model.SquashedCommit{
+		{Hash: "ccc3333333", Message: "third", Author: "carol", CommitDate: date},
+		{Hash: "bbb2222222", Message: "<second>", Author: "bob", CommitDate: date},
+	}
+
+	text := renderSquashedCommits(squashed, false, summaryStyles{})
+	assert.Contains(t, text, "Squashed commits: 2")
+	assert.Contains(t, text, "ccc3333 third (carol, 2026-03-01T12:00:00Z)")
+
+	markdown := renderSquashedCommits(squashed, true, summaryStyles{})
+	assert.Contains(t, markdown, "- **Squashed commits**:\n")
+	assert.Contains(t, markdown, "  - bbb2222222 <second> (bob, 2026-03-01T12:00:00Z)\n")
+
+	htmlOutput := renderSquashedCommitsHTML(squashed)
+	assert.Contains(t, htmlOutput, "<h2>Squashed Commits</h2>")
+	assert.Contains(t, htmlOutput, "<td>&lt;second&gt;</td>")
+	assert.Equal(t, 2, strings.Count(htmlOutput, "<tr><td>"))
+
+	assert.Empty(t, renderSquashedCommits(nil, false, summaryStyles{}))
+	assert.Empty(t, renderSquashedCommitsHTML(nil))
+}
+
+func TestSquash_RejectedForLeftRightComparisons(t *testing.T) {
+	for _, sub := range []*cobra.Command{GetSummaryCommand(), GetReportCommand()} {
+		cmd := testRootCmd(sub, "--no-logo", "--squash",
+			"../sample-specs/petstorev3.json", "../sample-specs/petstorev3.json")
+		err := cmd.Execute()
+		require.Error(t, err, sub.Name())
+		assert.ErrorIs(t, err, errSquashLeftRight, sub.Name())
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/pb33f/doctor/terminal"
 	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/pb33f/openapi-changes/internal/changecounts"
+	"github.com/pb33f/openapi-changes/internal/severity"
 	"github.com/pb33f/openapi-changes/model"
 	"github.com/spf13/cobra"
 )
@@ -47,18 +48,15 @@ type elementSummary struct {
 	name     string
 	total    int
 	breaking int
+	severity string
 }
 
-func buildElementSummaries(changes []*whatChangedModel.Change) []elementSummary {
+func buildElementSummaries(changes []*whatChangedModel.Change, severities changeSeverities) []elementSummary {
 	if len(changes) == 0 {
 		return nil
 	}
 
-	type counts struct {
-		total    int
-		breaking int
-	}
-	grouped := make(map[string]*counts)
+	grouped := make(map[string]*elementSummary)
 	for _, change := range changes {
 		if change == nil {
 			continue
@@ -68,17 +66,20 @@ func buildElementSummaries(changes []*whatChangedModel.Change) []elementSummary
 			name = "document"
 		}
 		if grouped[name] == nil {
-			grouped[name] = &counts{}
+			grouped[name] = &elementSummary{name: name, severity: severity.Ignore}
 		}
 		grouped[name].total++
 		if change.Breaking {
 			grouped[name].breaking++
 		}
+		if level := severities.Of(change); severity.AtLeast(level, grouped[name].severity) {
+			grouped[name].severity = level
+		}
 	}
 
 	summaries := make([]elementSummary, 0, len(grouped))
-	for name, stats := range grouped {
-		summaries = append(summaries, elementSummary{name: name, total: stats.total, breaking: stats.breaking})
+	for _, summary := range grouped {
+		summaries = append(summaries, *summary)
 	}
 	sort.Slice(summaries, func(i, j int) bool {
 		return summaries[i].name < summaries[j].name
@@ -156,6 +157,19 @@ func renderBreakingHighlights(highlights []string, markdown bool, styles summary
 	return sb.String()
 }
 
+// severityStyle returns the summary style for a change severity.
+func severityStyle(level string, styles summaryStyles) lipgloss.Style {
+	switch level {
+	case severity.Error:
+		return styles.breaking
+	case severity.Warning:
+		return styles.modification
+	case severity.Ignore:
+		return styles.stat
+	}
+	return styles.addition
+}
+
 func renderElementSummaryTable(summaries []elementSummary, markdown bool, styles summaryStyles) string {
 	if len(summaries) == 0 {
 		return ""
@@ -163,42 +177,36 @@ func renderElementSummaryTable(summaries []elementSummary, markdown bool, styles
 
 	var sb strings.Builder
 	if markdown {
-		sb.WriteString("| Document Element | Total Changes | Breaking Changes |\n")
-		sb.WriteString("|------------------|---------------|------------------|\n")
+		sb.WriteString("| Document Element | Total Changes | Breaking Changes | Severity |\n")
+		sb.WriteString("|------------------|---------------|------------------|----------|\n")
 		for _, summary := range summaries {
-			sb.WriteString(fmt.Sprintf("| %s | %d | %d |\n", summary.name, summary.total, summary.breaking))
+			sb.WriteString(fmt.Sprintf("| %s | %d | %d | %s %s |\n", summary.name, summary.total, summary.breaking,
+				severityIcon(summary.severity), summary.severity))
 		}
 		sb.WriteString("\n")
 		return sb.String()
 	}
 
-	nameWidth := len("Document Element")
-	totalWidth := len("Total Changes")
-	breakingWidth := len("Breaking Changes")
+	headers := []string{"Document Element", "Total Changes", "Breaking Changes", "Severity"}
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = len(header)
+	}
 	for _, summary := range summaries {
-		if len(summary.name) > nameWidth {
-			nameWidth = len(summary.name)
-		}
-		if l := len(fmt.Sprint(summary.total)); l > totalWidth {
-			totalWidth = l
-		}
-		if l := len(fmt.Sprint(summary.breaking)); l > breakingWidth {
-			breakingWidth = l
+		for i, cell := range []string{summary.name, fmt.Sprint(summary.total), fmt.Sprint(summary.breaking), summary.severity} {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
 		}
 	}
 
-	top := fmt.Sprintf("┌─%s─┬─%s─┬─%s─┐\n",
-		strings.Repeat("─", nameWidth),
-		strings.Repeat("─", totalWidth),
-		strings.Repeat("─", breakingWidth))
-	divider := fmt.Sprintf("├─%s─┼─%s─┼─%s─┤\n",
-		strings.Repeat("─", nameWidth),
-		strings.Repeat("─", totalWidth),
-		strings.Repeat("─", breakingWidth))
-	bottom := fmt.Sprintf("└─%s─┴─%s─┴─%s─┘\n",
-		strings.Repeat("─", nameWidth),
-		strings.Repeat("─", totalWidth),
-		strings.Repeat("─", breakingWidth))
+	border := func(left, middle, right string) string {
+		segments := make([]string, len(widths))
+		for i, width := range widths {
+			segments[i] = strings.Repeat("─", width+2)
+		}
+		return left + strings.Join(segments, middle) + right + "\n"
+	}
 
 	renderCell := func(width int, text string, alignRight bool, style lipgloss.Style) string {
 		padding := width - len(text)
@@ -217,20 +225,21 @@ func renderElementSummaryTable(summaries []elementSummary, markdown bool, styles
 		return style.Render(cell)
 	}
 
-	sb.WriteString(top)
+	sb.WriteString(border("┌", "┬", "┐"))
 	sb.WriteString("│ ")
-	sb.WriteString(renderCell(nameWidth, "Document Element", false, styles.title))
-	sb.WriteString(" │ ")
-	sb.WriteString(renderCell(totalWidth, "Total Changes", true, styles.title))
-	sb.WriteString(" │ ")
-	sb.WriteString(renderCell(breakingWidth, "Breaking Changes", true, styles.title))
+	for i, header := range headers {
+		if i > 0 {
+			sb.WriteString(" │ ")
+		}
+		sb.WriteString(renderCell(widths[i], header, i > 0 && i < 3, styles.title))
+	}
 	sb.WriteString(" │\n")
-	sb.WriteString(divider)
+	sb.WriteString(border("├", "┼", "┤"))
 	for _, summary := range summaries {
 		sb.WriteString("│ ")
-		sb.WriteString(renderCell(nameWidth, summary.name, false, lipgloss.NewStyle()))
+		sb.WriteString(renderCell(widths[0], summary.name, false, lipgloss.NewStyle()))
 		sb.WriteString(" │ ")
-		sb.WriteString(renderCell(totalWidth, fmt.Sprint(summary.total), true, styles.title))
+		sb.WriteString(renderCell(widths[1], fmt.Sprint(summary.total), true, styles.title))
 		sb.WriteString(" │ ")
 		breakingStyle := lipgloss.NewStyle()
 		if summary.breaking > 0 {
@@ -238,10 +247,12 @@ func renderElementSummaryTable(summaries []elementSummary, markdown bool, styles
 		} else if styles.stat.GetForeground() != nil {
 			breakingStyle = styles.stat
 		}
-		sb.WriteString(renderCell(breakingWidth, fmt.Sprint(summary.breaking), true, breakingStyle))
+		sb.WriteString(renderCell(widths[2], fmt.Sprint(summary.breaking), true, breakingStyle))
+		sb.WriteString(" │ ")
+		sb.WriteString(renderCell(widths[3], summary.severity, false, severityStyle(summary.severity, styles)))
 		sb.WriteString(" │\n")
 	}
-	sb.WriteString(bottom)
+	sb.WriteString(border("└", "┴", "┘"))
 	sb.WriteString("\n")
 	return sb.String()
 }
@@ -267,20 +278,26 @@ func renderSummary(
 	if err != nil {
 		return "", false, false, err
 	}
-	return renderSummaryWithTheme(commits, breakingConfig, markdown, theme, terminal.PaletteForTheme(theme), withLines, styles)
+	output, hasBreaking, hasChanges, _, err := renderSummaryWithTheme(
+		commits, breakingConfig, reportRules{}, markdown, theme, terminal.PaletteForTheme(theme), withLines, styles)
+	return output, hasBreaking, hasChanges, err
 }
 
+// renderSummaryWithTheme is renderSummary with an explicit theme and report rules.
+// Change severities and policy findings are rendered with each commit and
+// returned as the outcome that decides the exit status.
 func renderSummaryWithTheme(
 	commits []*model.Commit,
 	breakingConfig *whatChangedModel.BreakingRulesConfig,
+	rules reportRules,
 	markdown bool,
 	theme terminal.ThemeName,
 	palette terminal.Palette,
 	withLines bool,
 	styles summaryStyles,
-) (string, bool, bool, error) {
+) (string, bool, bool, *changeOutcome, error) {
 	if len(commits) == 0 {
-		return noChangesFoundMessage + "\n", false, false, nil
+		return noChangesFoundMessage + "\n", false, false, nil, nil
 	}
 
 	var sb strings.Builder
@@ -289,6 +306,7 @@ func renderSummaryWithTheme(
 	renderedCommits := 0
 	treeRendered := false
 	var renderErrors []error
+	outcome := &changeOutcome{}
 
 	for c, commit := range commits {
 		if commit.Document == nil || commit.OldDocument == nil {
@@ -314,10 +332,16 @@ func renderSummaryWithTheme(
 		}
 		renderedCommits++
 
+		var policyErr error
 		func() {
 			defer result.Release()
 
 			deduplicatedChanges := result.Changerator.DeduplicateChanges()
+			commitFindings, commitSeverities, err := policyForResult(rules, commit, result)
+			if err != nil {
+				policyErr = err
+				return
+			}
 
 			// Build node change tree and render tree for the first renderable commit only.
 			if !treeRendered {
@@ -362,7 +386,7 @@ func renderSummaryWithTheme(
 			}
 
 			sb.WriteString(renderDedupedCountsNote(markdown, styles))
-			sb.WriteString(renderElementSummaryTable(buildElementSummaries(deduplicatedChanges), markdown, styles))
+			sb.WriteString(renderElementSummaryTable(buildElementSummaries(deduplicatedChanges, commitSeverities), markdown, styles))
 
 			counts := changecounts.FromChanges(deduplicatedChanges)
 			breaking := counts.Breaking
@@ -379,6 +403,7 @@ func renderSummaryWithTheme(
 				sb.WriteString(styles.title.Render(fmt.Sprintf("Date: %s | Commit: %s", dateStr, commit.Message)))
 				sb.WriteString("\n")
 			}
+			sb.WriteString(renderSquashedCommits(commit.Squashed, markdown, styles))
 
 			if breaking == 0 {
 				if markdown {
@@ -438,25 +463,39 @@ func renderSummaryWithTheme(
 				}
 			}
 
+			severities := commitSeverities.Counts(deduplicatedChanges)
+			if markdown {
+				sb.WriteString(fmt.Sprintf("- **Severities**: _%s_\n", severityCountsText(severities)))
+			} else {
+				sb.WriteString(fmt.Sprintf("  Severities: %s\n", severityStyle(severity.Highest(severities), styles).Render(severityCountsText(severities))))
+			}
+
+			sb.WriteString(renderPolicyFindings(commitFindings, markdown, styles))
+			sb.WriteString(renderSuppressions(result.Suppressions, markdown, styles))
+			outcome.add(severities, commitFindings)
+
 			sb.WriteString("\n")
 		}()
+		if policyErr != nil {
+			return sb.String(), false, false, nil, policyErr
+		}
 	}
 
 	hasBreaking := totalBreaking > 0
 	hasChanges := totalChanges > 0
 	if renderedCommits == 0 && len(renderErrors) > 0 {
-		return sb.String(), false, false, fmt.Errorf("all %d commits failed to render: %w", len(renderErrors), errors.Join(renderErrors...))
+		return sb.String(), false, false, nil, fmt.Errorf("all %d commits failed to render: %w", len(renderErrors), errors.Join(renderErrors...))
 	}
 	if len(renderErrors) > 0 {
 		fmt.Fprintf(os.Stderr, "warning: %d commits failed to render\n", len(renderErrors))
 	}
 	if !hasChanges && len(renderErrors) == 0 {
 		if sb.Len() == 0 {
-			return noChangesFoundMessage + "\n", false, false, nil
+			return noChangesFoundMessage + "\n", false, false, nil, nil
 		}
-		return sb.String(), false, false, nil
+		return sb.String(), false, false, nil, nil
 	}
-	return sb.String(), hasBreaking, hasChanges, nil
+	return sb.String(), hasBreaking, hasChanges, outcome, nil
 }
 
 // GetSummaryCommand returns the cobra command for the current summary command.
@@ -476,7 +515,9 @@ func GetSummaryCommand() *cobra.Command {
 				return nil
 			}
 			input.Opts.markdown, _ = cmd.Flags().GetBool("markdown")
-			input.Opts.errorOnDiff, _ = cmd.Flags().GetBool("error-on-diff")
+			if input.Opts.failOn, err = readFailOnFlag(cmd); err != nil {
+				return err
+			}
 			styles := summaryStylesForPalette(input.Opts.palette)
 
 			if len(input.Commits) == 0 && len(args) == 2 {
@@ -487,8 +528,9 @@ func GetSummaryCommand() *cobra.Command {
 				}
 			}
 
-			output, hasBreaking, hasChanges, renderErr := renderSummaryWithTheme(
-				input.Commits, input.BreakingConfig, input.Opts.markdown, input.Opts.theme, input.Opts.palette, input.Opts.withLines, styles,
+			output, _, _, outcome, renderErr := renderSummaryWithTheme(
+				input.Commits, input.BreakingConfig, input.Opts.rules, input.Opts.markdown, input.Opts.theme, input.Opts.palette,
+				input.Opts.withLines, styles,
 			)
 			if output != "" {
 				fmt.Print(output)
@@ -496,19 +538,16 @@ func GetSummaryCommand() *cobra.Command {
 			if renderErr != nil {
 				return renderErr
 			}
-			if hasBreaking {
-				return errors.New("breaking changes discovered")
-			}
-			if hasChanges && input.Opts.errorOnDiff {
-				return errors.New("differences discovered")
-			}
-			return nil
+			return outcome.failOn(input.Opts.failOn)
 		},
 	}
 	addTerminalThemeFlags(cmd)
 	cmd.Flags().BoolP("markdown", "m", false, "Render output in markdown, using emojis")
 	cmd.Flags().Bool("with-lines", false, "Include source line and column locations in semantic tree leaves")
-	cmd.Flags().BoolP("error-on-diff", "", false, "Treat any differences as errors")
+	addFailOnFlag(cmd)
+	addErrorOnDiffFlag(cmd)
+	addSquashFlag(cmd)
+	addPolicyFlag(cmd)
 	return cmd
 }
 
@@ -0,0 +1,154 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/suppression"
+	"github.com/pb33f/openapi-changes/model"
+)
+
+const noSuppressionReason = "no reason given"
+
+// suppressBreakingChanges applies the x-openapi-changes extensions of the modified
+// document to a comparison, downgrading the breaking changes they ignore. Only the
+// root document is read: a malformed directive, or a directive in a file the root
+// document references, is reported as a warning and has no effect.
+func suppressBreakingChanges(commit *model.Commit, docChanges *whatChangedModel.DocumentChanges) []*model.Suppression {
+	if docChanges == nil {
+		return nil
+	}
+	warnReferencedSuppressions(commit)
+	if !bytes.Contains(commit.Data, []byte(suppression.Extension)) {
+		return nil
+	}
+	directives, err := suppression.Scan(commit.Data)
+	if err != nil {
+		emitCommitWarning(commit, fmt.Errorf("ignoring %s extensions in %s: %w", suppression.Extension,
+			commitSourceLabel(commit, true), err))
+	}
+	return suppression.Apply(directives, docChanges.GetAllChanges())
+}
+
+// warnReferencedSuppressions warns about x-openapi-changes extensions declared in
+// files the modified document references, which are not applied.
+func warnReferencedSuppressions(commit *model.Commit) {
+	if commit.Document == nil {
+		return
+	}
+	rolodex := commit.Document.GetRolodex()
+	if rolodex == nil {
+		return
+	}
+	for _, idx := range rolodex.GetIndexes() {
+		if idx == nil || idx == rolodex.GetRootIndex() {
+			continue
+		}
+		if directives, _ := suppression.ScanNode(idx.GetRootNode()); len(directives) > 0 {
+			emitCommitWarning(commit, fmt.Errorf("%d %s extensions in referenced file '%s' are not applied; "+
+				"declare them in the root document", len(directives), suppression.Extension, idx.GetSpecAbsolutePath()))
+		}
+	}
+}
+
+// flattenSuppressions copies suppressions onto the flattened changes they refer to,
+// picking up their hashes and normalized paths.
+func flattenSuppressions(suppressions []*model.Suppression, flattened map[*whatChangedModel.Change]*model.HashedChange) []*model.Suppression {
+	if len(suppressions) == 0 {
+		return nil
+	}
+	flat := make([]*model.Suppression, 0, len(suppressions))
+	for _, s := range suppressions {
+		copied := *s
+		if change, ok := flattened[s.Change]; ok {
+			copied.ChangeHash = change.ChangeHash
+			copied.Path = change.Path
+			copied.Property = change.Property
+			copied.Change = change.Change
+		}
+		flat = append(flat, &copied)
+	}
+	return flat
+}
+
+func suppressionReason(s *model.Suppression) string {
+	if s.Reason == "" {
+		return noSuppressionReason
+	}
+	return s.Reason
+}
+
+func suppressionChangeLocation(s *model.Suppression) string {
+	return strings.TrimSpace(s.Path + " " + s.Property)
+}
+
+func suppressionDeclaration(s *model.Suppression) string {
+	if s.Line > 0 {
+		return fmt.Sprintf("%s (line %d)", s.Location, s.Line)
+	}
+	return s.Location
+}
+
+// renderSuppressions renders the suppressed breaking changes of a commit for the summary command.
+func renderSuppressions(suppressions []*model.Suppression, markdown bool, styles summaryStyles) string {
+	if len(suppressions) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	if markdown {
+		sb.WriteString(fmt.Sprintf("- **Suppressed breaking changes**: _%d_\n", len(suppressions)))
+		for _, s := range suppressions {
+			sb.WriteString(fmt.Sprintf("  - `%s`: %s (`%s` on `%s`)\n", suppressionChangeLocation(s),
+				suppressionReason(s), suppression.Extension, suppressionDeclaration(s)))
+		}
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("  Suppressed breaking changes: %s\n", styles.title.Render(fmt.Sprint(len(suppressions)))))
+	for _, s := range suppressions {
+		sb.WriteString(fmt.Sprintf("    %s %s: %s %s\n", styles.stat.Render("[suppressed]"), suppressionChangeLocation(s),
+			suppressionReason(s), styles.detail.Render(suppressionDeclaration(s))))
+	}
+	return sb.String()
+}
+
+// renderSuppressionsMarkdown renders the suppressed breaking changes of a commit as a markdown table.
+func renderSuppressionsMarkdown(suppressions []*model.Suppression) string {
+	if len(suppressions) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("### Suppressed Breaking Changes\n\n")
+	sb.WriteString("| Change | Reason | Declared At |\n")
+	sb.WriteString("|--------|--------|-------------|\n")
+	for _, s := range suppressions {
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | `%s` |\n", escapeMarkdownTableCell(suppressionChangeLocation(s)),
+			escapeMarkdownTableCell(suppressionReason(s)), escapeMarkdownTableCell(suppressionDeclaration(s))))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// renderSuppressionsHTML renders the suppressed breaking changes of a commit as an
+// HTML section that is placed ahead of the change report.
+func renderSuppressionsHTML(suppressions []*model.Suppression) string {
+	if len(suppressions) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(`<div class="suppressions"><h2>Suppressed Breaking Changes</h2><table><thead><tr>`)
+	sb.WriteString(`<th>Change</th><th>Reason</th><th>Declared At</th></tr></thead><tbody>`)
+	for _, s := range suppressions {
+		sb.WriteString(fmt.Sprintf(`<tr><td><code>%s</code></td><td>%s</td><td><code>%s</code></td></tr>`,
+			html.EscapeString(suppressionChangeLocation(s)), html.EscapeString(suppressionReason(s)),
+			html.EscapeString(suppressionDeclaration(s))))
+	}
+	sb.WriteString(`</tbody></table></div>`)
+	return sb.String()
+}
@@ -0,0 +1,173 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const suppressionTestSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      x-openapi-changes:
+        ignore: [breaking]
+        reason: "Internal | beta operation"
+      parameters:
+        - name: limit
+          in: query
+`
+
+func suppressionTestCommit(changes ...*wcModel.Change) *model.Commit {
+	return &model.Commit{
+		Hash: "abc1234",
+		Data: []byte(suppressionTestSpec),
+		Changes: &wcModel.DocumentChanges{
+			PropertyChanges: &wcModel.PropertyChanges{Changes: changes},
+		},
+	}
+}
+
+func TestSuppressBreakingChanges(t *testing.T) {
+	removed := &wcModel.Change{Path: "$.paths['/pets'].get.parameters[0]", Property: "required",
+		ChangeType: wcModel.Modified, Original: "false", New: "true", Breaking: true}
+	elsewhere := &wcModel.Change{Path: "$.paths['/owners'].get", Property: "operationId",
+		ChangeType: wcModel.Modified, Breaking: true}
+	commit := suppressionTestCommit(removed, elsewhere)
+
+	suppressions := suppressBreakingChanges(commit, commit.Changes)
+	require.Len(t, suppressions, 1)
+	assert.False(t, removed.Breaking)
+	assert.True(t, elsewhere.Breaking)
+	assert.Equal(t, "Internal | beta operation", suppressions[0].Reason)
+	assert.Equal(t, "$.paths['/pets'].get", suppressions[0].Location)
+	assert.Equal(t, 5, suppressions[0].Line)
+
+	commit.Data = []byte("openapi: 3.1.0\n")
+	assert.Nil(t, suppressBreakingChanges(commit, commit.Changes))
+}
+
+func TestSuppressBreakingChanges_MalformedDirectiveWarns(t *testing.T) {
+	kept := &wcModel.Change{Path: "$.paths['/pets'].get", Property: "operationId",
+		ChangeType: wcModel.Modified, Breaking: true}
+	other := &wcModel.Change{Path: "$.paths['/owners'].get", Property: "operationId",
+		ChangeType: wcModel.Modified, Breaking: true}
+	commit := suppressionTestCommit(kept, other)
+	commit.Data = []byte(`paths:
+  /pets:
+    get:
+      x-openapi-changes:
+        ignore: [breaking]
+  /owners:
+    get:
+      x-openapi-changes:
+        ignore: [all]
+`)
+
+	var suppressions []*model.Suppression
+	stderr := captureStderr(t, func() {
+		suppressions = suppressBreakingChanges(commit, commit.Changes)
+	})
+	assert.Contains(t, stderr, "unknown ignore value 'all'")
+	require.Len(t, suppressions, 1, "the valid directive still applies")
+	assert.False(t, kept.Breaking)
+	assert.True(t, other.Breaking)
+}
+
+func TestSuppressBreakingChanges_WarnsAboutReferencedFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte(`type: object
+x-openapi-changes:
+  ignore: [breaking]
+properties:
+  name:
+    type: string
+`), 0o644))
+	root := []byte(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "./pet.yaml"
+`)
+	doc, err := libopenapi.NewDocumentWithConfiguration(root, &datamodel.DocumentConfiguration{
+		BasePath:            dir,
+		AllowFileReferences: true,
+	})
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	require.NoError(t, err)
+
+	commit := suppressionTestCommit()
+	commit.Data = root
+	commit.Document = doc
+	stderr := captureStderr(t, func() {
+		assert.Nil(t, suppressBreakingChanges(commit, commit.Changes))
+	})
+	assert.Contains(t, stderr, "x-openapi-changes extensions in referenced file")
+	assert.Contains(t, stderr, "pet.yaml")
+}
+
+func TestFlattenReport_Suppressions(t *testing.T) {
+	change := &wcModel.Change{Path: "$.paths['/pets'].get.parameters[0]", Property: "required",
+		ChangeType: wcModel.Modified, Original: "false", New: "true", Breaking: true,
+		Context: &wcModel.ChangeContext{NewLine: intPtr(8)}}
+	commit := suppressionTestCommit(change)
+	suppressions := suppressBreakingChanges(commit, commit.Changes)
+	report := createReport(commit)
+	report.Suppressions = suppressions
+
+	flat := FlattenReportWithParameterNames(report,
+		map[string]string{"$.paths['/pets'].get.parameters[0]": "limit"})
+	require.Len(t, flat.Changes, 1)
+	require.Len(t, flat.Suppressions, 1)
+	assert.False(t, flat.Changes[0].Breaking)
+	assert.Equal(t, flat.Changes[0].ChangeHash, flat.Suppressions[0].ChangeHash)
+	assert.Equal(t, "$.paths['/pets'].get.parameters['limit']", flat.Suppressions[0].Path)
+}
+
+func TestRenderSuppressions(t *testing.T) {
+	suppressions := []*model.Suppression{
+		{Reason: "Internal | beta <operation>", Location: "$.paths['/pets'].get", Line: 5,
+			Path: "$.paths['/pets']", Property: "get"},
+		{Location: "$.components.schemas.Pet", Path: "$.components.schemas['Pet']", Property: "type"},
+	}
+
+	summary := renderSuppressions(suppressions, true, summaryStyles{})
+	assert.Contains(t, summary, "- **Suppressed breaking changes**: _2_")
+	assert.Contains(t, summary, "  - `$.paths['/pets'] get`: Internal | beta <operation> (`x-openapi-changes` on `$.paths['/pets'].get (line 5)`)")
+	assert.Contains(t, summary, "no reason given")
+
+	text := renderSuppressions(suppressions, false, summaryStyles{})
+	assert.Contains(t, text, "  Suppressed breaking changes: 2")
+	assert.Contains(t, text, "[suppressed] $.components.schemas['Pet'] type: no reason given $.components.schemas.Pet")
+
+	markdown := renderSuppressionsMarkdown(suppressions)
+	assert.Contains(t, markdown, "### Suppressed Breaking Changes")
+	assert.Contains(t, markdown, "| `$.paths['/pets'] get` | Internal \\| beta <operation> | `$.paths['/pets'].get (line 5)` |")
+
+	htmlSection := renderSuppressionsHTML(suppressions)
+	assert.Contains(t, htmlSection, "Internal | beta &lt;operation&gt;")
+
+	assert.Empty(t, renderSuppressions(nil, true, summaryStyles{}))
+	assert.Empty(t, renderSuppressionsMarkdown(nil))
+	assert.Empty(t, renderSuppressionsHTML(nil))
+}
@@ -437,3 +437,22 @@ func ReadFileAtRevision(repoDir, revision, filePath string) ([]byte, error) {
 	}
 	return data, nil
 }
+
+// ReleaseTagContaining returns the lowest (version-sorted) tag that contains the given commit,
+// or an empty string when the commit has not been tagged in any release yet.
+func ReleaseTagContaining(repoDir, hash string) (string, error) {
+	cmd := exec.Command(GIT, NOPAGER, "tag", "--contains", hash, "--sort=v:refname")
+	var ou, er bytes.Buffer
+	cmd.Stdout = &ou
+	cmd.Stderr = &er
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("list tags containing '%s': %v -- stderr: %s", hash, err, strings.TrimSpace(er.String()))
+	}
+	for _, line := range strings.Split(ou.String(), "\n") {
+		if tag := strings.TrimSpace(line); tag != "" {
+			return tag, nil
+		}
+	}
+	return "", nil
+}
@@ -349,3 +349,39 @@ func gitOutput(t *testing.T, dir string, args ...string) string {
 	require.NoError(t, err, "git %v failed: %s", args, string(out))
 	return strings.TrimSpace(string(out))
 }
+
+func TestReleaseTagContaining(t *testing.T) {
+	repoDir := t.TempDir()
+	runGit(t, repoDir, "init")
+	runGit(t, repoDir, "config", "user.name", "Test User")
+	runGit(t, repoDir, "config", "user.email", "test@example.com")
+
+	specPath := filepath.Join(repoDir, "openapi.yaml")
+	commit := func(version string) string {
+		require.NoError(t, os.WriteFile(specPath, []byte("openapi: 3.0.3\ninfo:\n  title: test\n  version: '"+version+"'\npaths: {}\n"), 0o644))
+		runGit(t, repoDir, "add", "openapi.yaml")
+		runGit(t, repoDir, "commit", "-m", version)
+		return gitOutput(t, repoDir, "rev-parse", "--short", "HEAD")
+	}
+
+	first := commit("1.0")
+	runGit(t, repoDir, "tag", "v1.10.0")
+	second := commit("1.1")
+	runGit(t, repoDir, "tag", "v1.11.0")
+	third := commit("1.2")
+
+	tag, err := ReleaseTagContaining(repoDir, first)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.10.0", tag)
+
+	tag, err = ReleaseTagContaining(repoDir, second)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.11.0", tag)
+
+	tag, err = ReleaseTagContaining(repoDir, third)
+	require.NoError(t, err)
+	assert.Empty(t, tag)
+
+	_, err = ReleaseTagContaining(repoDir, "not-a-commit")
+	assert.Error(t, err)
+}
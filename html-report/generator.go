@@ -86,16 +86,19 @@ type ReportPayload struct {
 
 // ReportItem represents a single commit/comparison in the report.
 type ReportItem struct {
-	ChangeId            string         `json:"changeId"`
-	Graph               *GraphData     `json:"graph"`
-	ExplorerGraph       *GraphData     `json:"explorerGraph,omitempty"`
-	Summary             *SummaryData   `json:"summary"`
-	HtmlReport          string         `json:"htmlReport"`
-	OriginalSpec        string         `json:"originalSpec"`
-	ModifiedSpec        string         `json:"modifiedSpec"`
-	OriginalHighlighted map[int]string `json:"originalHighlighted,omitempty"`
-	ModifiedHighlighted map[int]string `json:"modifiedHighlighted,omitempty"`
-	Commit              *CommitInfo    `json:"commit"`
+	ChangeId            string                 `json:"changeId"`
+	Graph               *GraphData             `json:"graph"`
+	ExplorerGraph       *GraphData             `json:"explorerGraph,omitempty"`
+	Summary             *SummaryData           `json:"summary"`
+	HtmlReport          string                 `json:"htmlReport"`
+	OriginalSpec        string                 `json:"originalSpec"`
+	ModifiedSpec        string                 `json:"modifiedSpec"`
+	OriginalHighlighted map[int]string         `json:"originalHighlighted,omitempty"`
+	ModifiedHighlighted map[int]string         `json:"modifiedHighlighted,omitempty"`
+	Commit              *CommitInfo            `json:"commit"`
+	Severities          map[string]int         `json:"severities,omitempty"`
+	PolicyFindings      []*model.PolicyFinding `json:"policyFindings,omitempty"`
+	Suppressions        []*model.Suppression   `json:"suppressions,omitempty"`
 }
 
 // highlightSpecLines uses Chroma to syntax-highlight each line of a spec,
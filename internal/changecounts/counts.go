@@ -16,6 +16,47 @@ type Counts struct {
 	BreakingRemovals      int
 }
 
+// Category is the broad kind of change a change type is counted as.
+type Category int
+
+const (
+	CategoryOther Category = iota
+	CategoryAddition
+	CategoryModification
+	CategoryRemoval
+)
+
+// Label names the category as a past-tense verb, such as "added", for the
+// text reports. Changes of no known category are "changed".
+func (c Category) Label() string {
+	switch c {
+	case CategoryAddition:
+		return "added"
+	case CategoryModification:
+		return "modified"
+	case CategoryRemoval:
+		return "removed"
+	}
+	return "changed"
+}
+
+// CategoryOf returns the category a change is counted under.
+func CategoryOf(ch *whatChangedModel.Change) Category {
+	if ch == nil {
+		return CategoryOther
+	}
+	switch ch.ChangeType {
+	case whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded:
+		return CategoryAddition
+	case whatChangedModel.Modified:
+		return CategoryModification
+	case whatChangedModel.PropertyRemoved, whatChangedModel.ObjectRemoved:
+		return CategoryRemoval
+	default:
+		return CategoryOther
+	}
+}
+
 func FromChanges(changes []*whatChangedModel.Change) Counts {
 	var counts Counts
 
@@ -28,27 +69,23 @@ func FromChanges(changes []*whatChangedModel.Change) Counts {
 			counts.Breaking++
 		}
 
-		switch ch.ChangeType {
-		case whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded:
-			counts.Total++
+		counts.Total++
+		switch CategoryOf(ch) {
+		case CategoryAddition:
 			counts.Additions++
 			if ch.Breaking {
 				counts.BreakingAdditions++
 			}
-		case whatChangedModel.Modified:
-			counts.Total++
+		case CategoryModification:
 			counts.Modifications++
 			if ch.Breaking {
 				counts.BreakingModifications++
 			}
-		case whatChangedModel.PropertyRemoved, whatChangedModel.ObjectRemoved:
-			counts.Total++
+		case CategoryRemoval:
 			counts.Removals++
 			if ch.Breaking {
 				counts.BreakingRemovals++
 			}
-		default:
-			counts.Total++
 		}
 	}
 
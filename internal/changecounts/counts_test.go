@@ -76,3 +76,20 @@ func TestFromChanges_UnknownTypeCountsTowardTotalOnly(t *testing.T) {
 	assert.Equal(t, 0, counts.BreakingModifications)
 	assert.Equal(t, 0, counts.BreakingRemovals)
 }
+
+func TestCategoryOf(t *testing.T) {
+	assert.Equal(t, CategoryAddition, CategoryOf(&whatChangedModel.Change{ChangeType: whatChangedModel.ObjectAdded}))
+	assert.Equal(t, CategoryAddition, CategoryOf(&whatChangedModel.Change{ChangeType: whatChangedModel.PropertyAdded}))
+	assert.Equal(t, CategoryModification, CategoryOf(&whatChangedModel.Change{ChangeType: whatChangedModel.Modified}))
+	assert.Equal(t, CategoryRemoval, CategoryOf(&whatChangedModel.Change{ChangeType: whatChangedModel.PropertyRemoved}))
+	assert.Equal(t, CategoryRemoval, CategoryOf(&whatChangedModel.Change{ChangeType: whatChangedModel.ObjectRemoved}))
+	assert.Equal(t, CategoryOther, CategoryOf(&whatChangedModel.Change{ChangeType: 999}))
+	assert.Equal(t, CategoryOther, CategoryOf(nil))
+}
+
+func TestCategoryLabel(t *testing.T) {
+	assert.Equal(t, "added", CategoryAddition.Label())
+	assert.Equal(t, "modified", CategoryModification.Label())
+	assert.Equal(t, "removed", CategoryRemoval.Label())
+	assert.Equal(t, "changed", CategoryOther.Label())
+}
@@ -0,0 +1,440 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deprecation follows deprecated operations, parameters and schemas
+// through a specification's history and checks removals against a policy.
+package deprecation
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/openapi-changes/model"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	KindOperation = "operation"
+	KindParameter = "parameter"
+	KindSchema    = "schema"
+	KindProperty  = "property"
+
+	sunsetExtension   = "x-sunset"
+	sunsetHeader      = "Sunset"
+	deprecationHeader = "Deprecation"
+)
+
+// Subject is something in a specification that can be deprecated and removed.
+type Subject struct {
+	Kind       string
+	Path       string
+	Label      string
+	Deprecated bool
+	Sunset     string
+}
+
+// Snapshot holds every deprecatable subject of a single revision, keyed by JSONPath.
+// A zero Date marks a baseline revision whose commit details are unknown.
+type Snapshot struct {
+	Hash     string
+	Date     time.Time
+	Version  string
+	Subjects map[string]*Subject
+}
+
+// Policy is the minimum time a subject must spend deprecated before it can be
+// removed. Removing a subject that was never deprecated always violates the policy.
+type Policy struct {
+	MinDays     int
+	MinReleases int
+}
+
+// NewSnapshot collects the operations, parameters, component schemas and
+// component schema properties of a document.
+func NewSnapshot(doc *v3.Document, hash string, date time.Time) *Snapshot {
+	snapshot := &Snapshot{Hash: hash, Date: date, Subjects: make(map[string]*Subject)}
+	if doc == nil {
+		return snapshot
+	}
+	if doc.Info != nil {
+		snapshot.Version = doc.Info.Version
+	}
+
+	if doc.Paths != nil {
+		for pathKey, pathItem := range doc.Paths.PathItems.FromOldest() {
+			if pathItem == nil {
+				continue
+			}
+			pathItemPath := "$.paths['" + escapeKey(pathKey) + "']"
+			addParameters(snapshot, pathItemPath, pathKey, pathItem.Parameters)
+			for method, operation := range pathItem.GetOperations().FromOldest() {
+				addOperation(snapshot, pathItemPath, pathKey, method, operation)
+			}
+		}
+	}
+
+	if doc.Components != nil {
+		for name, proxy := range doc.Components.Schemas.FromOldest() {
+			addSchema(snapshot, name, proxy)
+		}
+	}
+	return snapshot
+}
+
+func addOperation(snapshot *Snapshot, pathItemPath, pathKey, method string, operation *v3.Operation) {
+	if operation == nil {
+		return
+	}
+	operationPath := pathItemPath + "." + method
+	label := strings.ToUpper(method) + " " + pathKey
+	subject := &Subject{
+		Kind:       KindOperation,
+		Path:       operationPath,
+		Label:      label,
+		Deprecated: operation.Deprecated != nil && *operation.Deprecated,
+		Sunset:     extensionValue(operation.Extensions, sunsetExtension),
+	}
+	if operation.Responses != nil {
+		responses := make([]*v3.Response, 0, orderedmap.Len(operation.Responses.Codes)+1)
+		for _, response := range operation.Responses.Codes.FromOldest() {
+			responses = append(responses, response)
+		}
+		responses = append(responses, operation.Responses.Default)
+		for _, response := range responses {
+			if response == nil {
+				continue
+			}
+			for name, header := range response.Headers.FromOldest() {
+				switch http.CanonicalHeaderKey(name) {
+				case deprecationHeader:
+					subject.Deprecated = true
+				case sunsetHeader:
+					if subject.Sunset == "" {
+						subject.Sunset = headerExampleValue(header)
+					}
+				}
+			}
+		}
+	}
+	snapshot.Subjects[operationPath] = subject
+	addParameters(snapshot, operationPath, label, operation.Parameters)
+}
+
+func addParameters(snapshot *Snapshot, ownerPath, ownerLabel string, parameters []*v3.Parameter) {
+	for _, parameter := range parameters {
+		if parameter == nil || parameter.Name == "" {
+			continue
+		}
+		parameterPath := ownerPath + ".parameters['" + escapeKey(parameter.Name) + "']"
+		snapshot.Subjects[parameterPath] = &Subject{
+			Kind:       KindParameter,
+			Path:       parameterPath,
+			Label:      fmt.Sprintf("parameter '%s' (%s) of %s", parameter.Name, parameter.In, ownerLabel),
+			Deprecated: parameter.Deprecated,
+			Sunset:     extensionValue(parameter.Extensions, sunsetExtension),
+		}
+	}
+}
+
+func addSchema(snapshot *Snapshot, name string, proxy *base.SchemaProxy) {
+	if proxy == nil {
+		return
+	}
+	schemaPath := "$.components.schemas['" + escapeKey(name) + "']"
+	schema := proxy.Schema()
+	subject := &Subject{Kind: KindSchema, Path: schemaPath, Label: fmt.Sprintf("schema '%s'", name)}
+	snapshot.Subjects[schemaPath] = subject
+	if schema == nil {
+		return
+	}
+	subject.Deprecated = schema.Deprecated != nil && *schema.Deprecated
+	subject.Sunset = extensionValue(schema.Extensions, sunsetExtension)
+
+	for property, propertyProxy := range schema.Properties.FromOldest() {
+		propertyPath := schemaPath + ".properties['" + escapeKey(property) + "']"
+		propertySubject := &Subject{
+			Kind:  KindProperty,
+			Path:  propertyPath,
+			Label: fmt.Sprintf("property '%s' of schema '%s'", property, name),
+		}
+		if propertySchema := propertyProxy.Schema(); propertySchema != nil {
+			propertySubject.Deprecated = propertySchema.Deprecated != nil && *propertySchema.Deprecated
+			propertySubject.Sunset = extensionValue(propertySchema.Extensions, sunsetExtension)
+		}
+		snapshot.Subjects[propertyPath] = propertySubject
+	}
+}
+
+// headerExampleValue returns the first example value given for a header: its
+// example, one of its examples, or the example, examples, const or default of
+// its schema.
+func headerExampleValue(header *v3.Header) string {
+	if header == nil {
+		return ""
+	}
+	if value := nodeValue(header.Example); value != "" {
+		return value
+	}
+	for _, example := range header.Examples.FromOldest() {
+		if example == nil {
+			continue
+		}
+		if value := nodeValue(example.Value); value != "" {
+			return value
+		}
+		if value := nodeValue(example.DataValue); value != "" {
+			return value
+		}
+		if value := strings.TrimSpace(example.SerializedValue); value != "" {
+			return value
+		}
+	}
+	if header.Schema == nil {
+		return ""
+	}
+	schema := header.Schema.Schema()
+	if schema == nil {
+		return ""
+	}
+	if value := nodeValue(schema.Example); value != "" {
+		return value
+	}
+	for _, example := range schema.Examples {
+		if value := nodeValue(example); value != "" {
+			return value
+		}
+	}
+	if value := nodeValue(schema.Const); value != "" {
+		return value
+	}
+	return nodeValue(schema.Default)
+}
+
+func escapeKey(key string) string {
+	return strings.ReplaceAll(key, "'", "\\'")
+}
+
+func extensionValue(extensions *orderedmap.Map[string, *yaml.Node], name string) string {
+	if extensions == nil {
+		return ""
+	}
+	return nodeValue(extensions.GetOrZero(name))
+}
+
+func nodeValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(node.Value)
+}
+
+// sunsetLayouts are the accepted formats for sunset dates: the HTTP-date used
+// by the Sunset header, RFC 3339 and plain dates.
+var sunsetLayouts = []string{http.TimeFormat, time.RFC1123, time.RFC1123Z, time.RFC3339, time.DateOnly}
+
+func parseSunset(value string) (time.Time, bool) {
+	for _, layout := range sunsetLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// openLifecycle tracks a subject while it is deprecated. firstSeen is the date of
+// the first dated revision it was seen deprecated in.
+type openLifecycle struct {
+	lifecycle *model.DeprecationLifecycle
+	versions  map[string]struct{}
+	firstSeen time.Time
+}
+
+func snapshotVersion(snapshot *Snapshot) string {
+	if snapshot.Version != "" {
+		return snapshot.Version
+	}
+	return snapshot.Hash
+}
+
+// Track walks snapshots from oldest to newest and returns the lifecycle of
+// every subject that was deprecated or removed, with any policy violations.
+// Subjects removed along with a removed parent (for example the parameters of
+// a removed operation) are reported once, through the parent.
+func Track(snapshots []*Snapshot, policy Policy) []*model.DeprecationLifecycle {
+	var lifecycles []*model.DeprecationLifecycle
+	open := make(map[string]*openLifecycle)
+
+	for i, snapshot := range snapshots {
+		if snapshot == nil {
+			continue
+		}
+		if i > 0 && snapshots[i-1] != nil {
+			for _, removed := range removedSubjects(snapshots[i-1], snapshot) {
+				lifecycle := closeLifecycle(open[removed.Path], removed, snapshot, policy)
+				for path := range open {
+					if path == removed.Path || isChildPath(removed.Path, path) {
+						delete(open, path)
+					}
+				}
+				lifecycles = append(lifecycles, lifecycle)
+			}
+		}
+
+		for path, subject := range snapshot.Subjects {
+			current := open[path]
+			if !subject.Deprecated {
+				// A subject that is no longer deprecated starts again from scratch.
+				delete(open, path)
+				continue
+			}
+			if current == nil {
+				current = &openLifecycle{
+					lifecycle: &model.DeprecationLifecycle{
+						Kind:  subject.Kind,
+						Path:  subject.Path,
+						Label: subject.Label,
+					},
+					versions: make(map[string]struct{}),
+				}
+				if !snapshot.Date.IsZero() && i > 0 {
+					date := snapshot.Date
+					current.lifecycle.DeprecatedDate = &date
+					current.lifecycle.DeprecatedCommit = snapshot.Hash
+					current.lifecycle.DeprecatedVersion = snapshot.Version
+				} else {
+					current.lifecycle.DeprecatedBeforeHistory = true
+				}
+				open[path] = current
+			}
+			if current.firstSeen.IsZero() {
+				current.firstSeen = snapshot.Date
+			}
+			if subject.Sunset != "" {
+				current.lifecycle.Sunset = subject.Sunset
+			}
+			current.versions[snapshotVersion(snapshot)] = struct{}{}
+			current.lifecycle.ReleasesDeprecated = len(current.versions)
+		}
+	}
+
+	for _, current := range open {
+		lifecycles = append(lifecycles, current.lifecycle)
+	}
+	sort.SliceStable(lifecycles, func(i, j int) bool {
+		return lifecycles[i].Path < lifecycles[j].Path
+	})
+	return lifecycles
+}
+
+// removedSubjects returns the subjects in previous that are missing from
+// current, skipping any whose parent was removed as well.
+func removedSubjects(previous, current *Snapshot) []*Subject {
+	var removed []*Subject
+	for path, subject := range previous.Subjects {
+		if _, ok := current.Subjects[path]; !ok {
+			removed = append(removed, subject)
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].Path < removed[j].Path
+	})
+
+	var roots []*Subject
+	for _, subject := range removed {
+		if !hasRemovedParent(removed, subject) {
+			roots = append(roots, subject)
+		}
+	}
+	return roots
+}
+
+func hasRemovedParent(removed []*Subject, subject *Subject) bool {
+	for _, candidate := range removed {
+		if isChildPath(candidate.Path, subject.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+func isChildPath(parent, child string) bool {
+	return strings.HasPrefix(child, parent+".") || strings.HasPrefix(child, parent+"[")
+}
+
+func closeLifecycle(current *openLifecycle, removed *Subject, snapshot *Snapshot, policy Policy) *model.DeprecationLifecycle {
+	removedDate := snapshot.Date
+	if current == nil {
+		return &model.DeprecationLifecycle{
+			Kind:          removed.Kind,
+			Path:          removed.Path,
+			Label:         removed.Label,
+			RemovedCommit: snapshot.Hash,
+			RemovedDate:   &removedDate,
+			Violation:     "removed without being deprecated first",
+		}
+	}
+
+	lifecycle := current.lifecycle
+	lifecycle.RemovedCommit = snapshot.Hash
+	lifecycle.RemovedDate = &removedDate
+
+	var violations []string
+	switch {
+	case lifecycle.DeprecatedDate != nil:
+		lifecycle.DaysDeprecated = int(removedDate.Sub(*lifecycle.DeprecatedDate).Hours() / 24)
+		if lifecycle.DaysDeprecated < policy.MinDays {
+			violations = append(violations, fmt.Sprintf("deprecated for %d days, policy requires %d",
+				lifecycle.DaysDeprecated, policy.MinDays))
+		}
+		if lifecycle.ReleasesDeprecated < policy.MinReleases {
+			violations = append(violations, fmt.Sprintf("deprecated for %d releases, policy requires %d",
+				lifecycle.ReleasesDeprecated, policy.MinReleases))
+		}
+	default:
+		// The deprecation happened before the history window, so the days and
+		// releases inside the window are only a lower bound. The policy holds when
+		// that bound meets it, and cannot be verified otherwise.
+		daysKnown := !current.firstSeen.IsZero()
+		if daysKnown {
+			lifecycle.DaysDeprecated = int(removedDate.Sub(current.firstSeen).Hours() / 24)
+		}
+		daysMet := policy.MinDays <= 0 || (daysKnown && lifecycle.DaysDeprecated >= policy.MinDays)
+		if !daysMet || lifecycle.ReleasesDeprecated < policy.MinReleases {
+			seen := fmt.Sprintf("%d releases", lifecycle.ReleasesDeprecated)
+			if daysKnown {
+				seen = fmt.Sprintf("%d days and %s", lifecycle.DaysDeprecated, seen)
+			}
+			var required []string
+			if policy.MinDays > 0 {
+				required = append(required, fmt.Sprintf("%d days", policy.MinDays))
+			}
+			if policy.MinReleases > 0 {
+				required = append(required, fmt.Sprintf("%d releases", policy.MinReleases))
+			}
+			violations = append(violations, fmt.Sprintf("deprecated before history window, only %s seen, policy requires %s",
+				seen, strings.Join(required, " and ")))
+		}
+	}
+	if sunset, ok := parseSunset(lifecycle.Sunset); ok && removedDate.Before(sunset) {
+		violations = append(violations, fmt.Sprintf("removed before its sunset date (%s)", lifecycle.Sunset))
+	}
+	lifecycle.Violation = strings.Join(violations, "; ")
+	return lifecycle
+}
+
+// Violations returns the lifecycles that broke the policy.
+func Violations(lifecycles []*model.DeprecationLifecycle) []*model.DeprecationLifecycle {
+	var violations []*model.DeprecationLifecycle
+	for _, lifecycle := range lifecycles {
+		if lifecycle.Violation != "" {
+			violations = append(violations, lifecycle)
+		}
+	}
+	return violations
+}
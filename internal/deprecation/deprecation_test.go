@@ -0,0 +1,331 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package deprecation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func snapshotFromSpec(t *testing.T, spec, hash string, date time.Time) *Snapshot {
+	t.Helper()
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	v3Model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+	return NewSnapshot(&v3Model.Model, hash, date)
+}
+
+const deprecationSpecV1 = `openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      parameters:
+        - name: expand
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+    delete:
+      responses:
+        "204":
+          description: gone
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+        legacyCode:
+          type: string
+`
+
+const deprecationSpecV2 = `openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.1.0
+paths:
+  /orders/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      parameters:
+        - name: expand
+          in: query
+          deprecated: true
+          x-sunset: "2026-06-01"
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+    delete:
+      deprecated: true
+      responses:
+        "204":
+          description: gone
+          headers:
+            Sunset:
+              example: "Wed, 01 Jul 2026 00:00:00 GMT"
+              schema:
+                type: string
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+        legacyCode:
+          type: string
+          deprecated: true
+`
+
+const deprecationSpecV3 = `openapi: 3.1.0
+info:
+  title: Orders
+  version: 2.0.0
+paths:
+  /orders/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+func TestNewSnapshot(t *testing.T) {
+	snapshot := snapshotFromSpec(t, deprecationSpecV2, "abc", time.Now())
+
+	assert.Equal(t, "1.1.0", snapshot.Version)
+
+	deleteOperation := snapshot.Subjects["$.paths['/orders/{id}'].delete"]
+	require.NotNil(t, deleteOperation)
+	assert.Equal(t, KindOperation, deleteOperation.Kind)
+	assert.Equal(t, "DELETE /orders/{id}", deleteOperation.Label)
+	assert.True(t, deleteOperation.Deprecated)
+	assert.Equal(t, "Wed, 01 Jul 2026 00:00:00 GMT", deleteOperation.Sunset)
+
+	expand := snapshot.Subjects["$.paths['/orders/{id}'].get.parameters['expand']"]
+	require.NotNil(t, expand)
+	assert.Equal(t, "parameter 'expand' (query) of GET /orders/{id}", expand.Label)
+	assert.True(t, expand.Deprecated)
+	assert.Equal(t, "2026-06-01", expand.Sunset)
+
+	pathParameter := snapshot.Subjects["$.paths['/orders/{id}'].parameters['id']"]
+	require.NotNil(t, pathParameter)
+	assert.False(t, pathParameter.Deprecated)
+
+	legacy := snapshot.Subjects["$.components.schemas['Order'].properties['legacyCode']"]
+	require.NotNil(t, legacy)
+	assert.Equal(t, KindProperty, legacy.Kind)
+	assert.True(t, legacy.Deprecated)
+
+	assert.False(t, snapshot.Subjects["$.paths['/orders/{id}'].get"].Deprecated)
+}
+
+func TestNewSnapshot_DeprecationHeaderMarksOperation(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      responses:
+        "200":
+          description: ok
+          headers:
+            deprecation:
+              schema:
+                type: string
+`
+	snapshot := snapshotFromSpec(t, spec, "abc", time.Now())
+	assert.True(t, snapshot.Subjects["$.paths['/orders'].get"].Deprecated)
+}
+
+func TestNewSnapshot_SunsetFromSchemaAndExamples(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      deprecated: true
+      responses:
+        "200":
+          description: ok
+          headers:
+            Sunset:
+              schema:
+                type: string
+                example: "Wed, 01 Jul 2026 00:00:00 GMT"
+    delete:
+      deprecated: true
+      responses:
+        "204":
+          description: gone
+          headers:
+            Sunset:
+              examples:
+                planned:
+                  value: "Tue, 01 Sep 2026 00:00:00 GMT"
+              schema:
+                type: string
+`
+	snapshot := snapshotFromSpec(t, spec, "abc", time.Now())
+	assert.Equal(t, "Wed, 01 Jul 2026 00:00:00 GMT", snapshot.Subjects["$.paths['/orders'].get"].Sunset)
+	assert.Equal(t, "Tue, 01 Sep 2026 00:00:00 GMT", snapshot.Subjects["$.paths['/orders'].delete"].Sunset)
+}
+
+func lifecycleFor(lifecycles []*model.DeprecationLifecycle, path string) *model.DeprecationLifecycle {
+	for _, lifecycle := range lifecycles {
+		if lifecycle.Path == path {
+			return lifecycle
+		}
+	}
+	return nil
+}
+
+func TestTrack_PolicyViolations(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []*Snapshot{
+		snapshotFromSpec(t, deprecationSpecV1, "aaa", start),
+		snapshotFromSpec(t, deprecationSpecV2, "bbb", start.Add(10*24*time.Hour)),
+		snapshotFromSpec(t, deprecationSpecV3, "ccc", start.Add(40*24*time.Hour)),
+	}
+
+	lifecycles := Track(snapshots, Policy{MinDays: 20, MinReleases: 2})
+	require.Len(t, lifecycles, 3)
+
+	deleteOperation := lifecycleFor(lifecycles, "$.paths['/orders/{id}'].delete")
+	require.NotNil(t, deleteOperation)
+	assert.Equal(t, "bbb", deleteOperation.DeprecatedCommit)
+	assert.Equal(t, "1.1.0", deleteOperation.DeprecatedVersion)
+	assert.Equal(t, "ccc", deleteOperation.RemovedCommit)
+	assert.Equal(t, 30, deleteOperation.DaysDeprecated)
+	assert.Equal(t, 1, deleteOperation.ReleasesDeprecated)
+	assert.Equal(t, "deprecated for 1 releases, policy requires 2; removed before its sunset date (Wed, 01 Jul 2026 00:00:00 GMT)",
+		deleteOperation.Violation)
+
+	expand := lifecycleFor(lifecycles, "$.paths['/orders/{id}'].get.parameters['expand']")
+	require.NotNil(t, expand)
+	assert.Equal(t, "deprecated for 1 releases, policy requires 2; removed before its sunset date (2026-06-01)", expand.Violation)
+
+	legacy := lifecycleFor(lifecycles, "$.components.schemas['Order'].properties['legacyCode']")
+	require.NotNil(t, legacy)
+	assert.Equal(t, "deprecated for 1 releases, policy requires 2", legacy.Violation)
+
+	assert.Len(t, Violations(lifecycles), 3)
+
+	relaxed := Track(snapshots, Policy{MinDays: 20})
+	assert.Empty(t, lifecycleFor(relaxed, "$.components.schemas['Order'].properties['legacyCode']").Violation)
+	assert.Len(t, Violations(relaxed), 2, "sunset dates still apply")
+}
+
+func TestTrack_RemovedWithoutDeprecation(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []*Snapshot{
+		snapshotFromSpec(t, deprecationSpecV1, "aaa", start),
+		snapshotFromSpec(t, deprecationSpecV3, "ccc", start.Add(24*time.Hour)),
+	}
+
+	lifecycles := Track(snapshots, Policy{})
+	require.Len(t, lifecycles, 3)
+	for _, lifecycle := range lifecycles {
+		assert.Equal(t, "removed without being deprecated first", lifecycle.Violation, lifecycle.Path)
+		assert.Equal(t, "ccc", lifecycle.RemovedCommit)
+		assert.Nil(t, lifecycle.DeprecatedDate)
+	}
+	// parameters of the removed DELETE operation are not reported separately.
+	assert.NotNil(t, lifecycleFor(lifecycles, "$.paths['/orders/{id}'].delete"))
+	assert.NotNil(t, lifecycleFor(lifecycles, "$.paths['/orders/{id}'].get.parameters['expand']"))
+	assert.NotNil(t, lifecycleFor(lifecycles, "$.components.schemas['Order'].properties['legacyCode']"))
+}
+
+func TestTrack_OpenAndBaselineDeprecations(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []*Snapshot{
+		snapshotFromSpec(t, deprecationSpecV2, "", time.Time{}),
+		snapshotFromSpec(t, deprecationSpecV2, "bbb", start),
+	}
+
+	lifecycles := Track(snapshots, Policy{MinDays: 90})
+	require.Len(t, lifecycles, 3)
+	for _, lifecycle := range lifecycles {
+		assert.Nil(t, lifecycle.DeprecatedDate, "deprecated before the inspected history")
+		assert.True(t, lifecycle.DeprecatedBeforeHistory)
+		assert.Empty(t, lifecycle.RemovedCommit)
+		assert.Empty(t, lifecycle.Violation)
+	}
+}
+
+func TestTrack_RemovedAfterBaselineDeprecation(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []*Snapshot{
+		snapshotFromSpec(t, deprecationSpecV2, "", time.Time{}),
+		snapshotFromSpec(t, deprecationSpecV3, "ccc", start),
+	}
+
+	lifecycles := Track(snapshots, Policy{MinDays: 30})
+	legacy := lifecycleFor(lifecycles, "$.components.schemas['Order'].properties['legacyCode']")
+	require.NotNil(t, legacy)
+	assert.True(t, legacy.DeprecatedBeforeHistory)
+	assert.Equal(t, "ccc", legacy.RemovedCommit)
+	assert.Equal(t, "deprecated before history window, only 1 releases seen, policy requires 30 days",
+		legacy.Violation)
+
+	unchecked := Track(snapshots, Policy{})
+	assert.Empty(t, lifecycleFor(unchecked, "$.components.schemas['Order'].properties['legacyCode']").Violation,
+		"nothing to verify without a policy")
+}
+
+func TestTrack_BaselineDeprecationSeenLongEnough(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []*Snapshot{
+		snapshotFromSpec(t, deprecationSpecV2, "", time.Time{}),
+		snapshotFromSpec(t, deprecationSpecV2, "bbb", start),
+		snapshotFromSpec(t, deprecationSpecV3, "ccc", start.Add(45*24*time.Hour)),
+	}
+
+	lifecycles := Track(snapshots, Policy{MinDays: 30})
+	legacy := lifecycleFor(lifecycles, "$.components.schemas['Order'].properties['legacyCode']")
+	require.NotNil(t, legacy)
+	assert.Equal(t, 45, legacy.DaysDeprecated)
+	assert.Empty(t, legacy.Violation, "the window alone covers the policy")
+}
@@ -0,0 +1,326 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonschema generates JSON Schemas (draft 2020-12) from Go types by
+// following their json struct tags, so that the schemas published for configs
+// and reports cannot drift from the structs that produce them. Types that
+// marshal themselves are described with overrides.
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of every generated schema.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema. Only the keywords used by the generator are modelled.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+
+	// forbidden marks a schema that matches nothing (false), used to close objects.
+	forbidden bool
+}
+
+// False is the schema that matches nothing; as additionalProperties it closes an object.
+func False() *Schema {
+	return &Schema{forbidden: true}
+}
+
+// MarshalJSON writes False as the boolean schema false.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.forbidden {
+		return []byte("false"), nil
+	}
+	type plain Schema
+	return json.Marshal((*plain)(s))
+}
+
+// UnmarshalJSON reads the boolean schema false back as False.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "false":
+		*s = Schema{forbidden: true}
+		return nil
+	case "true":
+		*s = Schema{}
+		return nil
+	}
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// Forbidden reports whether s is the boolean schema false.
+func (s *Schema) Forbidden() bool {
+	return s != nil && s.forbidden
+}
+
+// Types is the type keyword: a single type, or a list when a value may be one of several.
+type Types []string
+
+// MarshalJSON writes a single type as a string.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON reads a string or a list of strings.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// Has reports whether t includes the named type.
+func (t Types) Has(name string) bool {
+	for _, item := range t {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Generator builds schemas from Go types. Every named struct it meets becomes a
+// definition under $defs, referenced wherever the struct is used.
+type Generator struct {
+	overrides map[reflect.Type]*Schema
+	names     map[reflect.Type]string
+	defs      map[string]*Schema
+}
+
+// NewGenerator returns a generator without overrides.
+func NewGenerator() *Generator {
+	return &Generator{
+		overrides: make(map[reflect.Type]*Schema),
+		names:     make(map[reflect.Type]string),
+		defs:      make(map[string]*Schema),
+	}
+}
+
+// Override describes a type with a fixed schema instead of reflecting over it,
+// for types with a custom MarshalJSON. Named types are still placed under $defs.
+func (g *Generator) Override(value any, schema *Schema) {
+	g.overrides[indirect(reflect.TypeOf(value))] = schema
+}
+
+// Generate returns a self-contained schema for the type of value, with every
+// definition it references. The generator can be reused for further types.
+func (g *Generator) Generate(value any, id, title, description string) *Schema {
+	g.defs = make(map[string]*Schema)
+	g.names = make(map[reflect.Type]string)
+	root := g.reflect(reflect.TypeOf(value))
+	if root.Ref != "" {
+		// the root type is described inline, and recursive references point at the root
+		ref := root.Ref
+		inline := *g.defs[strings.TrimPrefix(ref, "#/$defs/")]
+		delete(g.defs, strings.TrimPrefix(ref, "#/$defs/"))
+		root = &inline
+		root.walk(func(s *Schema) {
+			if s.Ref == ref {
+				s.Ref = "#"
+			}
+		})
+		for _, def := range g.defs {
+			def.walk(func(s *Schema) {
+				if s.Ref == ref {
+					s.Ref = "#"
+				}
+			})
+		}
+	}
+	root.Schema = Draft
+	root.ID = id
+	root.Title = title
+	if description != "" {
+		root.Description = description
+	}
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+// reflect returns the schema of a type, registering the named structs it uses
+// under $defs and referring to them by $ref.
+func (g *Generator) reflect(t reflect.Type) *Schema {
+	t = indirect(t)
+	if schema, ok := g.overrides[t]; ok {
+		if t.Name() == "" {
+			return schema
+		}
+		return g.define(t, func() *Schema { return schema })
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Kind() != reflect.Struct && reflect.PointerTo(t).Implements(marshalerType):
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}, Description: "base64 encoded bytes"}
+		}
+		return &Schema{Type: Types{"array"}, Items: g.reflect(t.Elem())}
+	case reflect.Map:
+		schema := &Schema{Type: Types{"object"}, AdditionalProperties: g.reflect(t.Elem())}
+		switch t.Key().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			schema.PropertyNames = &Schema{Pattern: "^-?[0-9]+$"}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			schema.PropertyNames = &Schema{Pattern: "^[0-9]+$"}
+		}
+		return schema
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return g.define(t, func() *Schema { return g.object(t) })
+	}
+	// interfaces, and anything else encoding/json decides at runtime
+	return &Schema{}
+}
+
+// define registers a named type under $defs and returns a reference to it. Names
+// that clash with a type from another package are prefixed with its package name.
+func (g *Generator) define(t reflect.Type, build func() *Schema) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := g.defs[name]; taken {
+			pkg := t.PkgPath()
+			name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+		}
+		g.names[t] = name
+		g.defs[name] = &Schema{} // placeholder for recursive types
+		g.defs[name] = build()
+	}
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+// object describes a struct as encoding/json writes it: exported fields under
+// their json names, embedded structs inlined, "-" fields skipped. Fields without
+// omitempty are required, and may be null when they are pointers, slices or maps.
+func (g *Generator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: Types{"object"}, Properties: make(map[string]*Schema), AdditionalProperties: False()}
+	g.fields(t, schema)
+	if len(schema.Required) == 0 {
+		schema.Required = nil
+	}
+	return schema
+}
+
+func (g *Generator) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && indirect(field.Type).Kind() == reflect.Struct {
+			g.fields(indirect(field.Type), schema)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property := g.reflect(field.Type)
+		omitEmpty := strings.Contains(","+options+",", ",omitempty,")
+		if !omitEmpty {
+			schema.Required = append(schema.Required, name)
+			switch field.Type.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+				property = nullable(property)
+			}
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// nullable allows null in addition to whatever s allows.
+func nullable(s *Schema) *Schema {
+	switch {
+	case s.Ref != "":
+		return &Schema{AnyOf: []*Schema{s, {Type: Types{"null"}}}}
+	case len(s.Type) == 0 || s.Type.Has("null"):
+		return s
+	}
+	widened := *s
+	widened.Type = append(append(Types{}, s.Type...), "null")
+	return &widened
+}
+
+// walk calls fn for s and every schema nested in it, without following references.
+func (s *Schema) walk(fn func(*Schema)) {
+	if s == nil {
+		return
+	}
+	fn(s)
+	for _, property := range s.Properties {
+		property.walk(fn)
+	}
+	for _, option := range s.AnyOf {
+		option.walk(fn)
+	}
+	s.AdditionalProperties.walk(fn)
+	s.PropertyNames.walk(fn)
+	s.Items.walk(fn)
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,114 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAudit struct {
+	Created time.Time `json:"created"`
+}
+
+type testNode struct {
+	testAudit
+	Name     string            `json:"name"`
+	Count    int               `json:"count,omitempty"`
+	Ratio    float64           `json:"ratio,omitempty"`
+	Tags     []string          `json:"tags"`
+	Lines    map[int]string    `json:"lines,omitempty"`
+	Parent   *testNode         `json:"parent,omitempty"`
+	Children []*testNode       `json:"children,omitempty"`
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Secret   string            `json:"-"`
+	Custom   testCustom        `json:"custom,omitempty"`
+	internal string
+}
+
+type testCustom struct {
+	Value string
+}
+
+func (c testCustom) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Value)
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	generator := NewGenerator()
+	generator.Override(testCustom{}, &Schema{Type: Types{"string"}})
+	schema := generator.Generate(&testNode{}, "https://example.com/node.schema.json", "Node", "")
+
+	assert.Equal(t, Draft, schema.Schema)
+	assert.Equal(t, "https://example.com/node.schema.json", schema.ID)
+	assert.Equal(t, Types{"object"}, schema.Type)
+	assert.True(t, schema.AdditionalProperties.Forbidden())
+	assert.Equal(t, []string{"created", "name", "tags"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Secret")
+	assert.NotContains(t, schema.Properties, "internal")
+
+	assert.Equal(t, &Schema{Type: Types{"string"}, Format: "date-time"}, schema.Properties["created"])
+	assert.Equal(t, Types{"integer"}, schema.Properties["count"].Type)
+	assert.Equal(t, Types{"number"}, schema.Properties["ratio"].Type)
+	assert.Equal(t, Types{"array", "null"}, schema.Properties["tags"].Type, "nil slices are written as null")
+	assert.Equal(t, "^-?[0-9]+$", schema.Properties["lines"].PropertyNames.Pattern)
+	assert.Equal(t, &Schema{}, schema.Properties["raw"])
+	assert.Equal(t, "#/$defs/testCustom", schema.Properties["custom"].Ref)
+	assert.Equal(t, &Schema{Type: Types{"string"}}, schema.Defs["testCustom"])
+
+	// the root type is inline, and refers to itself as the document root
+	assert.Equal(t, "#", schema.Properties["parent"].Ref)
+	assert.Equal(t, "#", schema.Properties["children"].Items.Ref)
+	assert.NotContains(t, schema.Defs, "testNode")
+}
+
+func TestSchema_MarshalJSON(t *testing.T) {
+	schema := &Schema{
+		Type:                 Types{"object"},
+		Properties:           map[string]*Schema{"name": {Type: Types{"string", "null"}}},
+		AdditionalProperties: False(),
+	}
+	out, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{"name":{"type":["string","null"]}},"additionalProperties":false}`, string(out))
+
+	var decoded Schema
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, schema, &decoded)
+}
+
+func TestValidate(t *testing.T) {
+	generator := NewGenerator()
+	generator.Override(testCustom{}, &Schema{Type: Types{"string"}, Enum: []any{"a", "b"}})
+	schema := generator.Generate(&testNode{}, "", "Node", "")
+
+	node := &testNode{Name: "root", Lines: map[int]string{1: "one"},
+		Children: []*testNode{{Name: "child", Tags: []string{"x"}, Custom: testCustom{"a"}}}, Custom: testCustom{"b"}}
+	data, err := json.Marshal(node)
+	require.NoError(t, err)
+	errs, err := ValidateJSON(schema, data)
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, err = ValidateJSON(schema, []byte(`{"created": "2026-01-01T00:00:00Z", "name": 3, "tags": null,
+		"extra": true, "lines": {"one": "1"}, "children": [{"name": "child"}], "custom": "c"}`))
+	require.NoError(t, err)
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Error()
+	}
+	assert.ElementsMatch(t, []string{
+		"/children/0: missing required property 'created'",
+		"/children/0: missing required property 'tags'",
+		"/custom: value c is not one of [a b]",
+		"/extra: unknown property 'extra'",
+		"/lines/one: 'one' does not match ^-?[0-9]+$",
+		"/name: expected string, got integer",
+	}, messages)
+}
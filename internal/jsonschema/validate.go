@@ -0,0 +1,175 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Validate checks a decoded JSON value (as produced by json.Unmarshal into an
+// any) against a schema, supporting the keywords this package generates. It
+// returns one error per violation, prefixed with the JSON pointer of the value.
+func Validate(schema *Schema, value any) []error {
+	v := validator{root: schema}
+	v.validate(schema, value, "")
+	return v.errs
+}
+
+// ValidateJSON decodes data and validates it against a schema.
+func ValidateJSON(schema *Schema, data []byte) ([]error, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return Validate(schema, value), nil
+}
+
+type validator struct {
+	root *Schema
+	errs []error
+}
+
+func (v *validator) fail(pointer, format string, args ...any) {
+	if pointer == "" {
+		pointer = "/"
+	}
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", pointer, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) validate(s *Schema, value any, pointer string) {
+	if s == nil {
+		return
+	}
+	if s.forbidden {
+		v.fail(pointer, "no value is allowed here")
+		return
+	}
+	if s.Ref != "" {
+		def, ok := v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if s.Ref == "#" {
+			def, ok = v.root, true
+		}
+		if !ok {
+			v.fail(pointer, "unresolved reference %s", s.Ref)
+			return
+		}
+		v.validate(def, value, pointer)
+	}
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, option := range s.AnyOf {
+			sub := validator{root: v.root}
+			if sub.validate(option, value, pointer); len(sub.errs) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(pointer, "value does not match any of the allowed schemas")
+		}
+	}
+	if len(s.Type) > 0 && !matchesType(s.Type, value) {
+		v.fail(pointer, "expected %s, got %s", strings.Join(s.Type, " or "), typeOf(value))
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		v.fail(pointer, "value %v is not one of %v", value, s.Enum)
+	}
+	if s.Pattern != "" {
+		if text, ok := value.(string); ok && !regexp.MustCompile(s.Pattern).MatchString(text) {
+			v.fail(pointer, "'%s' does not match %s", text, s.Pattern)
+		}
+	}
+	switch typed := value.(type) {
+	case map[string]any:
+		v.validateObject(s, typed, pointer)
+	case []any:
+		if s.Items != nil {
+			for i, item := range typed {
+				v.validate(s.Items, item, fmt.Sprintf("%s/%d", pointer, i))
+			}
+		}
+	}
+}
+
+func (v *validator) validateObject(s *Schema, object map[string]any, pointer string) {
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			v.fail(pointer, "missing required property '%s'", name)
+		}
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		child := pointer + "/" + strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+		if s.PropertyNames != nil {
+			v.validate(s.PropertyNames, key, child)
+		}
+		if property, ok := s.Properties[key]; ok {
+			v.validate(property, object[key], child)
+			continue
+		}
+		if s.AdditionalProperties != nil {
+			if s.AdditionalProperties.forbidden {
+				v.fail(child, "unknown property '%s'", key)
+				continue
+			}
+			v.validate(s.AdditionalProperties, object[key], child)
+		}
+	}
+}
+
+func matchesType(types Types, value any) bool {
+	actual := typeOf(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeOf(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if typed == math.Trunc(typed) {
+			return "integer"
+		}
+		return "number"
+	case json.Number:
+		if _, err := typed.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func inEnum(enum []any, value any) bool {
+	encoded, _ := json.Marshal(value)
+	for _, allowed := range enum {
+		if candidate, _ := json.Marshal(allowed); string(candidate) == string(encoded) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,711 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The grammar, from lowest to highest precedence:
+//
+//	expression := and ( "||" and )*
+//	and        := comparison ( "&&" comparison )*
+//	comparison := unary [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" ) unary ]
+//	unary      := "!" unary | operand
+//	operand    := primary ( "." name | "." method "(" string ")" | "[" string "]" )*
+//	primary    := name | string | number | true | false | null | list
+//	            | "size(" expression ")" | "has(" expression ")" | "(" expression ")"
+//	list       := "[" [ literal ( "," literal )* ] "]"
+//
+// Method, index and list arguments are literals, so regular expressions and
+// globs are compiled (and rejected) when the policy is loaded.
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ".", ","}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			value, end, err := scanString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: src[i:end], value: value, pos: i})
+			i = end
+		case c == '-' || c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(src) && (src[end] >= '0' && src[end] <= '9' || src[end] == '.') {
+				end++
+			}
+			value, err := strconv.ParseFloat(src[i:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number '%s' at position %d", src[i:end], i)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[i:end], value: value, pos: i})
+			i = end
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			end := i
+			for end < len(src) && (src[end] == '_' || src[end] >= 'a' && src[end] <= 'z' ||
+				src[end] >= 'A' && src[end] <= 'Z' || src[end] >= '0' && src[end] <= '9') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[i:end], pos: i})
+			i = end
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", c, i)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+func scanString(src string, start int) (string, int, error) {
+	quote := src[start]
+	var sb strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		c := src[i]
+		if c == '\\' && i+1 < len(src) {
+			i++
+			switch src[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(src[i])
+			}
+			continue
+		}
+		if c == quote {
+			return sb.String(), i + 1, nil
+		}
+		sb.WriteByte(c)
+	}
+	return "", 0, fmt.Errorf("unterminated string starting at position %d", start)
+}
+
+// node is a parsed expression.
+type node interface {
+	eval(vars map[string]any) (any, error)
+}
+
+type literalNode struct{ value any }
+
+type identNode struct{ name string }
+
+// fieldNode selects a field of a map, by member access or by a literal index.
+type fieldNode struct {
+	target node
+	name   string
+}
+
+type notNode struct{ operand node }
+
+type logicalNode struct {
+	op          string
+	left, right node
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+type sizeNode struct{ operand node }
+
+type hasNode struct{ operand node }
+
+// methodNode is a string method. match is set for matches and glob.
+type methodNode struct {
+	target node
+	name   string
+	arg    string
+	match  *regexp.Regexp
+}
+
+// Expression is a compiled policy expression.
+type Expression struct {
+	source      string
+	root        node
+	identifiers []string
+}
+
+// String returns the expression source.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Compile parses an expression so it can be evaluated many times.
+func Compile(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+	}
+	return &Expression{source: source, root: root, identifiers: p.identifiers}, nil
+}
+
+// Identifiers returns the top-level variables the expression refers to.
+func (e *Expression) Identifiers() []string {
+	return e.identifiers
+}
+
+// Eval evaluates the expression against a set of variables.
+func (e *Expression) Eval(vars map[string]any) (any, error) {
+	return e.root.eval(vars)
+}
+
+// EvalBool evaluates the expression and requires a boolean result.
+func (e *Expression) EvalBool(vars map[string]any) (bool, error) {
+	value, err := e.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must evaluate to a boolean, got %s", typeName(value))
+	}
+	return result, nil
+}
+
+type parser struct {
+	tokens      []token
+	pos         int
+	identifiers []string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) acceptOperator(op string) bool {
+	if tok := p.peek(); tok.kind == tokenOperator && tok.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectOperator(op string) error {
+	if !p.acceptOperator(op) {
+		tok := p.peek()
+		if tok.kind == tokenEOF {
+			return fmt.Errorf("expected '%s' at end of expression", op)
+		}
+		return fmt.Errorf("expected '%s' at position %d, found '%s'", op, tok.pos, tok.text)
+	}
+	return nil
+}
+
+// expectString consumes a string literal, the only argument methods and indexes take.
+func (p *parser) expectString(what string) (string, error) {
+	tok := p.next()
+	if tok.kind != tokenString {
+		if tok.kind == tokenEOF {
+			return "", fmt.Errorf("expected a string %s at end of expression", what)
+		}
+		return "", fmt.Errorf("expected a string %s at position %d, found '%s'", what, tok.pos, tok.text)
+	}
+	return tok.value.(string), nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("&&") {
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	switch {
+	case tok.kind == tokenOperator && (tok.text == "==" || tok.text == "!=" || tok.text == "<" ||
+		tok.text == "<=" || tok.text == ">" || tok.text == ">="):
+	case tok.kind == tokenIdent && tok.text == "in":
+	default:
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &compareNode{op: tok.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.acceptOperator("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *parser) parseOperand() (node, error) {
+	target, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.acceptOperator("."):
+			tok := p.next()
+			if tok.kind != tokenIdent {
+				return nil, fmt.Errorf("expected a field or method name at position %d", tok.pos)
+			}
+			if !p.acceptOperator("(") {
+				target = &fieldNode{target: target, name: tok.text}
+				continue
+			}
+			method, err := p.parseMethod(target, tok)
+			if err != nil {
+				return nil, err
+			}
+			target = method
+		case p.acceptOperator("["):
+			key, err := p.expectString("key")
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator("]"); err != nil {
+				return nil, err
+			}
+			target = &fieldNode{target: target, name: key}
+		default:
+			return target, nil
+		}
+	}
+}
+
+func (p *parser) parseMethod(target node, name token) (node, error) {
+	method := &methodNode{target: target, name: name.text}
+	switch name.text {
+	case "contains", "startsWith", "endsWith", "matches", "glob":
+	default:
+		return nil, fmt.Errorf("unknown method '%s' at position %d", name.text, name.pos)
+	}
+	arg, err := p.expectString("argument")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	method.arg = arg
+	switch name.text {
+	case "matches":
+		method.match, err = regexp.Compile(arg)
+	case "glob":
+		method.match, err = regexp.Compile(globToRegexp(arg))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s' at position %d: %w", arg, name.pos, err)
+	}
+	return method, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString, tokenNumber:
+		return &literalNode{value: tok.value}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if p.acceptOperator("(") {
+			if tok.text != "size" && tok.text != "has" {
+				return nil, fmt.Errorf("unknown function '%s' at position %d", tok.text, tok.pos)
+			}
+			operand, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator(")"); err != nil {
+				return nil, err
+			}
+			if tok.text == "size" {
+				return &sizeNode{operand: operand}, nil
+			}
+			return &hasNode{operand: operand}, nil
+		}
+		p.identifiers = append(p.identifiers, tok.text)
+		return &identNode{name: tok.text}, nil
+	case tokenOperator:
+		switch tok.text {
+		case "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		case "[":
+			return p.parseList()
+		}
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+}
+
+func (p *parser) parseList() (node, error) {
+	items := []any{}
+	if p.acceptOperator("]") {
+		return &literalNode{value: items}, nil
+	}
+	for {
+		item, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		literal, ok := item.(*literalNode)
+		if !ok {
+			return nil, fmt.Errorf("list items must be literals")
+		}
+		items = append(items, literal.value)
+		if p.acceptOperator("]") {
+			return &literalNode{value: items}, nil
+		}
+		if err := p.expectOperator(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (n *literalNode) eval(map[string]any) (any, error) {
+	return n.value, nil
+}
+
+func (n *identNode) eval(vars map[string]any) (any, error) {
+	value, ok := vars[n.name]
+	if !ok {
+		return nil, fmt.Errorf("undeclared reference to '%s'", n.name)
+	}
+	return value, nil
+}
+
+func (n *fieldNode) eval(vars map[string]any) (any, error) {
+	target, err := n.target.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	switch fields := target.(type) {
+	case map[string]any:
+		return fields[n.name], nil
+	case nil:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("cannot select field '%s' from %s", n.name, typeName(target))
+}
+
+func (n *notNode) eval(vars map[string]any) (any, error) {
+	value, err := evalBool(n.operand, vars, "!")
+	if err != nil {
+		return nil, err
+	}
+	return !value, nil
+}
+
+func (n *logicalNode) eval(vars map[string]any) (any, error) {
+	left, err := evalBool(n.left, vars, n.op)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "&&" && !left {
+		return false, nil
+	}
+	if n.op == "||" && left {
+		return true, nil
+	}
+	return evalBool(n.right, vars, n.op)
+}
+
+func evalBool(n node, vars map[string]any, op string) (bool, error) {
+	value, err := n.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("'%s' requires booleans, got %s", op, typeName(value))
+	}
+	return b, nil
+}
+
+func (n *compareNode) eval(vars map[string]any) (any, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	case "in":
+		switch container := right.(type) {
+		case []any:
+			for _, item := range container {
+				if valuesEqual(left, item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]any:
+			key, ok := left.(string)
+			if !ok {
+				return false, nil
+			}
+			_, found := container[key]
+			return found, nil
+		}
+		return nil, fmt.Errorf("'in' requires a list or map, got %s", typeName(right))
+	}
+
+	var cmp int
+	if ls, ok := left.(string); ok {
+		rs, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare string with %s", typeName(right))
+		}
+		cmp = strings.Compare(ls, rs)
+	} else {
+		lf, lok := toFloat(left)
+		rf, rok := toFloat(right)
+		if !lok || !rok {
+			return nil, fmt.Errorf("cannot compare %s with %s", typeName(left), typeName(right))
+		}
+		switch {
+		case lf < rf:
+			cmp = -1
+		case lf > rf:
+			cmp = 1
+		}
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+func (n *sizeNode) eval(vars map[string]any) (any, error) {
+	value, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	switch typed := value.(type) {
+	case string:
+		return int64(len(typed)), nil
+	case []any:
+		return int64(len(typed)), nil
+	case map[string]any:
+		return int64(len(typed)), nil
+	case nil:
+		return int64(0), nil
+	}
+	return nil, fmt.Errorf("size() is not defined for %s", typeName(value))
+}
+
+func (n *hasNode) eval(vars map[string]any) (any, error) {
+	value, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	return value != nil, nil
+}
+
+func (n *methodNode) eval(vars map[string]any) (any, error) {
+	target, err := n.target.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := target.(string)
+	if !ok {
+		return nil, fmt.Errorf("no method '%s' on %s", n.name, typeName(target))
+	}
+	switch n.name {
+	case "contains":
+		return strings.Contains(str, n.arg), nil
+	case "startsWith":
+		return strings.HasPrefix(str, n.arg), nil
+	case "endsWith":
+		return strings.HasSuffix(str, n.arg), nil
+	}
+	return n.match.MatchString(str), nil
+}
+
+func toFloat(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case int64:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	}
+	return 0, false
+}
+
+func valuesEqual(left, right any) bool {
+	if lf, ok := toFloat(left); ok {
+		rf, ok := toFloat(right)
+		return ok && lf == rf
+	}
+	switch left.(type) {
+	case []any, map[string]any:
+		return false
+	}
+	switch right.(type) {
+	case []any, map[string]any:
+		return false
+	}
+	return left == right
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case string:
+		return "string"
+	case int64:
+		return "int"
+	case float64:
+		return "double"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "map"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// globToRegexp converts a path glob into an anchored regular expression.
+// '*' and '?' stay within a single path segment, '**' crosses segments, and a
+// trailing '/**' also matches the directory itself.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '/':
+			if strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob) {
+				sb.WriteString("(/.*)?")
+				i += 2
+			} else {
+				sb.WriteByte('/')
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
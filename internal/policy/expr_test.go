@@ -0,0 +1,143 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func evalExpression(t *testing.T, source string, vars map[string]any) any {
+	t.Helper()
+	expression, err := Compile(source)
+	require.NoError(t, err)
+	value, err := expression.Eval(vars)
+	require.NoError(t, err)
+	return value
+}
+
+func TestExpression_Evaluates(t *testing.T) {
+	vars := map[string]any{
+		"change": map[string]any{
+			"path":     "$.paths['/internal/jobs'].get.responses",
+			"property": "404",
+			"type":     "removed",
+			"breaking": true,
+		},
+		"operation": map[string]any{
+			"path":       "/internal/jobs",
+			"extensions": map[string]any{"x-beta": true},
+			"tags":       []any{"jobs", "internal"},
+		},
+		"commit": map[string]any{"changes": int64(21)},
+	}
+
+	tests := []struct {
+		source   string
+		expected any
+	}{
+		{`change.type == "removed" && change.breaking`, true},
+		{`change.type == 'added' || !change.breaking`, false},
+		{`change.property.matches("^[1-5][0-9]{2}$")`, true},
+		{`change.path.endsWith(".responses")`, true},
+		{`change.path.startsWith("$.components")`, false},
+		{`change.path.contains("/internal/")`, true},
+		{`operation.path.glob("/internal/**")`, true},
+		{`operation.path.glob("/internal/*/x")`, false},
+		{`operation.extensions["x-beta"] == true`, true},
+		{`operation.extensions["x-missing"] == null`, true},
+		{`has(operation.extensions["x-beta"])`, true},
+		{`"jobs" in operation.tags`, true},
+		{`"x-beta" in operation.extensions`, true},
+		{`size(operation.tags) == 2`, true},
+		{`commit.changes > 20`, true},
+		{`commit.changes >= 20.5`, true},
+		{`commit.changes < -1`, false},
+		{`change.type in ["added", "removed"]`, true},
+		{`operation.extensions["x-missing"]["nested"] == null`, true},
+		{`!(change.type == "added") && size("abc") == 3`, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.source, func(t *testing.T) {
+			assert.Equal(t, tc.expected, evalExpression(t, tc.source, vars))
+		})
+	}
+}
+
+func TestExpression_ShortCircuits(t *testing.T) {
+	vars := map[string]any{"value": nil}
+	assert.Equal(t, false, evalExpression(t, `value != null && value.startsWith("x")`, vars))
+	assert.Equal(t, true, evalExpression(t, `value == null || value.startsWith("x")`, vars))
+}
+
+func TestCompile_Errors(t *testing.T) {
+	for _, source := range []string{
+		`change.path ==`,
+		`(change.breaking`,
+		`"unterminated`,
+		`change.path # 1`,
+		`unknown(1)`,
+		`change.breaking true`,
+		`change.breaking ? 1 : 2`,
+		`commit.changes - 1 > 2`,
+		`change.path.endsWith(change.property)`,
+		`change.path.matches("[")`,
+		`change.path.size()`,
+		`change.path.trim("x")`,
+		`operation.tags[0] == "jobs"`,
+		`change.type in [change.property]`,
+	} {
+		t.Run(source, func(t *testing.T) {
+			_, err := Compile(source)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestExpression_RuntimeErrors(t *testing.T) {
+	vars := map[string]any{"change": map[string]any{"path": "$.paths", "breaking": true}}
+	for _, source := range []string{
+		`missing == 1`,
+		`change.breaking.startsWith("x")`,
+		`change.path > 1`,
+		`!change.path`,
+		`"$" in change.path`,
+	} {
+		t.Run(source, func(t *testing.T) {
+			expression, err := Compile(source)
+			require.NoError(t, err)
+			_, err = expression.Eval(vars)
+			assert.Error(t, err)
+		})
+	}
+
+	expression, err := Compile(`change.path`)
+	require.NoError(t, err)
+	_, err = expression.EvalBool(vars)
+	assert.ErrorContains(t, err, "must evaluate to a boolean")
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob, value string
+		matches     bool
+	}{
+		{"/internal/**", "/internal", true},
+		{"/internal/**", "/internal/jobs/{id}", true},
+		{"/internal/**", "/internally", false},
+		{"/orders/*", "/orders/{id}", true},
+		{"/orders/*", "/orders/{id}/items", false},
+		{"/orders/?", "/orders/1", true},
+		{"**/items", "/orders/{id}/items", true},
+		{"/v1.0/*", "/v1x0/a", false},
+	}
+	for _, tc := range tests {
+		re, err := regexp.Compile(globToRegexp(tc.glob))
+		require.NoError(t, err)
+		assert.Equal(t, tc.matches, re.MatchString(tc.value), "%s against %s", tc.glob, tc.value)
+	}
+}
@@ -0,0 +1,414 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+// Package policy evaluates user-defined rules, written as boolean expressions,
+// against the changes in a comparison and the documents they came from. Report
+// rules raise findings; allow rules set the severity of the changes they match
+// and exempt them from the report rules.
+//
+// Expressions borrow CEL's syntax but are deliberately a small predicate
+// language rather than CEL itself: rules only ever test a fixed set of string,
+// number, boolean, list and map fields, so they need field access, comparisons,
+// 'in', '&&', '||', '!', size(), has() and a handful of string tests, and
+// nothing else. That is not worth a CEL runtime and its dependency tree in a
+// CLI. Method arguments must be string literals, so every pattern is compiled,
+// and every mistake reported, when the policy is loaded rather than part way
+// through a report.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/changecounts"
+	"github.com/pb33f/openapi-changes/model"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+	SeverityIgnore  = "ignore"
+
+	ScopeChange = "change"
+	ScopeCommit = "commit"
+
+	ActionReport = "report"
+	ActionAllow  = "allow"
+)
+
+// scopeVariables lists the variables each rule scope can refer to.
+var scopeVariables = map[string]map[string]bool{
+	ScopeChange: {"change": true, "operation": true, "commit": true, "document": true},
+	ScopeCommit: {"commit": true, "document": true},
+}
+
+// Rule is a single policy rule. When is evaluated once per change (the default
+// scope) or once per commit. A report rule raises a finding with Severity when
+// it is true; an allow rule gives the matching change Severity instead.
+type Rule struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Action      string `yaml:"action,omitempty"`
+	Severity    string `yaml:"severity"`
+	Scope       string `yaml:"scope,omitempty"`
+	When        string `yaml:"when"`
+	Message     string `yaml:"message,omitempty"`
+
+	expression *Expression
+}
+
+// Policy is a compiled set of rules.
+type Policy struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// Load reads and compiles a policy file.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file '%s': %w", path, err)
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file '%s': %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse decodes and compiles a policy from YAML.
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	var problems []error
+	names := make(map[string]struct{}, len(p.Rules))
+	for i, rule := range p.Rules {
+		if rule == nil {
+			problems = append(problems, fmt.Errorf("rule %d is empty", i+1))
+			continue
+		}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if _, dup := names[rule.Name]; dup {
+			problems = append(problems, fmt.Errorf("rule '%s' is defined more than once", rule.Name))
+		}
+		names[rule.Name] = struct{}{}
+
+		switch rule.Action {
+		case ActionReport, ActionAllow:
+		case "":
+			rule.Action = ActionReport
+		default:
+			problems = append(problems, fmt.Errorf("rule '%s' has unknown action '%s' (expected report or allow)",
+				rule.Name, rule.Action))
+		}
+		if rule.Action == ActionAllow {
+			switch rule.Severity {
+			case SeverityWarning, SeverityInfo, SeverityIgnore:
+			case "":
+				rule.Severity = SeverityIgnore
+			default:
+				problems = append(problems, fmt.Errorf("allow rule '%s' has unknown severity '%s' (expected warning, info or ignore)",
+					rule.Name, rule.Severity))
+			}
+		} else {
+			switch rule.Severity {
+			case SeverityError, SeverityWarning, SeverityInfo:
+			case "":
+				rule.Severity = SeverityError
+			default:
+				problems = append(problems, fmt.Errorf("rule '%s' has unknown severity '%s' (expected error, warning or info)",
+					rule.Name, rule.Severity))
+			}
+		}
+		switch rule.Scope {
+		case ScopeChange, ScopeCommit:
+		case "":
+			rule.Scope = ScopeChange
+		default:
+			problems = append(problems, fmt.Errorf("rule '%s' has unknown scope '%s' (expected change or commit)",
+				rule.Name, rule.Scope))
+		}
+		if rule.Action == ActionAllow && rule.Scope == ScopeCommit {
+			problems = append(problems, fmt.Errorf("allow rule '%s' must be change-scoped", rule.Name))
+		}
+		if strings.TrimSpace(rule.When) == "" {
+			problems = append(problems, fmt.Errorf("rule '%s' has no 'when' expression", rule.Name))
+			continue
+		}
+		expression, err := Compile(rule.When)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("rule '%s': %w", rule.Name, err))
+			continue
+		}
+		for _, name := range expression.Identifiers() {
+			if variables, ok := scopeVariables[rule.Scope]; ok && !variables[name] {
+				problems = append(problems, fmt.Errorf("rule '%s': '%s' is not available to %s-scoped rules",
+					rule.Name, name, rule.Scope))
+			}
+		}
+		rule.expression = expression
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return &p, nil
+}
+
+// Operation is the operation (or path item, when Method is empty) a change belongs to.
+type Operation struct {
+	Path        string
+	Method      string
+	OperationID string
+	Tags        []string
+	Deprecated  bool
+	Extensions  map[string]any
+}
+
+// Document describes the revision a set of changes was found in.
+type Document struct {
+	Title      string
+	Version    string
+	Extensions map[string]any
+}
+
+// Commit describes the commit a set of changes was found in.
+type Commit struct {
+	Hash    string
+	Message string
+	Author  string
+	Date    time.Time
+}
+
+// Input is everything a policy is evaluated against for a single comparison.
+// Resolve, when set, returns the operation a change belongs to and the location
+// of the change beneath it.
+type Input struct {
+	Commit   Commit
+	Document Document
+	Changes  []*model.HashedChange
+	Resolve  func(change *model.HashedChange) (*Operation, string)
+}
+
+// Evaluate runs every rule against the input and returns the findings in rule
+// order. Allow rules run first: the first one to match a change sets its
+// Severity, and report rules raise no findings for that change.
+func (p *Policy) Evaluate(input *Input) ([]*model.PolicyFinding, error) {
+	if p == nil || input == nil {
+		return nil, nil
+	}
+
+	changes := make([]*whatChangedModel.Change, 0, len(input.Changes))
+	for _, change := range input.Changes {
+		if change != nil && change.Change != nil {
+			changes = append(changes, change.Change)
+		}
+	}
+	counts := changecounts.FromChanges(changes)
+	commitVars := map[string]any{
+		"hash":          input.Commit.Hash,
+		"message":       input.Commit.Message,
+		"author":        input.Commit.Author,
+		"date":          formatDate(input.Commit.Date),
+		"changes":       int64(counts.Total),
+		"breaking":      int64(counts.Breaking),
+		"additions":     int64(counts.Additions),
+		"modifications": int64(counts.Modifications),
+		"removals":      int64(counts.Removals),
+	}
+	documentVars := map[string]any{
+		"title":      input.Document.Title,
+		"version":    input.Document.Version,
+		"extensions": normalizeMap(input.Document.Extensions),
+	}
+
+	// Change variables are built once and shared by every change-scoped rule.
+	changeScopes := make([]map[string]any, 0, len(input.Changes))
+	scopedChanges := make([]*model.HashedChange, 0, len(input.Changes))
+	for _, change := range input.Changes {
+		if change == nil || change.Change == nil {
+			continue
+		}
+		var operation *Operation
+		location := ""
+		if input.Resolve != nil {
+			operation, location = input.Resolve(change)
+		}
+		changeScopes = append(changeScopes, map[string]any{
+			"change":    changeVars(change, location),
+			"operation": operationVars(operation),
+			"commit":    commitVars,
+			"document":  documentVars,
+		})
+		scopedChanges = append(scopedChanges, change)
+	}
+
+	allowed := make([]bool, len(changeScopes))
+	for _, rule := range p.Rules {
+		if rule.Action != ActionAllow {
+			continue
+		}
+		for i, vars := range changeScopes {
+			if allowed[i] {
+				continue
+			}
+			matched, err := rule.expression.EvalBool(vars)
+			if err != nil {
+				return nil, fmt.Errorf("policy rule '%s': %w", rule.Name, err)
+			}
+			if matched {
+				allowed[i] = true
+				scopedChanges[i].Severity = rule.Severity
+			}
+		}
+	}
+
+	var findings []*model.PolicyFinding
+	for _, rule := range p.Rules {
+		if rule.Action == ActionAllow {
+			continue
+		}
+		if rule.Scope == ScopeCommit {
+			matched, err := rule.expression.EvalBool(map[string]any{"commit": commitVars, "document": documentVars})
+			if err != nil {
+				return nil, fmt.Errorf("policy rule '%s': %w", rule.Name, err)
+			}
+			if matched {
+				findings = append(findings, rule.finding(input.Commit.Hash, nil))
+			}
+			continue
+		}
+		for i, vars := range changeScopes {
+			if allowed[i] {
+				continue
+			}
+			matched, err := rule.expression.EvalBool(vars)
+			if err != nil {
+				return nil, fmt.Errorf("policy rule '%s': %w", rule.Name, err)
+			}
+			if matched {
+				findings = append(findings, rule.finding(input.Commit.Hash, scopedChanges[i]))
+			}
+		}
+	}
+	return findings, nil
+}
+
+func (r *Rule) finding(commit string, change *model.HashedChange) *model.PolicyFinding {
+	message := r.Message
+	if message == "" {
+		message = r.Description
+	}
+	if message == "" {
+		message = r.Name
+	}
+	finding := &model.PolicyFinding{
+		Rule:     r.Name,
+		Severity: r.Severity,
+		Message:  message,
+		Commit:   commit,
+	}
+	if change != nil {
+		finding.ChangeHash = change.ChangeHash
+		finding.Path = change.Path
+		finding.Property = change.Property
+	}
+	return finding
+}
+
+func changeVars(change *model.HashedChange, location string) map[string]any {
+	vars := map[string]any{
+		"path":     change.Path,
+		"rawPath":  change.RawPath,
+		"property": change.Property,
+		"type":     changecounts.CategoryOf(change.Change).Label(),
+		"breaking": change.Breaking,
+		"original": change.Original,
+		"new":      change.New,
+		"hash":     change.ChangeHash,
+		"location": location,
+		"line":     int64(0),
+	}
+	if change.RawPath == "" {
+		vars["rawPath"] = change.Path
+	}
+	if change.Context != nil {
+		if change.Context.NewLine != nil {
+			vars["line"] = int64(*change.Context.NewLine)
+		} else if change.Context.OriginalLine != nil {
+			vars["line"] = int64(*change.Context.OriginalLine)
+		}
+	}
+	return vars
+}
+
+func operationVars(operation *Operation) map[string]any {
+	if operation == nil {
+		operation = &Operation{}
+	}
+	tags := make([]any, 0, len(operation.Tags))
+	for _, tag := range operation.Tags {
+		tags = append(tags, tag)
+	}
+	return map[string]any{
+		"path":        operation.Path,
+		"method":      operation.Method,
+		"operationId": operation.OperationID,
+		"tags":        tags,
+		"deprecated":  operation.Deprecated,
+		"extensions":  normalizeMap(operation.Extensions),
+	}
+}
+
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(time.RFC3339)
+}
+
+// normalizeMap converts decoded YAML values into the value types the
+// expression language works with.
+func normalizeMap(values map[string]any) map[string]any {
+	normalized := make(map[string]any, len(values))
+	for key, value := range values {
+		normalized[key] = normalizeValue(value)
+	}
+	return normalized
+}
+
+func normalizeValue(value any) any {
+	switch typed := value.(type) {
+	case int:
+		return int64(typed)
+	case int32:
+		return int64(typed)
+	case uint64:
+		return int64(typed)
+	case float32:
+		return float64(typed)
+	case map[string]any:
+		return normalizeMap(typed)
+	case map[any]any:
+		converted := make(map[string]any, len(typed))
+		for key, item := range typed {
+			converted[fmt.Sprint(key)] = normalizeValue(item)
+		}
+		return converted
+	case []any:
+		items := make([]any, len(typed))
+		for i, item := range typed {
+			items[i] = normalizeValue(item)
+		}
+		return items
+	}
+	return value
+}
@@ -0,0 +1,229 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const examplePolicy = `rules:
+  - name: response-removal
+    description: Removing a response code is only allowed under /internal/**
+    severity: error
+    when: >-
+      change.type == "removed" && change.path.endsWith(".responses")
+      && !operation.path.glob("/internal/**")
+  - name: required-request-property
+    severity: error
+    message: New required request properties need x-beta operations
+    when: >-
+      change.type == "added" && change.property == "required"
+      && change.location.startsWith("requestBody")
+      && operation.extensions["x-beta"] != true
+  - name: large-commit
+    scope: commit
+    severity: warning
+    message: More than 2 changes in one commit
+    when: commit.changes > 2
+`
+
+func testChange(path, property string, changeType int, breaking bool) *model.HashedChange {
+	change := &model.HashedChange{Change: &whatChangedModel.Change{
+		Path:       path,
+		Property:   property,
+		ChangeType: changeType,
+		Breaking:   breaking,
+		Context:    &whatChangedModel.ChangeContext{},
+	}}
+	change.HashChange()
+	return change
+}
+
+func TestParse_ExamplePolicy(t *testing.T) {
+	p, err := Parse([]byte(examplePolicy))
+	require.NoError(t, err)
+	require.Len(t, p.Rules, 3)
+	assert.Equal(t, ScopeChange, p.Rules[0].Scope)
+	assert.Equal(t, ScopeCommit, p.Rules[2].Scope)
+}
+
+func TestParse_Defaults(t *testing.T) {
+	p, err := Parse([]byte("rules:\n  - when: change.breaking\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "rule-1", p.Rules[0].Name)
+	assert.Equal(t, SeverityError, p.Rules[0].Severity)
+	assert.Equal(t, ScopeChange, p.Rules[0].Scope)
+}
+
+func TestParse_AllowDefaults(t *testing.T) {
+	p, err := Parse([]byte("rules:\n  - action: allow\n    when: change.breaking\n"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionAllow, p.Rules[0].Action)
+	assert.Equal(t, SeverityIgnore, p.Rules[0].Severity)
+
+	p, err = Parse([]byte("rules:\n  - when: change.breaking\n"))
+	require.NoError(t, err)
+	assert.Equal(t, ActionReport, p.Rules[0].Action)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"severity":   "rules:\n  - name: a\n    severity: fatal\n    when: change.breaking\n",
+		"scope":      "rules:\n  - name: a\n    scope: file\n    when: change.breaking\n",
+		"when":       "rules:\n  - name: a\n",
+		"syntax":     "rules:\n  - name: a\n    when: change.breaking &&\n",
+		"duplicate":  "rules:\n  - name: a\n    when: change.breaking\n  - name: a\n    when: change.breaking\n",
+		"variable":   "rules:\n  - name: a\n    when: changes.breaking\n",
+		"commitVars": "rules:\n  - name: a\n    scope: commit\n    when: change.breaking\n",
+		"action":     "rules:\n  - name: a\n    action: deny\n    when: change.breaking\n",
+		"allowLevel": "rules:\n  - name: a\n    action: allow\n    severity: error\n    when: change.breaking\n",
+		"allowScope": "rules:\n  - name: a\n    action: allow\n    scope: commit\n    when: commit.changes > 1\n",
+		"yaml":       "rules: [",
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(source))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes-policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(examplePolicy), 0o644))
+	p, err := Load(path)
+	require.NoError(t, err)
+	assert.Len(t, p.Rules, 3)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read policy file")
+}
+
+func TestEvaluate_ExamplePolicy(t *testing.T) {
+	p, err := Parse([]byte(examplePolicy))
+	require.NoError(t, err)
+
+	internalRemoval := testChange("$.paths['/internal/jobs'].get.responses", "404", whatChangedModel.PropertyRemoved, true)
+	publicRemoval := testChange("$.paths['/orders'].get.responses", "404", whatChangedModel.PropertyRemoved, true)
+	betaRequired := testChange("$.paths['/orders'].post.requestBody.content['application/json'].schema",
+		"required", whatChangedModel.PropertyAdded, true)
+	stableRequired := testChange("$.paths['/orders'].put.requestBody.content['application/json'].schema",
+		"required", whatChangedModel.PropertyAdded, true)
+
+	operations := map[*model.HashedChange]*Operation{
+		internalRemoval: {Path: "/internal/jobs", Method: "get"},
+		publicRemoval:   {Path: "/orders", Method: "get"},
+		betaRequired:    {Path: "/orders", Method: "post", Extensions: map[string]any{"x-beta": true}},
+		stableRequired:  {Path: "/orders", Method: "put"},
+	}
+	locations := map[*model.HashedChange]string{
+		internalRemoval: "responses",
+		publicRemoval:   "responses",
+		betaRequired:    "requestBody.content['application/json'].schema",
+		stableRequired:  "requestBody.content['application/json'].schema",
+	}
+
+	findings, err := p.Evaluate(&Input{
+		Commit:  Commit{Hash: "abc123"},
+		Changes: []*model.HashedChange{internalRemoval, publicRemoval, betaRequired, stableRequired},
+		Resolve: func(change *model.HashedChange) (*Operation, string) {
+			return operations[change], locations[change]
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 3)
+
+	assert.Equal(t, "response-removal", findings[0].Rule)
+	assert.Equal(t, publicRemoval.Path, findings[0].Path)
+	assert.Equal(t, publicRemoval.ChangeHash, findings[0].ChangeHash)
+	assert.Equal(t, "Removing a response code is only allowed under /internal/**", findings[0].Message)
+
+	assert.Equal(t, "required-request-property", findings[1].Rule)
+	assert.Equal(t, stableRequired.Path, findings[1].Path)
+
+	assert.Equal(t, "large-commit", findings[2].Rule)
+	assert.Equal(t, SeverityWarning, findings[2].Severity)
+	assert.Equal(t, "abc123", findings[2].Commit)
+	assert.Empty(t, findings[2].ChangeHash)
+}
+
+func TestEvaluate_AllowRulesExemptChanges(t *testing.T) {
+	p, err := Parse([]byte(`rules:
+  - name: response-removal
+    severity: error
+    when: change.type == "removed" && change.path.endsWith(".responses")
+  - name: internal-response-removal
+    action: allow
+    when: change.type == "removed" && operation.path.glob("/internal/**")
+  - name: deprecated-response-removal
+    action: allow
+    severity: warning
+    when: change.type == "removed" && operation.deprecated
+`))
+	require.NoError(t, err)
+
+	internalRemoval := testChange("$.paths['/internal/jobs'].get.responses", "404", whatChangedModel.PropertyRemoved, true)
+	deprecatedRemoval := testChange("$.paths['/legacy'].get.responses", "404", whatChangedModel.PropertyRemoved, true)
+	publicRemoval := testChange("$.paths['/orders'].get.responses", "404", whatChangedModel.PropertyRemoved, true)
+	operations := map[*model.HashedChange]*Operation{
+		internalRemoval:   {Path: "/internal/jobs", Method: "get", Deprecated: true},
+		deprecatedRemoval: {Path: "/legacy", Method: "get", Deprecated: true},
+		publicRemoval:     {Path: "/orders", Method: "get"},
+	}
+
+	findings, err := p.Evaluate(&Input{
+		Changes: []*model.HashedChange{internalRemoval, deprecatedRemoval, publicRemoval},
+		Resolve: func(change *model.HashedChange) (*Operation, string) {
+			return operations[change], "responses"
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, publicRemoval.ChangeHash, findings[0].ChangeHash)
+
+	assert.Equal(t, SeverityIgnore, internalRemoval.Severity, "the first matching allow rule sets the severity")
+	assert.Equal(t, SeverityWarning, deprecatedRemoval.Severity)
+	assert.Empty(t, publicRemoval.Severity)
+}
+
+func TestEvaluate_DocumentAndCommitVariables(t *testing.T) {
+	p, err := Parse([]byte(`rules:
+  - name: beta-document
+    scope: commit
+    severity: info
+    when: document.extensions["x-stage"] == "beta" && commit.author == "jane" && document.version.startsWith("2.")
+`))
+	require.NoError(t, err)
+
+	findings, err := p.Evaluate(&Input{
+		Commit:   Commit{Author: "jane"},
+		Document: Document{Version: "2.1.0", Extensions: map[string]any{"x-stage": "beta"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "beta-document", findings[0].Message)
+}
+
+func TestEvaluate_RuntimeErrorNamesRule(t *testing.T) {
+	p, err := Parse([]byte("rules:\n  - name: bad\n    when: change.breaking.startsWith('x')\n"))
+	require.NoError(t, err)
+	_, err = p.Evaluate(&Input{Changes: []*model.HashedChange{
+		testChange("$.paths", "/a", whatChangedModel.PropertyAdded, false),
+	}})
+	assert.ErrorContains(t, err, "policy rule 'bad'")
+}
+
+func TestEvaluate_NilPolicy(t *testing.T) {
+	var p *Policy
+	findings, err := p.Evaluate(&Input{})
+	assert.NoError(t, err)
+	assert.Nil(t, findings)
+}
@@ -0,0 +1,309 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+// Package review records the decisions reviewers make about changes while they
+// walk through them in the console. A change can be marked reviewed, approved or
+// needs-discussion and carry a short note. Marks are keyed by change hash, the
+// same hash the reports give each change, and are kept in a local JSON file.
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+)
+
+const (
+	Reviewed        = "reviewed"
+	Approved        = "approved"
+	NeedsDiscussion = "needs-discussion"
+)
+
+// Statuses lists the statuses in the order the console cycles through them.
+var Statuses = []string{Reviewed, Approved, NeedsDiscussion}
+
+// Next returns the status after status in the cycle. The status after the last
+// one is "", which clears the mark.
+func Next(status string) string {
+	if i := slices.Index(Statuses, status); i+1 < len(Statuses) {
+		return Statuses[i+1]
+	}
+	return ""
+}
+
+// Mark is a reviewer's decision about a change. The change's location is kept
+// alongside the decision so that exports can describe it without the spec.
+type Mark struct {
+	Status   string    `json:"status,omitempty"`
+	Note     string    `json:"note,omitempty"`
+	Commit   string    `json:"commit,omitempty"`
+	Change   string    `json:"change,omitempty"` // added, modified or removed
+	Property string    `json:"property,omitempty"`
+	Path     string    `json:"path,omitempty"`
+	Breaking bool      `json:"breaking,omitempty"`
+	Updated  time.Time `json:"updated"`
+}
+
+// empty reports whether the mark records nothing and can be dropped.
+func (m *Mark) empty() bool {
+	return m == nil || (m.Status == "" && m.Note == "")
+}
+
+// NewMark returns an unmarked mark that describes a change in a commit.
+func NewMark(ch *whatChangedModel.Change, commit string) *Mark {
+	mark := &Mark{Commit: commit, Property: ch.Property, Path: ch.Path, Breaking: ch.Breaking}
+	switch ch.ChangeType {
+	case whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded:
+		mark.Change = "added"
+	case whatChangedModel.PropertyRemoved, whatChangedModel.ObjectRemoved:
+		mark.Change = "removed"
+	case whatChangedModel.Modified:
+		mark.Change = "modified"
+	}
+	return mark
+}
+
+// ChangeHash returns the hash that identifies a change in reports.
+func ChangeHash(ch *whatChangedModel.Change) string {
+	if ch.Context == nil {
+		// hashing reads the change's line and column context
+		withContext := *ch
+		withContext.Context = &whatChangedModel.ChangeContext{}
+		ch = &withContext
+	}
+	hashed := model.HashedChange{Change: ch}
+	hashed.HashChange()
+	return hashed.ChangeHash
+}
+
+// Store holds the marks of a review file. A store without a path is kept in
+// memory only.
+type Store struct {
+	path  string
+	marks map[string]*Mark
+}
+
+type storeFile struct {
+	Marks map[string]*Mark `json:"marks"`
+}
+
+// NewStore returns an empty store that saves to path.
+func NewStore(path string) *Store {
+	return &Store{path: path, marks: make(map[string]*Mark)}
+}
+
+// Load reads a review file. A file that does not exist yet is an empty store.
+func Load(path string) (*Store, error) {
+	store := NewStore(path)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read review file '%s': %w", path, err)
+	}
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("cannot parse review file '%s': %w", path, err)
+	}
+	for hash, mark := range file.Marks {
+		if !mark.empty() {
+			store.marks[hash] = mark
+		}
+	}
+	return store, nil
+}
+
+// Path returns the file the store saves to.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Len returns the number of marked changes.
+func (s *Store) Len() int {
+	return len(s.marks)
+}
+
+// Get returns the mark of a change, or nil when it is not marked.
+func (s *Store) Get(hash string) *Mark {
+	return s.marks[hash]
+}
+
+// Set replaces the mark of a change. A mark without a status or a note clears it.
+func (s *Store) Set(hash string, mark *Mark) {
+	if mark.empty() {
+		delete(s.marks, hash)
+		return
+	}
+	mark.Updated = time.Now().UTC()
+	s.marks[hash] = mark
+}
+
+// Save writes the store to its file.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(storeFile{Marks: s.marks}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("cannot write review file '%s': %w", s.path, err)
+	}
+	return nil
+}
+
+// sorted returns the marks in the order they are exported: by commit, path and property.
+func (s *Store) sorted() []*Mark {
+	hashes := make([]string, 0, len(s.marks))
+	for hash := range s.marks {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		a, b := s.marks[hashes[i]], s.marks[hashes[j]]
+		if a.Commit != b.Commit {
+			return a.Commit < b.Commit
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Property != b.Property {
+			return a.Property < b.Property
+		}
+		return hashes[i] < hashes[j]
+	})
+	marks := make([]*Mark, len(hashes))
+	for i, hash := range hashes {
+		marks[i] = s.marks[hash]
+	}
+	return marks
+}
+
+var markdownSections = []struct{ status, title string }{
+	{NeedsDiscussion, "Needs discussion"},
+	{Approved, "Approved"},
+	{Reviewed, "Reviewed"},
+	{"", "Notes"},
+}
+
+// Markdown renders the marks as a markdown summary of the review, with the
+// changes that need discussion first.
+func (s *Store) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# API review\n")
+	if len(s.marks) == 0 {
+		sb.WriteString("\nNo changes have been marked.\n")
+		return sb.String()
+	}
+	marks := s.sorted()
+	for _, section := range markdownSections {
+		var items []string
+		for _, mark := range marks {
+			if mark.Status != section.status {
+				continue
+			}
+			item := fmt.Sprintf("- %s `%s` at `%s`", mark.Change, mark.Property, mark.Path)
+			if mark.Breaking {
+				item += " **(breaking)**"
+			}
+			if mark.Commit != "" {
+				item += " in " + shortHash(mark.Commit)
+			}
+			if mark.Note != "" {
+				item += ": " + mark.Note
+			}
+			items = append(items, item)
+		}
+		if len(items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %s (%d)\n\n%s\n", section.title, len(items), strings.Join(items, "\n"))
+	}
+	return sb.String()
+}
+
+// BaselineChange is a change that reviewers accepted.
+type BaselineChange struct {
+	ChangeHash string `json:"changeHash"`
+	Commit     string `json:"commit,omitempty"`
+	Change     string `json:"change,omitempty"`
+	Property   string `json:"property,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Breaking   bool   `json:"breaking,omitempty"`
+	Note       string `json:"note,omitempty"`
+}
+
+// Baseline is the record of the changes a review approved, by change hash.
+type Baseline struct {
+	Changes []BaselineChange `json:"changes"`
+}
+
+// Baseline returns the approved changes.
+func (s *Store) Baseline() *Baseline {
+	baseline := &Baseline{Changes: []BaselineChange{}}
+	for hash, mark := range s.marks {
+		if mark.Status != Approved {
+			continue
+		}
+		baseline.Changes = append(baseline.Changes, BaselineChange{
+			ChangeHash: hash,
+			Commit:     mark.Commit,
+			Change:     mark.Change,
+			Property:   mark.Property,
+			Path:       mark.Path,
+			Breaking:   mark.Breaking,
+			Note:       mark.Note,
+		})
+	}
+	sort.Slice(baseline.Changes, func(i, j int) bool {
+		a, b := baseline.Changes[i], baseline.Changes[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.ChangeHash < b.ChangeHash
+	})
+	return baseline
+}
+
+// ExportPaths returns where the markdown summary and the baseline of a review
+// file are exported: beside it, named after it.
+func ExportPaths(path string) (markdownPath, baselinePath string) {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	return base + ".md", base + ".baseline.json"
+}
+
+// Export writes the markdown summary and the baseline beside the review file.
+func (s *Store) Export() (markdownPath, baselinePath string, err error) {
+	if s.path == "" {
+		return "", "", errors.New("no review file to export beside")
+	}
+	markdownPath, baselinePath = ExportPaths(s.path)
+	if err := os.WriteFile(markdownPath, []byte(s.Markdown()), 0644); err != nil {
+		return "", "", fmt.Errorf("cannot write review summary '%s': %w", markdownPath, err)
+	}
+	data, err := json.MarshalIndent(s.Baseline(), "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(baselinePath, append(data, '\n'), 0644); err != nil {
+		return "", "", fmt.Errorf("cannot write review baseline '%s': %w", baselinePath, err)
+	}
+	return markdownPath, baselinePath, nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,132 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package review
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	assert.Equal(t, Reviewed, Next(""))
+	assert.Equal(t, Approved, Next(Reviewed))
+	assert.Equal(t, NeedsDiscussion, Next(Approved))
+	assert.Equal(t, "", Next(NeedsDiscussion))
+}
+
+func TestChangeHash_MatchesReports(t *testing.T) {
+	line := 8
+	ch := &whatChangedModel.Change{
+		ChangeType: whatChangedModel.Modified,
+		Property:   "required",
+		Original:   "false",
+		New:        "true",
+		Context:    &whatChangedModel.ChangeContext{NewLine: &line},
+	}
+	hashed := model.HashedChange{Change: ch}
+	hashed.HashChange()
+	assert.Equal(t, hashed.ChangeHash, ChangeHash(ch))
+
+	ch.Context = nil
+	assert.NotEmpty(t, ChangeHash(ch), "changes without context are hashed too")
+	assert.Nil(t, ch.Context)
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.json")
+	store, err := Load(path)
+	require.NoError(t, err, "a missing review file is an empty review")
+	assert.Zero(t, store.Len())
+
+	ch := &whatChangedModel.Change{ChangeType: whatChangedModel.PropertyRemoved, Property: "limit", Path: "$.paths['/pets']", Breaking: true}
+	mark := NewMark(ch, "abc1234def")
+	mark.Status = NeedsDiscussion
+	mark.Note = "ask the mobile team"
+	store.Set("hash-1", mark)
+	store.Set("hash-2", NewMark(ch, "abc1234def")) // an empty mark is not kept
+	require.NoError(t, store.Save())
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, 1, loaded.Len())
+	got := loaded.Get("hash-1")
+	require.NotNil(t, got)
+	assert.Equal(t, NeedsDiscussion, got.Status)
+	assert.Equal(t, "removed", got.Change)
+	assert.Equal(t, "ask the mobile team", got.Note)
+	assert.False(t, got.Updated.IsZero())
+
+	got.Status, got.Note = "", ""
+	loaded.Set("hash-1", got)
+	assert.Nil(t, loaded.Get("hash-1"), "clearing the status and note removes the mark")
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "cannot parse review file")
+}
+
+func testStore() *Store {
+	store := NewStore("")
+	store.Set("h1", &Mark{Status: Approved, Change: "added", Property: "summary", Path: "$.paths['/pets']", Commit: "bbbbbbbbb"})
+	store.Set("h2", &Mark{Status: NeedsDiscussion, Change: "removed", Property: "limit", Path: "$.paths['/pets'].get",
+		Breaking: true, Commit: "aaaaaaaaa", Note: "who calls this?"})
+	store.Set("h3", &Mark{Status: Reviewed, Change: "modified", Property: "title", Path: "$.info"})
+	store.Set("h4", &Mark{Note: "typo", Change: "modified", Property: "description", Path: "$.info"})
+	return store
+}
+
+func TestStore_Markdown(t *testing.T) {
+	assert.Equal(t, `# API review
+
+## Needs discussion (1)
+
+- removed `+"`limit`"+` at `+"`$.paths['/pets'].get`"+` **(breaking)** in aaaaaaa: who calls this?
+
+## Approved (1)
+
+- added `+"`summary`"+` at `+"`$.paths['/pets']`"+` in bbbbbbb
+
+## Reviewed (1)
+
+- modified `+"`title`"+` at `+"`$.info`"+`
+
+## Notes (1)
+
+- modified `+"`description`"+` at `+"`$.info`"+`: typo
+`, testStore().Markdown())
+	assert.Contains(t, NewStore("").Markdown(), "No changes have been marked.")
+}
+
+func TestStore_Export(t *testing.T) {
+	_, _, err := testStore().Export()
+	assert.Error(t, err, "a store without a file has nowhere to export to")
+
+	store := testStore()
+	store.path = filepath.Join(t.TempDir(), "review.json")
+	markdownPath, baselinePath, err := store.Export()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(filepath.Dir(store.path), "review.md"), markdownPath)
+
+	markdown, err := os.ReadFile(markdownPath)
+	require.NoError(t, err)
+	assert.Equal(t, store.Markdown(), string(markdown))
+
+	data, err := os.ReadFile(baselinePath)
+	require.NoError(t, err)
+	var baseline Baseline
+	require.NoError(t, json.Unmarshal(data, &baseline))
+	require.Len(t, baseline.Changes, 1, "only approved changes are in the baseline")
+	assert.Equal(t, "h1", baseline.Changes[0].ChangeHash)
+	assert.Equal(t, "summary", baseline.Changes[0].Property)
+}
@@ -0,0 +1,177 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package severity
+
+import (
+	"strings"
+)
+
+const componentDocument = "document"
+
+// rootComponents are document properties that are components of their own in
+// the breaking rules config.
+var rootComponents = map[string]bool{
+	"openapi": true, "jsonSchemaDialect": true, "$self": true, "info": true, "paths": true,
+	"servers": true, "tags": true, "security": true, "components": true, "externalDocs": true,
+}
+
+var operationMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true,
+	"head": true, "patch": true, "trace": true, "query": true,
+}
+
+// transitions maps a component and a path segment to the component that
+// segment leads to. A value ending in "[]" is a container whose next segment
+// (a key or index) is the named component.
+var transitions = map[string]map[string]string{
+	componentDocument: {
+		"info": "info", "paths": "paths", "webhooks": "pathItem[]", "components": "components",
+		"servers": "server[]", "tags": "tag[]", "security": "securityRequirement[]", "externalDocs": "externalDocs",
+	},
+	"info": {"contact": "contact", "license": "license"},
+	"pathItem": {
+		"parameters": "parameter[]", "servers": "server[]", "additionalOperations": "operation[]",
+	},
+	"operation": {
+		"parameters": "parameter[]", "requestBody": "requestBody", "responses": "responses",
+		"callbacks": "callback[]", "security": "securityRequirement[]", "servers": "server[]",
+		"externalDocs": "externalDocs",
+	},
+	"parameter":   {"schema": "schema", "content": "mediaType[]", "examples": "example[]"},
+	"header":      {"schema": "schema", "content": "mediaType[]", "examples": "example[]"},
+	"requestBody": {"content": "mediaType[]"},
+	"response":    {"headers": "header[]", "content": "mediaType[]", "links": "link[]"},
+	"mediaType": {
+		"schema": "schema", "itemSchema": "schema", "examples": "example[]", "encoding": "encoding[]",
+	},
+	"encoding": {"headers": "header[]"},
+	"link":     {"server": "server"},
+	"server":   {"variables": "serverVariable[]"},
+	"tag":      {"externalDocs": "externalDocs"},
+	"schema": {
+		"properties": "schema[]", "patternProperties": "schema[]", "dependentSchemas": "schema[]", "$defs": "schema[]",
+		"allOf": "schema[]", "anyOf": "schema[]", "oneOf": "schema[]", "prefixItems": "schema[]",
+		"items": "schema", "additionalProperties": "schema", "not": "schema", "contains": "schema",
+		"if": "schema", "then": "schema", "else": "schema", "propertyNames": "schema",
+		"unevaluatedItems": "schema", "unevaluatedProperties": "schema", "contentSchema": "schema",
+		"discriminator": "discriminator", "xml": "xml", "externalDocs": "externalDocs",
+	},
+	"components": {
+		"schemas": "schema[]", "responses": "response[]", "parameters": "parameter[]", "examples": "example[]",
+		"requestBodies": "requestBody[]", "headers": "header[]", "securitySchemes": "securityScheme[]",
+		"links": "link[]", "callbacks": "callback[]", "pathItems": "pathItem[]",
+	},
+	"securityScheme": {"flows": "oauthFlows"},
+	"oauthFlows": {
+		"implicit": "oauthFlow", "password": "oauthFlow", "clientCredentials": "oauthFlow",
+		"authorizationCode": "oauthFlow", "device": "oauthFlow",
+	},
+}
+
+// Locate infers the breaking rules component and property of a change from
+// its JSONPath, e.g. "$.paths['/pets'].get.parameters['limit']" with property
+// "required" is the "required" property of a "parameter". Changes to the
+// entries of a container, such as a parameter removed from an operation, are
+// the container's property of its owner ("parameters" of an "operation").
+func Locate(path, property string) (string, string) {
+	component := componentDocument
+	container, containerName := "", ""
+	for _, segment := range SplitPath(path) {
+		if container != "" {
+			component, container = container, ""
+			continue
+		}
+		component, container = step(component, segment)
+		containerName = segment
+	}
+	switch {
+	case container != "":
+		return component, containerName
+	case component == componentDocument && rootComponents[property]:
+		return property, ""
+	case component == "responses" && property != "default":
+		return component, "codes"
+	}
+	return component, property
+}
+
+func step(component, segment string) (string, string) {
+	switch component {
+	case "paths", "callback":
+		return "pathItem", ""
+	case "responses":
+		return "response", ""
+	case "pathItem":
+		if operationMethods[segment] {
+			return "operation", ""
+		}
+	}
+	next, ok := transitions[component][segment]
+	if !ok {
+		return component, ""
+	}
+	if container, ok := strings.CutSuffix(next, "[]"); ok {
+		return component, container
+	}
+	return next, ""
+}
+
+// SplitPath splits a JSONPath into its segments, accepting both dotted and
+// bracketed (quoted or numeric) segments.
+func SplitPath(path string) []string {
+	s := strings.TrimPrefix(path, "$")
+	var segments []string
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			if end > 0 {
+				segments = append(segments, s[:end])
+			}
+			s = s[end:]
+		case '[':
+			segment, rest := splitBracket(s[1:])
+			segments = append(segments, segment)
+			s = rest
+		default:
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			segments = append(segments, s[:end])
+			s = s[end:]
+		}
+	}
+	return segments
+}
+
+// splitBracket reads a bracketed segment (after the opening bracket) and returns it with the remainder.
+func splitBracket(s string) (string, string) {
+	if len(s) > 0 && (s[0] == '\'' || s[0] == '"') {
+		quote := s[0]
+		var sb strings.Builder
+		for i := 1; i < len(s); i++ {
+			switch {
+			case s[i] == '\\' && i+1 < len(s):
+				i++
+				sb.WriteByte(s[i])
+			case s[i] == quote:
+				rest := s[i+1:]
+				return sb.String(), strings.TrimPrefix(rest, "]")
+			default:
+				sb.WriteByte(s[i])
+			}
+		}
+		return sb.String(), ""
+	}
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end+1:]
+}
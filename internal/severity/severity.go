@@ -0,0 +1,272 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+// Package severity assigns a severity (error, warning, info or ignore) to each
+// change. Severities are configured per component and property in the same
+// shape as the breaking rules config; changes without a configured severity are
+// errors when they are breaking and info otherwise.
+package severity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	Error   = "error"
+	Warning = "warning"
+	Info    = "info"
+	Ignore  = "ignore"
+
+	// None is only valid as a threshold; it is never reached by any change.
+	None = "none"
+)
+
+// Levels lists the assignable severities from most to least severe.
+var Levels = []string{Error, Warning, Info, Ignore}
+
+var ranks = map[string]int{Ignore: 0, Info: 1, Warning: 2, Error: 3, None: 4}
+
+// Valid reports whether s is a severity that can be assigned to a change.
+func Valid(s string) bool {
+	_, ok := ranks[s]
+	return ok && s != None
+}
+
+// ParseThreshold validates a --fail-on threshold. Ignore is not a threshold,
+// as ignored changes can never fail a command.
+func ParseThreshold(s string) (string, error) {
+	if _, ok := ranks[s]; !ok || s == Ignore {
+		return "", fmt.Errorf("unknown severity threshold '%s' (expected error, warning, info or none)", s)
+	}
+	return s, nil
+}
+
+// AtLeast reports whether severity s meets threshold. Ignored changes never meet a threshold.
+func AtLeast(s, threshold string) bool {
+	rank, ok := ranks[s]
+	if !ok || s == Ignore || s == None {
+		return false
+	}
+	return rank >= ranks[threshold]
+}
+
+// Rule assigns a severity to each kind of change.
+type Rule struct {
+	Added    string `json:"added,omitempty" yaml:"added,omitempty"`
+	Modified string `json:"modified,omitempty" yaml:"modified,omitempty"`
+	Removed  string `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+func (r *Rule) forChangeType(changeType int) string {
+	if r == nil {
+		return ""
+	}
+	switch changeType {
+	case wcModel.PropertyAdded, wcModel.ObjectAdded:
+		return r.Added
+	case wcModel.PropertyRemoved, wcModel.ObjectRemoved:
+		return r.Removed
+	case wcModel.Modified:
+		return r.Modified
+	}
+	return ""
+}
+
+func (r *Rule) empty() bool {
+	return r.Added == "" && r.Modified == "" && r.Removed == ""
+}
+
+// ComponentRules holds the severities of a single component. Default applies to
+// every property of the component without a rule of its own.
+type ComponentRules struct {
+	Default    *Rule
+	Properties map[string]*Rule
+}
+
+// UnmarshalYAML reads added/modified/removed keys as the component default and
+// every other key as a property rule, so that simple components such as
+// `openapi: {modified: error}` read the same as they do in the breaking rules.
+func (c *ComponentRules) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping of properties to severities", node.Line)
+	}
+	var defaults Rule
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "added":
+			defaults.Added = value.Value
+		case "modified":
+			defaults.Modified = value.Value
+		case "removed":
+			defaults.Removed = value.Value
+		default:
+			var rule Rule
+			if err := value.Decode(&rule); err != nil {
+				return fmt.Errorf("line %d: %s: %w", key.Line, key.Value, err)
+			}
+			if c.Properties == nil {
+				c.Properties = make(map[string]*Rule)
+			}
+			c.Properties[key.Value] = &rule
+		}
+	}
+	if !defaults.empty() {
+		c.Default = &defaults
+	}
+	return nil
+}
+
+// MarshalYAML writes the component back in the shape UnmarshalYAML reads.
+func (c ComponentRules) MarshalYAML() (any, error) {
+	out := make(map[string]any, len(c.Properties)+3)
+	if c.Default != nil {
+		for kind, value := range map[string]string{"added": c.Default.Added, "modified": c.Default.Modified, "removed": c.Default.Removed} {
+			if value != "" {
+				out[kind] = value
+			}
+		}
+	}
+	for property, rule := range c.Properties {
+		out[property] = rule
+	}
+	return out, nil
+}
+
+// merge returns r with every kind of change set in override replaced.
+func (r *Rule) merge(override *Rule) *Rule {
+	if override == nil {
+		return r
+	}
+	merged := Rule{}
+	if r != nil {
+		merged = *r
+	}
+	if override.Added != "" {
+		merged.Added = override.Added
+	}
+	if override.Modified != "" {
+		merged.Modified = override.Modified
+	}
+	if override.Removed != "" {
+		merged.Removed = override.Removed
+	}
+	return &merged
+}
+
+// Config maps component names (as used by the breaking rules config) to their severities.
+type Config map[string]*ComponentRules
+
+// Merge returns a new config with override layered on top of c: every kind of
+// change that override sets for a component or property replaces the one in c.
+func (c Config) Merge(override Config) Config {
+	if len(c) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(Config, len(c)+len(override))
+	for _, layer := range []Config{c, override} {
+		for component, rules := range layer {
+			if rules == nil {
+				continue
+			}
+			target := merged[component]
+			if target == nil {
+				target = &ComponentRules{}
+				merged[component] = target
+			}
+			target.Default = target.Default.merge(rules.Default)
+			for property, rule := range rules.Properties {
+				if target.Properties == nil {
+					target.Properties = make(map[string]*Rule)
+				}
+				target.Properties[property] = target.Properties[property].merge(rule)
+			}
+		}
+	}
+	return merged
+}
+
+// Validate reports every unknown severity in the config.
+func (c Config) Validate() error {
+	var problems []error
+	check := func(where string, rule *Rule) {
+		if rule == nil {
+			return
+		}
+		for kind, value := range map[string]string{"added": rule.Added, "modified": rule.Modified, "removed": rule.Removed} {
+			if value != "" && !Valid(value) {
+				problems = append(problems, fmt.Errorf("severities.%s.%s: unknown severity '%s' (expected error, warning, info or ignore)",
+					where, kind, value))
+			}
+		}
+	}
+	for _, component := range sortedKeys(c) {
+		rules := c[component]
+		if rules == nil {
+			continue
+		}
+		check(component, rules.Default)
+		for _, property := range sortedKeys(rules.Properties) {
+			check(component+"."+property, rules.Properties[property])
+		}
+	}
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Error() < problems[j].Error() })
+	return errors.Join(problems...)
+}
+
+// Of returns the severity of a change: the configured severity for its
+// component and property, then the component default, then error for
+// breaking changes and info for everything else.
+func (c Config) Of(change *wcModel.Change) string {
+	if change == nil {
+		return Info
+	}
+	component, property := Locate(change.Path, change.Property)
+	if rules := c[component]; rules != nil {
+		if s := rules.Properties[property].forChangeType(change.ChangeType); s != "" {
+			return s
+		}
+		if s := rules.Default.forChangeType(change.ChangeType); s != "" {
+			return s
+		}
+	}
+	if change.Breaking {
+		return Error
+	}
+	return Info
+}
+
+// Counts tallies the severities of a set of changes.
+func (c Config) Counts(changes []*wcModel.Change) map[string]int {
+	counts := make(map[string]int)
+	for _, change := range changes {
+		if change != nil {
+			counts[c.Of(change)]++
+		}
+	}
+	return counts
+}
+
+// Highest returns the most severe level present in counts, or Ignore when there is none.
+func Highest(counts map[string]int) string {
+	for _, level := range Levels {
+		if counts[level] > 0 {
+			return level
+		}
+	}
+	return Ignore
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
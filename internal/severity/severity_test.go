@@ -0,0 +1,142 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package severity
+
+import (
+	"testing"
+
+	wcModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		path, property      string
+		component, wantProp string
+	}{
+		{"$", "openapi", "openapi", ""},
+		{"$", "x-internal", "document", "x-internal"},
+		{"$.info", "title", "info", "title"},
+		{"$.info.contact", "email", "contact", "email"},
+		{"$.paths['/pets']", "get", "pathItem", "get"},
+		{"$.paths['/pets'].get", "operationId", "operation", "operationId"},
+		{"$.paths['/pets'].get.parameters", "limit", "operation", "parameters"},
+		{"$.paths['/pets'].get.parameters['limit']", "required", "parameter", "required"},
+		{"$.paths['/pets'].get.parameters[1].schema", "type", "schema", "type"},
+		{"$.paths['/pets'].get.responses", "404", "responses", "codes"},
+		{"$.paths['/pets'].get.responses.200", "description", "response", "description"},
+		{"$.paths['/pets'].get.responses['200'].content['application/json'].schema.properties['name']", "type", "schema", "type"},
+		{"$.paths['/pets'].get.responses['200'].content['application/json']", "example", "mediaType", "example"},
+		{"$.paths['/a.b'].post.requestBody", "required", "requestBody", "required"},
+		{"$.components.schemas", "Pet", "components", "schemas"},
+		{"$.components.schemas['Pet'].discriminator", "propertyName", "discriminator", "propertyName"},
+		{"$.components.securitySchemes['oauth'].flows.implicit", "authorizationUrl", "oauthFlow", "authorizationUrl"},
+		{"$.servers[0].variables['region']", "default", "serverVariable", "default"},
+		{"$.webhooks['newPet'].post", "summary", "operation", "summary"},
+	}
+	for _, tt := range tests {
+		component, property := Locate(tt.path, tt.property)
+		assert.Equal(t, tt.component, component, tt.path)
+		assert.Equal(t, tt.wantProp, property, tt.path)
+	}
+}
+
+func TestConfig_Of(t *testing.T) {
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(`
+openapi:
+  modified: warning
+schema:
+  modified: warning
+  description:
+    modified: ignore
+parameter:
+  required:
+    added: error
+`), &cfg))
+	require.NoError(t, cfg.Validate())
+
+	description := &wcModel.Change{Path: "$.components.schemas['Pet']", Property: "description", ChangeType: wcModel.Modified}
+	schemaType := &wcModel.Change{Path: "$.components.schemas['Pet']", Property: "type", ChangeType: wcModel.Modified, Breaking: true}
+	required := &wcModel.Change{Path: "$.paths['/pets'].get.parameters['limit']", Property: "required", ChangeType: wcModel.PropertyAdded}
+	version := &wcModel.Change{Path: "$", Property: "openapi", ChangeType: wcModel.Modified}
+	removed := &wcModel.Change{Path: "$.paths['/pets']", Property: "get", ChangeType: wcModel.PropertyRemoved, Breaking: true}
+	title := &wcModel.Change{Path: "$.info", Property: "title", ChangeType: wcModel.Modified}
+
+	assert.Equal(t, Ignore, cfg.Of(description))
+	assert.Equal(t, Warning, cfg.Of(schemaType))
+	assert.Equal(t, Error, cfg.Of(required))
+	assert.Equal(t, Warning, cfg.Of(version))
+	assert.Equal(t, Error, cfg.Of(removed))
+	assert.Equal(t, Info, cfg.Of(title))
+
+	var none Config
+	assert.Equal(t, Error, none.Of(schemaType))
+	assert.Equal(t, Info, none.Of(description))
+
+	counts := cfg.Counts([]*wcModel.Change{description, schemaType, required, removed, title})
+	assert.Equal(t, map[string]int{Ignore: 1, Warning: 1, Error: 2, Info: 1}, counts)
+	assert.Equal(t, Error, Highest(counts))
+	assert.Equal(t, Ignore, Highest(map[string]int{Ignore: 3}))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(`
+schema:
+  removed: fatal
+  type:
+    modified: critical
+`), &cfg))
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "severities.schema.removed: unknown severity 'fatal'")
+	assert.ErrorContains(t, err, "severities.schema.type.modified: unknown severity 'critical'")
+}
+
+func TestConfig_RoundTrip(t *testing.T) {
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte("schema:\n  modified: warning\n  description:\n    modified: ignore\n"), &cfg))
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	var again Config
+	require.NoError(t, yaml.Unmarshal(out, &again))
+	assert.Equal(t, cfg, again)
+}
+
+func TestConfig_Merge(t *testing.T) {
+	var base, override Config
+	require.NoError(t, yaml.Unmarshal([]byte("schema:\n  modified: warning\n  removed: error\n  type:\n    modified: error\noperation:\n  added: info\n"), &base))
+	require.NoError(t, yaml.Unmarshal([]byte("schema:\n  modified: info\n  type:\n    added: warning\nparameter:\n  removed: ignore\n"), &override))
+
+	merged := base.Merge(override)
+	assert.Equal(t, &Rule{Modified: Info, Removed: Error}, merged["schema"].Default)
+	assert.Equal(t, &Rule{Added: Warning, Modified: Error}, merged["schema"].Properties["type"])
+	assert.Equal(t, &Rule{Added: Info}, merged["operation"].Default)
+	assert.Equal(t, &Rule{Removed: Ignore}, merged["parameter"].Default)
+	assert.Equal(t, Warning, base["schema"].Default.Modified, "merging leaves the base untouched")
+
+	assert.Nil(t, Config(nil).Merge(nil))
+}
+
+func TestThresholds(t *testing.T) {
+	for _, threshold := range []string{Error, Warning, Info, None} {
+		parsed, err := ParseThreshold(threshold)
+		require.NoError(t, err)
+		assert.Equal(t, threshold, parsed)
+	}
+	_, err := ParseThreshold(Ignore)
+	assert.Error(t, err)
+	_, err = ParseThreshold("fatal")
+	assert.Error(t, err)
+
+	assert.True(t, AtLeast(Error, Warning))
+	assert.True(t, AtLeast(Warning, Warning))
+	assert.False(t, AtLeast(Info, Warning))
+	assert.False(t, AtLeast(Ignore, Info))
+	assert.False(t, AtLeast(Error, None))
+}
@@ -0,0 +1,194 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+// Package suppression reads x-openapi-changes extensions from a specification and
+// downgrades the breaking changes beneath the objects that declare them.
+package suppression
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/severity"
+	"github.com/pb33f/openapi-changes/model"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	// Extension is the specification extension that declares a suppression.
+	Extension = "x-openapi-changes"
+
+	// IgnoreBreaking downgrades breaking changes to non-breaking ones.
+	IgnoreBreaking = "breaking"
+)
+
+var plainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// Directive is an x-openapi-changes extension found in a specification.
+type Directive struct {
+	Location string
+	Line     int
+	Ignore   []string
+	Reason   string
+	segments []string
+}
+
+// Ignores reports whether the directive ignores the given kind of change.
+func (d *Directive) Ignores(kind string) bool {
+	return slices.Contains(d.Ignore, kind)
+}
+
+// Scan finds every x-openapi-changes extension in a YAML or JSON specification.
+// Directives that cannot be read are reported in the error and left out; the
+// others are still returned.
+func Scan(data []byte) ([]*Directive, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return ScanNode(&root)
+}
+
+// ScanNode is Scan for a specification that has already been parsed.
+func ScanNode(root *yaml.Node) ([]*Directive, error) {
+	if root == nil {
+		return nil, nil
+	}
+	var directives []*Directive
+	var errs []error
+	var walk func(node *yaml.Node, segments []string)
+	walk = func(node *yaml.Node, segments []string) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, segments)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value != Extension {
+					walk(value, append(slices.Clip(segments), key.Value))
+					continue
+				}
+				directive, err := decode(value, segments)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s at line %d: %w", Extension, key.Line, err))
+					continue
+				}
+				directive.Line = key.Line
+				directives = append(directives, directive)
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				walk(child, append(slices.Clip(segments), strconv.Itoa(i)))
+			}
+		}
+	}
+	walk(root, nil)
+	return directives, errors.Join(errs...)
+}
+
+func decode(node *yaml.Node, segments []string) (*Directive, error) {
+	var body struct {
+		Ignore []string `yaml:"ignore"`
+		Reason string   `yaml:"reason"`
+	}
+	if err := node.Decode(&body); err != nil {
+		return nil, err
+	}
+	for _, ignore := range body.Ignore {
+		if ignore != IgnoreBreaking {
+			return nil, fmt.Errorf("unknown ignore value '%s' (expected %s)", ignore, IgnoreBreaking)
+		}
+	}
+	return &Directive{
+		Location: location(segments),
+		Ignore:   body.Ignore,
+		Reason:   body.Reason,
+		segments: segments,
+	}, nil
+}
+
+// location renders segments as a JSONPath, bracketing keys that are not plain identifiers.
+func location(segments []string) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, segment := range segments {
+		switch {
+		case plainKey.MatchString(segment):
+			sb.WriteString("." + segment)
+		case isIndex(segment):
+			sb.WriteString("[" + segment + "]")
+		default:
+			escaped := strings.ReplaceAll(strings.ReplaceAll(segment, `\`, `\\`), "'", `\'`)
+			sb.WriteString("['" + escaped + "']")
+		}
+	}
+	return sb.String()
+}
+
+func isIndex(segment string) bool {
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+// Apply downgrades every breaking change beneath a directive that ignores breaking
+// changes and returns a suppression for each one. The directive closest to a change
+// decides, so a nested `ignore: []` opts back out of a suppression declared above it.
+func Apply(directives []*Directive, changes []*whatChangedModel.Change) []*model.Suppression {
+	if len(directives) == 0 {
+		return nil
+	}
+	var suppressions []*model.Suppression
+	seen := make(map[*whatChangedModel.Change]struct{})
+	for _, change := range changes {
+		if change == nil || !change.Breaking {
+			continue
+		}
+		if _, ok := seen[change]; ok {
+			continue
+		}
+		seen[change] = struct{}{}
+		directive := closest(directives, changeSegments(change))
+		if directive == nil || !directive.Ignores(IgnoreBreaking) {
+			continue
+		}
+		change.Breaking = false
+		suppressions = append(suppressions, &model.Suppression{
+			Reason:   directive.Reason,
+			Location: directive.Location,
+			Line:     directive.Line,
+			Path:     change.Path,
+			Property: change.Property,
+			Change:   change,
+		})
+	}
+	return suppressions
+}
+
+func changeSegments(change *whatChangedModel.Change) []string {
+	segments := severity.SplitPath(change.Path)
+	if change.Property != "" {
+		segments = append(segments, change.Property)
+	}
+	return segments
+}
+
+// closest returns the deepest directive whose object contains the given location.
+func closest(directives []*Directive, segments []string) *Directive {
+	var found *Directive
+	for _, directive := range directives {
+		if len(directive.segments) > len(segments) || !slices.Equal(directive.segments, segments[:len(directive.segments)]) {
+			continue
+		}
+		if found == nil || len(directive.segments) > len(found.segments) {
+			found = directive
+		}
+	}
+	return found
+}
@@ -0,0 +1,93 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package suppression
+
+import (
+	"testing"
+
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const suppressedSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      x-openapi-changes:
+        ignore: [breaking]
+        reason: Nobody calls this yet, see API-123
+      parameters:
+        - name: limit
+          in: query
+          x-openapi-changes:
+            ignore: []
+components:
+  schemas:
+    Pet:
+      x-openapi-changes: {ignore: [breaking]}
+      type: object
+`
+
+func TestScan(t *testing.T) {
+	directives, err := Scan([]byte(suppressedSpec))
+	require.NoError(t, err)
+	require.Len(t, directives, 3)
+
+	assert.Equal(t, "$.paths['/pets'].get", directives[0].Location)
+	assert.Equal(t, 5, directives[0].Line)
+	assert.Equal(t, "Nobody calls this yet, see API-123", directives[0].Reason)
+	assert.True(t, directives[0].Ignores(IgnoreBreaking))
+
+	assert.Equal(t, "$.paths['/pets'].get.parameters[0]", directives[1].Location)
+	assert.False(t, directives[1].Ignores(IgnoreBreaking))
+
+	assert.Equal(t, "$.components.schemas.Pet", directives[2].Location)
+	assert.Empty(t, directives[2].Reason)
+}
+
+func TestScan_JSON(t *testing.T) {
+	directives, err := Scan([]byte(`{"paths": {"/a'b": {"x-openapi-changes": {"ignore": ["breaking"], "reason": "r"}}}}`))
+	require.NoError(t, err)
+	require.Len(t, directives, 1)
+	assert.Equal(t, `$.paths['/a\'b']`, directives[0].Location)
+}
+
+func TestScan_Errors(t *testing.T) {
+	_, err := Scan([]byte("paths:\n  /pets:\n    x-openapi-changes:\n      ignore: [everything]\n"))
+	assert.EqualError(t, err, "x-openapi-changes at line 3: unknown ignore value 'everything' (expected breaking)")
+
+	_, err = Scan([]byte("info:\n  x-openapi-changes: nope\n"))
+	assert.ErrorContains(t, err, "x-openapi-changes at line 2:")
+}
+
+func TestApply(t *testing.T) {
+	directives, err := Scan([]byte(suppressedSpec))
+	require.NoError(t, err)
+
+	operation := &whatChangedModel.Change{Path: "$.paths['/pets'].get", Property: "operationId", Breaking: true}
+	response := &whatChangedModel.Change{Path: "$.paths['/pets'].get.responses", Property: "200", Breaking: true}
+	parameter := &whatChangedModel.Change{Path: "$.paths['/pets'].get.parameters[0]", Property: "required", Breaking: true}
+	schema := &whatChangedModel.Change{Path: "$.components.schemas['Pet']", Property: "type", Breaking: true}
+	other := &whatChangedModel.Change{Path: "$.paths['/petsy'].get", Property: "operationId", Breaking: true}
+	additive := &whatChangedModel.Change{Path: "$.paths['/pets'].get", Property: "summary"}
+
+	suppressions := Apply(directives, []*whatChangedModel.Change{operation, response, parameter, schema, other, additive, operation})
+	require.Len(t, suppressions, 3)
+
+	assert.False(t, operation.Breaking)
+	assert.False(t, response.Breaking)
+	assert.False(t, schema.Breaking)
+	assert.True(t, parameter.Breaking, "the nested directive opts back out")
+	assert.True(t, other.Breaking)
+
+	assert.Same(t, operation, suppressions[0].Change)
+	assert.Equal(t, "Nobody calls this yet, see API-123", suppressions[0].Reason)
+	assert.Equal(t, "$.paths['/pets'].get", suppressions[0].Location)
+	assert.Equal(t, 5, suppressions[0].Line)
+	assert.Equal(t, "operationId", suppressions[0].Property)
+	assert.Equal(t, "$.components.schemas.Pet", suppressions[2].Location)
+
+	assert.Nil(t, Apply(nil, []*whatChangedModel.Change{other}))
+}
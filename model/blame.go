@@ -0,0 +1,47 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "time"
+
+// BlameEntry is a single change to an operation, attributed to the commit
+// that introduced it. Component is set when the change was made to a component
+// the operation references, such as "schemas/Order".
+type BlameEntry struct {
+	Hash        string        `json:"commitHash"`
+	Message     string        `json:"message"`
+	Author      string        `json:"author"`
+	AuthorEmail string        `json:"authorEmail"`
+	CommitDate  time.Time     `json:"committed"`
+	Component   string        `json:"component,omitempty"`
+	Change      *HashedChange `json:"change"`
+}
+
+// OperationBlame holds the history of changes that touched a single operation.
+// Method is empty for changes made to the path item itself (for example when the
+// whole path was added or removed).
+type OperationBlame struct {
+	Path    string        `json:"path"`
+	Method  string        `json:"method,omitempty"`
+	Changes []*BlameEntry `json:"changes"`
+}
+
+// ComponentBlame holds the history of changes to a component that no operation
+// references.
+type ComponentBlame struct {
+	Component string        `json:"component"`
+	Changes   []*BlameEntry `json:"changes"`
+}
+
+// BlameReport groups the changes from a git history by operation instead of by commit.
+// Changes to components are listed under the operations that reference them, or
+// under Components when no operation does.
+type BlameReport struct {
+	GitRepoPath   string                    `json:"gitRepoPath"`
+	GitFilePath   string                    `json:"gitFilePath"`
+	DateGenerated string                    `json:"dateGenerated,omitempty"`
+	MetaData      *HistoricalReportMetaData `json:"metaData,omitempty"`
+	Operations    []*OperationBlame         `json:"operations"`
+	Components    []*ComponentBlame         `json:"components,omitempty"`
+}
@@ -32,4 +32,15 @@ type Commit struct {
 	ModifiedSource    string                 `gorm:"-" json:"-"`
 	Synthetic         bool                   `gorm:"-" json:"-"`
 	DocumentRewriters []DocumentPathRewriter `gorm:"-" json:"-"`
+	Squashed          []*SquashedCommit      `gorm:"-" json:"squashedCommits,omitempty"`
+}
+
+// SquashedCommit records a single revision that contributed to a squashed
+// (cumulative) comparison.
+type SquashedCommit struct {
+	Hash        string    `json:"commitHash"`
+	Message     string    `json:"message"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"authorEmail"`
+	CommitDate  time.Time `json:"committed"`
 }
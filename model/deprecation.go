@@ -0,0 +1,40 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "time"
+
+// DeprecationLifecycle follows a single operation, parameter, schema or schema
+// property from the revision it was deprecated in to the revision it was removed in.
+// DeprecatedDate is nil and DeprecatedBeforeHistory is set when the deprecation
+// happened before the history that was inspected; DaysDeprecated and
+// ReleasesDeprecated then only count what was seen inside it. RemovedCommit is
+// empty while the subject still exists.
+type DeprecationLifecycle struct {
+	Kind                    string     `json:"kind"`
+	Path                    string     `json:"path"`
+	Label                   string     `json:"label"`
+	DeprecatedCommit        string     `json:"deprecatedCommit,omitempty"`
+	DeprecatedDate          *time.Time `json:"deprecatedDate,omitempty"`
+	DeprecatedVersion       string     `json:"deprecatedVersion,omitempty"`
+	DeprecatedBeforeHistory bool       `json:"deprecatedBeforeHistory,omitempty"`
+	Sunset                  string     `json:"sunset,omitempty"`
+	RemovedCommit           string     `json:"removedCommit,omitempty"`
+	RemovedDate             *time.Time `json:"removedDate,omitempty"`
+	DaysDeprecated          int        `json:"daysDeprecated,omitempty"`
+	ReleasesDeprecated      int        `json:"releasesDeprecated,omitempty"`
+	Violation               string     `json:"violation,omitempty"`
+}
+
+// DeprecationReport is the result of checking a git history against a
+// deprecation policy.
+type DeprecationReport struct {
+	GitRepoPath   string                    `json:"gitRepoPath"`
+	GitFilePath   string                    `json:"gitFilePath"`
+	DateGenerated string                    `json:"dateGenerated,omitempty"`
+	MinDays       int                       `json:"minDays"`
+	MinReleases   int                       `json:"minReleases"`
+	MetaData      *HistoricalReportMetaData `json:"metaData,omitempty"`
+	Lifecycles    []*DeprecationLifecycle   `json:"lifecycles"`
+}
@@ -0,0 +1,34 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+// MergeConflict describes a single JSONPath + property that was changed on both
+// sides of a three-way comparison, where each side made a different change. It
+// lists every change each side made there that the other side did not.
+type MergeConflict struct {
+	Path     string          `json:"path"`
+	Property string          `json:"property,omitempty"`
+	Ours     []*HashedChange `json:"ours"`
+	Theirs   []*HashedChange `json:"theirs"`
+}
+
+// MergeBreakingChange is a breaking change from a three-way comparison, along
+// with the side(s) that introduced it ("ours", "theirs" or both).
+type MergeBreakingChange struct {
+	Sides  []string      `json:"sides"`
+	Change *HashedChange `json:"change"`
+}
+
+// MergeCheckReport is the result of comparing two revisions (ours and theirs)
+// against a shared base revision.
+type MergeCheckReport struct {
+	BasePath      string                 `json:"basePath"`
+	OursPath      string                 `json:"oursPath"`
+	TheirsPath    string                 `json:"theirsPath"`
+	DateGenerated string                 `json:"dateGenerated,omitempty"`
+	Ours          *FlatReport            `json:"ours,omitempty"`
+	Theirs        *FlatReport            `json:"theirs,omitempty"`
+	Conflicts     []*MergeConflict       `json:"conflicts"`
+	Breaking      []*MergeBreakingChange `json:"breaking"`
+}
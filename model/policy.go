@@ -0,0 +1,16 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+// PolicyFinding is the result of a policy rule matching a change, or a whole
+// commit for commit-scoped rules (in which case ChangeHash, Path and Property are empty).
+type PolicyFinding struct {
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	Message    string `json:"message"`
+	Commit     string `json:"commit,omitempty"`
+	ChangeHash string `json:"changeHash,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Property   string `json:"property,omitempty"`
+}
@@ -13,32 +13,96 @@ import (
 	"time"
 )
 
+const (
+	// ReportVersion1 is the layout of report JSON before it was versioned: no
+	// reportVersion field, and no severities, policy findings or suppressions.
+	ReportVersion1 = 1
+
+	// ReportVersion2 adds reportVersion, severities, policyFindings and
+	// suppressions to reports, and severity to changes.
+	ReportVersion2 = 2
+
+	// CurrentReportVersion is the layout written unless an older one is requested.
+	CurrentReportVersion = ReportVersion2
+)
+
+// ReportVersions lists every report layout that can be written, oldest first.
+var ReportVersions = []int{ReportVersion1, ReportVersion2}
+
 type HashedChange struct {
 	*model.Change
 	ChangeHash string `json:"changeHash,omitempty"`
 	RawPath    string `json:"rawPath,omitempty"`
+	Severity   string `json:"severity,omitempty"`
 }
 
-func (hc *HashedChange) MarshalJSON() ([]byte, error) {
-	changeJson, err := hc.Change.MarshalJSON()
+// hashedChangeJSON is the frozen field set of a change in report JSON. Fields are
+// in alphabetical order, which is the order they have always been written in.
+type hashedChangeJSON struct {
+	Breaking        bool               `json:"breaking"`
+	Change          int                `json:"change"`
+	ChangeHash      string             `json:"changeHash"`
+	ChangeText      string             `json:"changeText"`
+	Context         *changeContextJSON `json:"context,omitempty"`
+	New             string             `json:"new,omitempty"`
+	NewEncoded      string             `json:"newEncoded,omitempty"`
+	Original        string             `json:"original,omitempty"`
+	OriginalEncoded string             `json:"originalEncoded,omitempty"`
+	Path            string             `json:"path,omitempty"`
+	Property        string             `json:"property"`
+	RawPath         string             `json:"rawPath,omitempty"`
+	Severity        string             `json:"severity,omitempty"`
+	Type            string             `json:"type,omitempty"`
+}
 
-	if err != nil {
-		return nil, err
-	}
+type changeContextJSON struct {
+	Document       string `json:"document,omitempty"`
+	NewColumn      *int   `json:"newColumn,omitempty"`
+	NewLine        *int   `json:"newLine,omitempty"`
+	OriginalColumn *int   `json:"originalColumn,omitempty"`
+	OriginalLine   *int   `json:"originalLine,omitempty"`
+}
 
-	var data map[string]interface{}
+// changeTexts are the names libopenapi gives each change type in JSON.
+var changeTexts = map[int]string{
+	model.Modified:        "modified",
+	model.PropertyAdded:   "property_added",
+	model.ObjectAdded:     "object_added",
+	model.ObjectRemoved:   "object_removed",
+	model.PropertyRemoved: "property_removed",
+}
 
-	err = json.Unmarshal(changeJson, &data)
-	if err != nil {
-		return nil, err
-	}
+// ChangeText returns the change type as text, as it is written in report JSON.
+func (hc *HashedChange) ChangeText() string {
+	return changeTexts[hc.ChangeType]
+}
 
-	data["changeHash"] = hc.ChangeHash
-	if hc.RawPath != "" {
-		data["rawPath"] = hc.RawPath
+func (hc *HashedChange) MarshalJSON() ([]byte, error) {
+	out := hashedChangeJSON{
+		Breaking:        hc.Breaking,
+		Change:          hc.ChangeType,
+		ChangeHash:      hc.ChangeHash,
+		ChangeText:      hc.ChangeText(),
+		New:             hc.New,
+		NewEncoded:      hc.NewEncoded,
+		Original:        hc.Original,
+		OriginalEncoded: hc.OriginalEncoded,
+		Path:            hc.Path,
+		Property:        hc.Property,
+		RawPath:         hc.RawPath,
+		Severity:        hc.Severity,
+		Type:            hc.Type,
 	}
-
-	return json.Marshal(data)
+	if context := hc.Context; context != nil {
+		out.Context = &changeContextJSON{
+			Document:       context.DocumentLocation,
+			NewColumn:      context.NewColumn,
+			NewLine:        context.NewLine,
+			OriginalColumn: context.OriginalColumn,
+			OriginalLine:   context.OriginalLine,
+		}
+	}
+	return json.Marshal(out)
 }
 
 func getIntValue(pointer *int) int {
@@ -72,15 +136,21 @@ type Report struct {
 	CreatedAt time.Time                   `json:"-"`
 	UpdatedAt time.Time                   `json:"-"`
 	Commit    *Commit                     `gorm:"foreignKey:ID" json:"commitDetails,omitempty"`
+	// Suppressions are the breaking changes of the commit that its spec suppressed.
+	Suppressions []*Suppression `gorm:"-" json:"-"`
 }
 
 type FlatReport struct {
-	Summary       map[string]*reports.Changed `json:"reportSummary"`
-	Changes       []*HashedChange             `json:"changes"`
-	OriginalPath  string                      `json:"originalPath,omitempty"`
-	ModifiedPath  string                      `json:"modifiedPath,omitempty"`
-	DateGenerated string                      `json:"dateGenerated,omitempty"`
-	Commit        *Commit                     `gorm:"foreignKey:ID" json:"commitDetails,omitempty"`
+	ReportVersion  int                         `json:"reportVersion,omitempty"`
+	Summary        map[string]*reports.Changed `json:"reportSummary"`
+	Changes        []*HashedChange             `json:"changes"`
+	OriginalPath   string                      `json:"originalPath,omitempty"`
+	ModifiedPath   string                      `json:"modifiedPath,omitempty"`
+	DateGenerated  string                      `json:"dateGenerated,omitempty"`
+	Commit         *Commit                     `gorm:"foreignKey:ID" json:"commitDetails,omitempty"`
+	Severities     map[string]int              `json:"severities,omitempty"`
+	PolicyFindings []*PolicyFinding            `json:"policyFindings,omitempty"`
+	Suppressions   []*Suppression              `json:"suppressions,omitempty"`
 }
 
 type HistoricalReportMetaData struct {
@@ -89,6 +159,7 @@ type HistoricalReportMetaData struct {
 }
 
 type FlatHistoricalReport struct {
+	ReportVersion int                       `json:"reportVersion,omitempty"`
 	GitRepoPath   string                    `json:"gitRepoPath"`
 	GitFilePath   string                    `json:"gitFilePath"`
 	Filename      string                    `json:"filename"`
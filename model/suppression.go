@@ -0,0 +1,20 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// Suppression records a breaking change that was downgraded by an x-openapi-changes
+// extension in the modified document, along with the reason the extension gives.
+type Suppression struct {
+	Reason     string        `json:"reason,omitempty"`
+	Location   string        `json:"location"`
+	Line       int           `json:"line,omitempty"`
+	ChangeHash string        `json:"changeHash,omitempty"`
+	Path       string        `json:"path,omitempty"`
+	Property   string        `json:"property,omitempty"`
+	Change     *model.Change `json:"-"`
+}
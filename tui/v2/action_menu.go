@@ -0,0 +1,295 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+)
+
+// JSONReportFn builds the JSON report of a commit, as the report command writes
+// it, or nil when the commit has no changes. Like RunChangeratorFn, it is
+// injected from the cmd package, which owns report flattening, the rules that
+// are applied to reports and their layout.
+type JSONReportFn func(commit *model.Commit, breakingConfig *whatChangedModel.BreakingRulesConfig) ([]byte, error)
+
+// consoleAction is an entry of the action menu. Copy actions put text on the
+// clipboard; export actions prompt for a file to write with this extension.
+type consoleAction struct {
+	key    string
+	label  string
+	export string
+}
+
+var consoleActions = []consoleAction{
+	{key: "p", label: "Copy JSONPath"},
+	{key: "o", label: "Copy old value"},
+	{key: "n", label: "Copy new value"},
+	{key: "d", label: "Copy diff"},
+	{key: "m", label: "Export markdown report…", export: ".md"},
+	{key: "j", label: "Export JSON report…", export: ".json"},
+}
+
+// actionMenuWidth is the width of the action menu overlay.
+const actionMenuWidth = 46
+
+// actionMenu is the overlay listing the copy and export actions. While an
+// export is prompting, the file path is typed into path, and overwriting is
+// set while asking whether to replace a file that is already there.
+type actionMenu struct {
+	cursor      int
+	prompting   bool
+	overwriting bool
+	path        textinput.Model
+}
+
+// actionDoneMsg reports the outcome of an action that ran in the background.
+type actionDoneMsg struct {
+	notice string
+}
+
+// openActionMenu opens the action menu.
+func (m *ConsoleModel) openActionMenu() {
+	m.actionMenu = actionMenu{}
+	m.showActionMenu = true
+}
+
+// handleActionMenuKeys handles key events while the action menu is showing.
+// An action runs from its key, or from enter on the entry under the cursor.
+func (m ConsoleModel) handleActionMenuKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.actionMenu.prompting {
+		return m.handleExportPromptKeys(msg)
+	}
+	if msg.String() == "ctrl+c" {
+		return m.handleQuit()
+	}
+	// the letter keys of the actions come first, as they may also be bound elsewhere
+	for i, action := range consoleActions {
+		if msg.String() == action.key {
+			m.actionMenu.cursor = i
+			return m, m.runAction(action)
+		}
+	}
+	switch m.keys.action(msg) {
+	case actBack, actQuit:
+		m.showActionMenu = false
+	case actUp:
+		m.actionMenu.cursor = max(m.actionMenu.cursor-1, 0)
+	case actDown:
+		m.actionMenu.cursor = min(m.actionMenu.cursor+1, len(consoleActions)-1)
+	case actSelect:
+		return m, m.runAction(consoleActions[m.actionMenu.cursor])
+	}
+	return m, nil
+}
+
+// handleExportPromptKeys handles key events while the file path of an export
+// is being typed. Enter writes the file, asking first when it is already there,
+// and esc goes back to the menu.
+func (m ConsoleModel) handleExportPromptKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.actionMenu.overwriting {
+		return m.handleOverwriteKeys(msg)
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return m.handleQuit()
+	case "enter":
+		path := strings.TrimSpace(m.actionMenu.path.Value())
+		if path == "" {
+			return m, nil
+		}
+		if _, err := os.Stat(path); err == nil {
+			m.actionMenu.overwriting = true
+			return m, nil
+		}
+		m.showActionMenu = false
+		return m, m.export(consoleActions[m.actionMenu.cursor], path)
+	case "esc":
+		m.actionMenu.prompting = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.actionMenu.path, cmd = m.actionMenu.path.Update(msg)
+	return m, cmd
+}
+
+// handleOverwriteKeys handles the answer to whether an export replaces the file
+// that is already at its path. y writes over it, and any other key goes back
+// to the path.
+func (m ConsoleModel) handleOverwriteKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	m.actionMenu.overwriting = false
+	switch msg.String() {
+	case "ctrl+c":
+		return m.handleQuit()
+	case "y":
+		m.showActionMenu = false
+		return m, m.export(consoleActions[m.actionMenu.cursor], strings.TrimSpace(m.actionMenu.path.Value()))
+	}
+	return m, nil
+}
+
+// newExportPathInput returns the input the file path of an export is typed
+// into, holding a suggested path.
+func newExportPathInput(styles consoleStyles, path string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = "File: "
+	inputStyles := textinput.DefaultDarkStyles()
+	inputStyles.Focused.Prompt = styles.helpKey
+	inputStyles.Focused.Text = styles.helpKey
+	inputStyles.Cursor.Color = styles.helpKey.GetForeground()
+	inputStyles.Cursor.Blink = false
+	input.SetStyles(inputStyles)
+	input.SetWidth(actionMenuWidth - 4 - len(input.Prompt) - 2) // padding and border(4), the prompt and the cursor
+	input.SetValue(path)
+	input.Focus()
+	return input
+}
+
+// runAction copies to the clipboard, or starts prompting for the file to export to.
+func (m *ConsoleModel) runAction(action consoleAction) tea.Cmd {
+	if action.export != "" {
+		m.actionMenu.prompting = true
+		name := shortHash(m.activeHash)
+		if m.comparison != nil {
+			name = strings.ReplaceAll(m.comparison.Hash, "..", "-")
+		}
+		m.actionMenu.path = newExportPathInput(m.styles, name+"-report"+action.export)
+		return nil
+	}
+
+	m.showActionMenu = false
+	ch := m.activeChange
+	if ch == nil {
+		m.notice = "no change selected to copy"
+		return nil
+	}
+	var text, what string
+	switch action.key {
+	case "p":
+		text, what = ch.Path, "JSONPath"
+	case "o":
+		text, what = resolveOldValue(ch), "old value"
+	case "n":
+		text, what = resolveNewValue(ch), "new value"
+	case "d":
+		text, what = m.plainDiff(), "diff"
+	}
+	if text == "" {
+		m.notice = "the change has no " + what + " to copy"
+		return nil
+	}
+	m.notice = "copied " + what + " to the clipboard"
+	return tea.SetClipboard(text)
+}
+
+// plainDiff returns the diff panel as plain text, without styling or padding.
+func (m ConsoleModel) plainDiff() string {
+	content := m.diffViewport.GetContent()
+	if m.diffSummary != "" {
+		content = m.diffSummary + "\n" + content
+	}
+	lines := strings.Split(stripANSI(content), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// export writes the active commit's markdown or JSON report to a file. The
+// markdown report is cached; the JSON report runs the changerator again, in the
+// background and in turn with the commit loads, and is laid out as the report
+// command writes it.
+func (m *ConsoleModel) export(action consoleAction, path string) tea.Cmd {
+	if action.export == ".md" {
+		markdown := m.reportMarkdown()
+		if markdown == "" {
+			m.notice = "no markdown report for this commit"
+			return nil
+		}
+		if err := os.WriteFile(path, []byte(markdown), 0644); err != nil {
+			m.notice = fmt.Sprintf("Error: failed to write report: %s", err)
+			return nil
+		}
+		m.notice = fmt.Sprintf("markdown report written to '%s'", path)
+		return nil
+	}
+
+	commit := m.activeCommit()
+	if m.jsonReportFn == nil || commit == nil {
+		m.notice = "JSON reports are not available"
+		return nil
+	}
+	loader, jsonReportFn, breakingCfg := m.loader, m.jsonReportFn, m.breakingCfg
+	return func() tea.Msg {
+		loader.run.Lock()
+		data, err := jsonReportFn(commit, breakingCfg)
+		loader.run.Unlock()
+		if err != nil {
+			return actionDoneMsg{notice: "Error: " + err.Error()}
+		}
+		if data == nil {
+			return actionDoneMsg{notice: "no changes to export"}
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			return actionDoneMsg{notice: fmt.Sprintf("Error: failed to write report: %s", err)}
+		}
+		return actionDoneMsg{notice: fmt.Sprintf("JSON report written to '%s'", path)}
+	}
+}
+
+// height returns the height of the action menu overlay.
+func (a actionMenu) height() int {
+	h := len(consoleActions) + 6 // border(2) + title and separators(3) + nav(1)
+	if a.prompting {
+		h++
+	}
+	return h
+}
+
+// View renders the action menu inside a bordered panel.
+func (a actionMenu) View(styles consoleStyles, keys KeyMap) string {
+	width := actionMenuWidth
+	var sb strings.Builder
+	sb.WriteString(styles.info.Render(" Actions"))
+	sb.WriteByte('\n')
+	sb.WriteString(styles.renderSeparator(width - 4))
+	sb.WriteByte('\n')
+	for i, action := range consoleActions {
+		if i == a.cursor {
+			line := fmt.Sprintf("[%s] %s", strings.ToUpper(action.key), action.label)
+			sb.WriteString(styles.selectedRow.Render(padToWidth(line, width-4)))
+		} else {
+			sb.WriteString(styles.renderHotkey(action.key) + " " + action.label)
+		}
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(styles.renderSeparator(width - 4))
+	sb.WriteByte('\n')
+	switch {
+	case a.overwriting:
+		name := filepath.Base(strings.TrimSpace(a.path.Value()))
+		sb.WriteString(styles.helpKey.Render(truncateToWidth(" Replace '"+name+"'?", width-4)))
+		sb.WriteByte('\n')
+		sb.WriteString(" " + styles.renderHotkey("y") + styles.renderLabel("overwrite") +
+			"  " + styles.renderHotkey("n") + styles.renderLabel("back"))
+	case a.prompting:
+		sb.WriteString(" " + a.path.View())
+		sb.WriteByte('\n')
+		sb.WriteString(" " + styles.renderHotkey("enter") + styles.renderLabel("write") +
+			"  " + styles.renderHotkey("esc") + styles.renderLabel("back"))
+	default:
+		sb.WriteString(" " + keys.hints(styles,
+			keyHint{[]keyAction{actSelect}, "run"},
+			keyHint{[]keyAction{actBack}, "close"},
+		))
+	}
+	return styles.activePanel.Width(width).Padding(0, 1, 0, 1).Render(sb.String())
+}
@@ -0,0 +1,164 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionMenu_CopyJSONPath(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m.activeChange.Path = "$.info.title"
+
+	m = pressKeys(m, "e")
+	require.True(t, m.showActionMenu)
+	assert.Contains(t, stripANSI(m.View().Content), "Copy JSONPath")
+
+	result, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "p"}))
+	m = result.(ConsoleModel)
+	assert.False(t, m.showActionMenu)
+	require.NotNil(t, cmd)
+	assert.Equal(t, "$.info.title", fmt.Sprint(cmd()), "the JSONPath goes on the clipboard")
+	assert.Contains(t, m.renderNavBar(), "copied JSONPath")
+}
+
+func TestActionMenu_CopyValuesAndDiff(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+
+	result, cmd := pressKeys(m, "e").Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "o"}))
+	require.NotNil(t, cmd)
+	assert.Equal(t, "Old Title", fmt.Sprint(cmd()))
+
+	m = pressKeys(result.(ConsoleModel), "e")
+	for range 2 {
+		result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+		m = result.(ConsoleModel)
+	}
+	_, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	require.NotNil(t, cmd, "enter runs the action under the cursor")
+	assert.Equal(t, "New Title", fmt.Sprint(cmd()))
+
+	_, cmd = pressKeys(m, "e").Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "d"}))
+	require.NotNil(t, cmd)
+	diff := fmt.Sprint(cmd())
+	assert.NotContains(t, diff, "\x1b[", "the diff is copied as plain text")
+	assert.Contains(t, diff, "title")
+}
+
+func TestActionMenu_EscCloses(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m = pressKeys(m, "e")
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	m = result.(ConsoleModel)
+	assert.False(t, m.showActionMenu)
+	assert.Equal(t, FocusTree, m.focus)
+}
+
+func TestActionMenu_ExportMarkdown(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m.cache.get("abc123").markdown = "# Changes\n"
+
+	m = pressKeys(m, "e", "m")
+	require.True(t, m.actionMenu.prompting)
+	assert.Equal(t, "abc123-report.md", m.actionMenu.path.Value())
+	assert.Contains(t, stripANSI(m.View().Content), "File: abc123-report.md")
+
+	path := filepath.Join(t.TempDir(), "changes.md")
+	m.actionMenu.path.SetValue(path)
+	result, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	m = result.(ConsoleModel)
+	assert.Nil(t, cmd)
+	assert.False(t, m.showActionMenu)
+	assert.Contains(t, m.notice, "markdown report written")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Changes\n", string(data))
+}
+
+func TestActionMenu_ExportJSON(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m = pressKeys(m, "e", "j")
+	m.actionMenu.path.SetValue(filepath.Join(t.TempDir(), "unavailable.json"))
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	assert.Equal(t, "JSON reports are not available", result.(ConsoleModel).notice)
+
+	var reported *model.Commit
+	m = m.WithJSONReports(func(commit *model.Commit, _ *whatChangedModel.BreakingRulesConfig) ([]byte, error) {
+		reported = commit
+		return json.Marshal(&model.FlatReport{Commit: &model.Commit{Hash: commit.Hash}})
+	})
+	m = pressKeys(m, "e", "j")
+	path := filepath.Join(t.TempDir(), "changes.json")
+	m.actionMenu.path.SetValue(path)
+	result, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	require.NotNil(t, reported)
+	assert.Equal(t, "abc123", reported.Hash)
+	assert.Contains(t, m.notice, "JSON report written")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var flat model.FlatReport
+	require.NoError(t, json.Unmarshal(data, &flat))
+	assert.Equal(t, "abc123", flat.Commit.Hash)
+}
+
+func TestActionMenu_ExportPromptEditsPath(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m = pressKeys(m, "e", "m")
+	for range len(".md") {
+		result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyBackspace}))
+		m = result.(ConsoleModel)
+	}
+	// keys bound elsewhere are typed into the path
+	m = pressKeys(m, ".", "q")
+	assert.True(t, m.showActionMenu)
+	assert.Equal(t, "abc123-report.q", m.actionMenu.path.Value())
+}
+
+func TestActionMenu_ExportAsksBeforeOverwriting(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m.cache.get("abc123").markdown = "# Changes\n"
+	path := filepath.Join(t.TempDir(), "changes.md")
+	require.NoError(t, os.WriteFile(path, []byte("keep"), 0644))
+
+	m = pressKeys(m, "e", "m")
+	m.actionMenu.path.SetValue(path)
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	m = result.(ConsoleModel)
+	require.True(t, m.actionMenu.overwriting)
+	assert.Contains(t, stripANSI(m.View().Content), "Replace 'changes.md'?")
+
+	m = pressKeys(m, "n")
+	assert.False(t, m.actionMenu.overwriting)
+	assert.True(t, m.actionMenu.prompting, "declining goes back to the path")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "keep", string(data))
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	m = pressKeys(result.(ConsoleModel), "y")
+	assert.False(t, m.showActionMenu)
+	assert.Contains(t, m.notice, "markdown report written")
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Changes\n", string(data))
+}
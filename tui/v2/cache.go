@@ -23,11 +23,12 @@ type cacheEntry struct {
 	treeRoot         *v3.Node
 	stats            *changerator.ChangeStatistics
 	nodeStatsCache   map[*v3.Node]nodeStats
-	newLines         []string // pre-split commit.Data
-	oldLines         []string // pre-split commit.OldData
-	markdown         string   // cached raw markdown report (width-independent)
-	renderedMarkdown string   // cached glamour-rendered markdown
-	renderedWidth    int      // content width used for renderedMarkdown
+	newLines         []string        // pre-split commit.Data
+	oldLines         []string        // pre-split commit.OldData
+	markdown         string          // cached raw markdown report (width-independent)
+	renderedMarkdown string          // cached glamour-rendered markdown
+	renderedWidth    int             // content width used for renderedMarkdown
+	sideBySideRows   []sideBySideRow // old and new spec lines aligned for the side-by-side diff
 }
 
 // changeratorResultV2 wraps the cmd.changeratorResult to avoid import cycles.
@@ -32,7 +32,7 @@ func newCodeModal(lines []string, hl highlightRange, ch *whatChangedModel.Change
 	modalW, modalH, contentW := modalDimensions(termWidth, termHeight)
 
 	// Build the fixed diff summary
-	summary := renderDiffSummary(ch, modalW, styles)
+	summary := renderDiffSummary(ch, modalW, styles, "")
 	summaryH := strings.Count(summary, "\n")
 	// +1 for the separator line below the summary
 	if summaryH > 0 {
@@ -69,14 +69,17 @@ func newCodeModal(lines []string, hl highlightRange, ch *whatChangedModel.Change
 }
 
 // View renders the code modal content inside a bordered panel.
-func (m codeModal) View(styles consoleStyles) string {
+func (m codeModal) View(styles consoleStyles, keys KeyMap) string {
 	border := styles.activePanel.Width(m.width).Height(m.height).
 		Padding(0, 1, 0, 1)
 
-	nav := " " + styles.renderHotkey("↑↓") + styles.renderLabel("scroll") +
-		"  " + styles.renderHotkey("pgup/pgdn") + styles.renderLabel("page") +
-		"  " + styles.renderHotkey("space") + styles.renderLabel("recenter") +
-		"  " + styles.renderHotkey("enter/esc") + styles.renderLabel("close")
+	nav := " " + keys.hints(styles,
+		keyHint{[]keyAction{actUp, actDown}, "scroll"},
+		keyHint{[]keyAction{actPageUp, actPageDown}, "page"},
+		keyHint{[]keyAction{actRecenter}, "recenter"},
+		keyHint{[]keyAction{actEdit}, "edit"},
+		keyHint{[]keyAction{actSelect, actBack}, "close"},
+	)
 	sep := styles.renderSeparator(m.width - 4)
 
 	var sb strings.Builder
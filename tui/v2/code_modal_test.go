@@ -31,7 +31,7 @@ func TestNewCodeModal_BasicRendering(t *testing.T) {
 	styles := newConsoleStyles()
 	modal := newCodeModal(lines, singleLineRange(50), testChange, 120, 40, styles)
 
-	view := modal.View(styles)
+	view := modal.View(styles, defaultKeyMap())
 	// Diff summary should appear at the top
 	assert.Contains(t, view, "Modified: title")
 	assert.Contains(t, view, "- title: Old")
@@ -56,7 +56,7 @@ func TestNewCodeModal_HighlightsChangeLine(t *testing.T) {
 	styles := newConsoleStyles()
 	modal := newCodeModal(lines, singleLineRange(3), ch, 80, 20, styles)
 
-	view := modal.View(styles)
+	view := modal.View(styles, defaultKeyMap())
 	// The change line should be highlighted with ">"
 	assert.True(t, strings.Contains(view, ">"))
 }
@@ -92,7 +92,7 @@ func TestNewCodeModal_SmallTerminal(t *testing.T) {
 
 	// Very small terminal
 	modal := newCodeModal(lines, singleLineRange(2), ch, 30, 10, styles)
-	view := modal.View(styles)
+	view := modal.View(styles, defaultKeyMap())
 	assert.NotEmpty(t, view)
 }
 
@@ -120,7 +120,7 @@ func TestNewCodeModal_RangeHighlight(t *testing.T) {
 	hl := highlightRange{start: 3, end: 8}
 	modal := newCodeModal(lines, hl, ch, 120, 40, styles)
 
-	view := modal.View(styles)
+	view := modal.View(styles, defaultKeyMap())
 	// Primary line should have > marker
 	assert.Contains(t, view, ">")
 	// Body lines should have │ gutter marker
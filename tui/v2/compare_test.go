@@ -0,0 +1,114 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeComparableCommits returns a newest-first history whose commits each hold
+// the spec as it was after them.
+func makeComparableCommits(t *testing.T, hashes ...string) []*model.Commit {
+	t.Helper()
+	commits := makeHistoryCommits(hashes...)
+	for _, commit := range commits {
+		commit.Data = []byte("openapi: 3.1.0\ninfo:\n  title: " + commit.Hash + "\n  version: '1'\n")
+		doc, err := libopenapi.NewDocument(commit.Data)
+		require.NoError(t, err)
+		commit.Document = doc
+	}
+	return commits
+}
+
+func TestComparisonCommit(t *testing.T) {
+	commits := makeComparableCommits(t, "ccc3333333", "bbb2222222", "aaa1111111")
+
+	comparison := comparisonCommit(commits, 2, 0)
+	assert.Equal(t, "aaa1111..ccc3333", comparison.Hash)
+	assert.True(t, comparison.Synthetic)
+	assert.Equal(t, commits[0].Data, comparison.Data)
+	assert.Equal(t, commits[2].Data, comparison.OldData)
+	assert.Same(t, commits[0].Document, comparison.Document)
+	assert.Same(t, commits[2].Document, comparison.OldDocument)
+	require.Len(t, comparison.Squashed, 2, "the from side's own changes are not compared")
+	assert.Equal(t, "ccc3333333", comparison.Squashed[0].Hash)
+	assert.Equal(t, "bbb2222222", comparison.Squashed[1].Hash)
+
+	reversed := comparisonCommit(commits, 0, 2)
+	assert.Equal(t, "ccc3333..aaa1111", reversed.Hash)
+	assert.Equal(t, commits[0].Data, reversed.OldData)
+	assert.Len(t, reversed.Squashed, 2)
+}
+
+func TestCompareKeys_MarkFromAndTo(t *testing.T) {
+	recorder := &recordingRunFn{}
+	m := NewConsoleModel(makeComparableCommits(t, "ccc3333", "bbb2222", "aaa1111"), nil, true, "test", recorder.run).
+		WithCacheSize(1)
+	m.width, m.height = 120, 40
+	m = runCmds(t, m, m.Init())
+	m.focus = FocusCommitTable
+	m.commitTable.Focus()
+
+	// mark the oldest commit as from: moving there selects it
+	result, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	result, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	result, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "f"}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	assert.Nil(t, m.comparison, "one side alone does not compare")
+	assert.Contains(t, stripANSI(m.commitTable.View()), "[from] aaa1111")
+
+	m.commitTable.SetCursor(0)
+	result, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "t"}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	require.NotNil(t, m.comparison)
+	assert.Equal(t, "aaa1111..ccc3333", m.activeHash)
+	assert.Contains(t, recorder.hashes, "aaa1111..ccc3333", "the comparison runs through the changerator")
+	assert.Equal(t, "No changes detected in this commit", m.emptyState)
+	assert.Contains(t, m.renderNavBar(), "comparing aaa1111..ccc3333")
+	assert.Contains(t, stripANSI(m.commitTable.View()), "[to] ccc3333")
+
+	result, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	assert.Nil(t, m.comparison)
+	assert.Equal(t, "aaa1111", m.activeHash, "esc goes back to the active commit")
+	assert.NotContains(t, stripANSI(m.commitTable.View()), "[from]")
+}
+
+func TestCompareKeys_SelectingACommitEndsComparison(t *testing.T) {
+	commits := makeComparableCommits(t, "ccc3333", "bbb2222", "aaa1111")
+	commits[1].Document = nil
+	m := NewConsoleModel(commits, nil, true, "test", (&recordingRunFn{}).run)
+	m.width, m.height = 120, 40
+	m = runCmds(t, m, m.Init())
+	m.focus = FocusCommitTable
+	m.commitTable.Focus()
+
+	m.commitTable.SetCursor(1)
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "f"}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, -1, m.compareFrom)
+	assert.Contains(t, m.renderNavBar(), "has no spec to compare")
+
+	m.commitTable.SetCursor(2)
+	result, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "f"}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	m.commitTable.SetCursor(0)
+	result, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "t"}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	require.NotNil(t, m.comparison)
+
+	result, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	m = runCmds(t, result.(ConsoleModel), cmd)
+	assert.Nil(t, m.comparison)
+	assert.Equal(t, "bbb2222", m.activeHash)
+	assert.Equal(t, 2, m.compareFrom, "the marks stay for the next comparison")
+}
@@ -51,7 +51,8 @@ func resolveNewValue(ch *whatChangedModel.Change) string {
 // renderDiff renders a diff card for a single change.
 // newLines/oldLines are pre-split spec lines from the cache.
 // The value diff is shown first, then the surrounding spec context.
-func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width int, styles consoleStyles) string {
+// progress is the breaking change progress shown in the header, if any.
+func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width int, styles consoleStyles, progress string) string {
 	if ch == nil {
 		return ""
 	}
@@ -62,7 +63,7 @@ func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width
 	switch ch.ChangeType {
 	case whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded:
 		if hasValueData(ch) {
-			sb.WriteString(renderDiffHeader(ch, width, styles, false))
+			sb.WriteString(renderDiffHeader(ch, width, styles, false, progress))
 			val := resolveNewValue(ch)
 			sb.WriteString(renderValueOneSided(ch.Property, val, "+", styles.added))
 			if ch.Context != nil && ch.Context.NewLine != nil {
@@ -71,7 +72,7 @@ func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width
 				sb.WriteString(renderFileContext(newLines, ch.Context.NewLine, hl, ch.ChangeType, width, styles))
 			}
 		} else if ch.Context != nil && ch.Context.NewLine != nil {
-			sb.WriteString(renderDiffHeader(ch, width, styles, true))
+			sb.WriteString(renderDiffHeader(ch, width, styles, true, progress))
 			region, start := extractRegion(newLines, ch.Context.NewLine, regionRadius)
 			if region == nil {
 				sb.WriteString(styles.grey.Render("  Added: no line information"))
@@ -79,13 +80,13 @@ func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width
 				sb.WriteString(renderOneSided(region, start, "+", styles.added, styles))
 			}
 		} else {
-			sb.WriteString(renderDiffHeader(ch, width, styles, true))
+			sb.WriteString(renderDiffHeader(ch, width, styles, true, progress))
 			sb.WriteString(styles.grey.Render("  Added: no line information"))
 		}
 
 	case whatChangedModel.PropertyRemoved, whatChangedModel.ObjectRemoved:
 		if hasValueData(ch) {
-			sb.WriteString(renderDiffHeader(ch, width, styles, false))
+			sb.WriteString(renderDiffHeader(ch, width, styles, false, progress))
 			val := resolveOldValue(ch)
 			sb.WriteString(renderValueOneSided(ch.Property, val, "-", styles.removed))
 			if ch.Context != nil && ch.Context.OriginalLine != nil {
@@ -94,7 +95,7 @@ func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width
 				sb.WriteString(renderFileContext(oldLines, ch.Context.OriginalLine, hl, ch.ChangeType, width, styles))
 			}
 		} else if ch.Context != nil && ch.Context.OriginalLine != nil {
-			sb.WriteString(renderDiffHeader(ch, width, styles, true))
+			sb.WriteString(renderDiffHeader(ch, width, styles, true, progress))
 			region, start := extractRegion(oldLines, ch.Context.OriginalLine, regionRadius)
 			if region == nil {
 				sb.WriteString(styles.grey.Render("  Removed: no line information"))
@@ -102,13 +103,13 @@ func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width
 				sb.WriteString(renderOneSided(region, start, "-", styles.removed, styles))
 			}
 		} else {
-			sb.WriteString(renderDiffHeader(ch, width, styles, true))
+			sb.WriteString(renderDiffHeader(ch, width, styles, true, progress))
 			sb.WriteString(styles.grey.Render("  Removed: no line information"))
 		}
 
 	case whatChangedModel.Modified:
 		if hasValueData(ch) {
-			sb.WriteString(renderDiffHeader(ch, width, styles, false))
+			sb.WriteString(renderDiffHeader(ch, width, styles, false, progress))
 			oldVal := resolveOldValue(ch)
 			newVal := resolveNewValue(ch)
 			sb.WriteString(renderValueDiff(ch.Property, oldVal, newVal, styles))
@@ -119,7 +120,7 @@ func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width
 		} else if ch.Context != nil &&
 			(ch.Context.OriginalLine != nil || ch.Context.NewLine != nil) {
 			// Tier 1 fallback: file-region diffing
-			sb.WriteString(renderDiffHeader(ch, width, styles, true))
+			sb.WriteString(renderDiffHeader(ch, width, styles, true, progress))
 			oldRegion, oldStart := extractRegion(oldLines, ch.Context.OriginalLine, regionRadius)
 			newRegion, newStart := extractRegion(newLines, ch.Context.NewLine, regionRadius)
 			if oldRegion == nil && newRegion == nil {
@@ -133,12 +134,12 @@ func renderDiff(ch *whatChangedModel.Change, newLines, oldLines []string, width
 			}
 		} else {
 			// Tier 2 fallback: nothing available
-			sb.WriteString(renderDiffHeader(ch, width, styles, true))
+			sb.WriteString(renderDiffHeader(ch, width, styles, true, progress))
 			sb.WriteString(styles.grey.Render("  no line information"))
 		}
 
 	default:
-		sb.WriteString(renderDiffHeader(ch, width, styles, true))
+		sb.WriteString(renderDiffHeader(ch, width, styles, true, progress))
 		sb.WriteByte('\n')
 		sb.WriteString(styles.grey.Render("  Unknown change type"))
 	}
@@ -243,12 +244,12 @@ func contextHighlightStyles(changeType int, styles consoleStyles) (primary, rang
 
 // renderDiffSummary renders the diff header and value diff for a change,
 // without spec context. Used as a fixed header in the code modal.
-func renderDiffSummary(ch *whatChangedModel.Change, width int, styles consoleStyles) string {
+func renderDiffSummary(ch *whatChangedModel.Change, width int, styles consoleStyles, progress string) string {
 	if ch == nil {
 		return ""
 	}
 	var sb strings.Builder
-	sb.WriteString(renderDiffHeader(ch, width, styles, false))
+	sb.WriteString(renderDiffHeader(ch, width, styles, false, progress))
 
 	switch ch.ChangeType {
 	case whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded:
@@ -427,8 +428,9 @@ func rewriteHunkHeader(header string, oldStart, newStart int) string {
 
 // renderDiffHeader renders the metadata card at the top of the diff.
 // When showValues is false, the Original/New/Value rows are suppressed
-// (because the diff body already shows the values).
-func renderDiffHeader(ch *whatChangedModel.Change, width int, styles consoleStyles, showValues bool) string {
+// (because the diff body already shows the values). A breaking change
+// shows progress, such as "breaking 3/17", next to its indicator.
+func renderDiffHeader(ch *whatChangedModel.Change, width int, styles consoleStyles, showValues bool, progress string) string {
 	var sb strings.Builder
 
 	// Action label
@@ -441,6 +443,9 @@ func renderDiffHeader(ch *whatChangedModel.Change, width int, styles consoleStyl
 	// Breaking indicator
 	if ch.Breaking {
 		sb.WriteString(styles.breaking.Render("  BREAKING CHANGE"))
+		if progress != "" {
+			sb.WriteString(styles.grey.Render("  " + progress))
+		}
 		sb.WriteByte('\n')
 	}
 
@@ -28,7 +28,7 @@ func TestRenderDiff_Modified(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 140, testStyles)
+	result := renderDiff(ch, nil, nil, 140, testStyles, "")
 	assert.Contains(t, result, "Modified")
 	assert.Contains(t, result, "description")
 	// Value-based: should show property with old/new values
@@ -48,7 +48,7 @@ func TestRenderDiff_Added(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 140, testStyles)
+	result := renderDiff(ch, nil, nil, 140, testStyles, "")
 	assert.Contains(t, result, "Added")
 	assert.Contains(t, result, "newProp")
 	// Value-based: should show + prefixed property
@@ -66,7 +66,7 @@ func TestRenderDiff_Removed(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 140, testStyles)
+	result := renderDiff(ch, nil, nil, 140, testStyles, "")
 	assert.Contains(t, result, "Removed")
 	assert.Contains(t, result, "BREAKING")
 	assert.Contains(t, result, "removedProp")
@@ -75,7 +75,7 @@ func TestRenderDiff_Removed(t *testing.T) {
 }
 
 func TestRenderDiff_NilChange(t *testing.T) {
-	assert.Equal(t, "", renderDiff(nil, nil, nil, 80, testStyles))
+	assert.Equal(t, "", renderDiff(nil, nil, nil, 80, testStyles, ""))
 }
 
 func TestRenderDiff_NilLineNum(t *testing.T) {
@@ -85,7 +85,7 @@ func TestRenderDiff_NilLineNum(t *testing.T) {
 		Context:    &whatChangedModel.ChangeContext{},
 	}
 
-	result := renderDiff(ch, nil, nil, 80, testStyles)
+	result := renderDiff(ch, nil, nil, 80, testStyles, "")
 	assert.Contains(t, result, "no line information")
 }
 
@@ -103,7 +103,7 @@ func TestRenderDiff_EncodedValues(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 140, testStyles)
+	result := renderDiff(ch, nil, nil, 140, testStyles, "")
 	// Should prefer encoded values and show multi-line diff
 	assert.Contains(t, result, "type:")
 	assert.Contains(t, result, "object")
@@ -124,7 +124,7 @@ func TestRenderDiff_MultiLineEncoded(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "@@")
 	// Lines should be properly separated (not concatenated)
 	assert.Contains(t, result, "-  name: string")
@@ -144,7 +144,7 @@ func TestRenderDiff_IdenticalValues(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "(values are identical)")
 }
 
@@ -161,7 +161,7 @@ func TestRenderDiff_YAMLDocMarker(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	// The YAML --- should appear in the diff output
 	assert.Contains(t, result, "---")
 }
@@ -187,7 +187,7 @@ func TestRenderDiff_FallbackToFileRegion(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, newLines, oldLines, 120, testStyles)
+	result := renderDiff(ch, newLines, oldLines, 120, testStyles, "")
 	// Should fall back to file-region diff with @@ headers
 	assert.Contains(t, result, "@@")
 	assert.Contains(t, result, "-description: old")
@@ -207,7 +207,7 @@ func TestRenderDiff_OneSidedLabel(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "+ prop: val")
 	assert.NotContains(t, result, "Lines")
 	assert.NotContains(t, result, "@@")
@@ -226,7 +226,7 @@ func TestRenderDiff_SingleLineModified(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "- version: 1.0.0")
 	assert.Contains(t, result, "+ version: 2.0.0")
 	assert.NotContains(t, result, "@@")
@@ -242,7 +242,7 @@ func TestRenderDiff_AddedMultiLine(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "+ type: object")
 	assert.Contains(t, result, "+ properties:")
 	assert.Contains(t, result, "+   name: string")
@@ -260,7 +260,7 @@ func TestRenderDiff_RemovedMultiLine(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "BREAKING")
 	assert.Contains(t, result, "- type: array")
 	assert.Contains(t, result, "- items:")
@@ -282,7 +282,7 @@ func TestRenderDiff_EncodedPreferredOverPlain(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	// Should use Encoded content, not plain
 	assert.Contains(t, result, "encoded: old-value")
 	assert.Contains(t, result, "encoded: new-value")
@@ -302,7 +302,7 @@ func TestRenderDiff_EmptyStringValue(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	// Should use value path (not fallback)
 	assert.Contains(t, result, "+ emptyField:")
 	assert.NotContains(t, result, "no line information")
@@ -321,7 +321,7 @@ func TestRenderDiff_HeaderNoValueDuplication(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 140, testStyles)
+	result := renderDiff(ch, nil, nil, 140, testStyles, "")
 	assert.NotContains(t, result, "Original:")
 	assert.NotContains(t, result, "->")
 	// But should show the diff body
@@ -342,7 +342,7 @@ func TestRenderDiff_IdenticalValuesSingleLine(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "(values are identical)")
 	// Should NOT show diff lines
 	assert.NotContains(t, result, "- format:")
@@ -357,7 +357,7 @@ func TestRenderDiff_NoValuesNoLines(t *testing.T) {
 		Context:    &whatChangedModel.ChangeContext{},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "no line information")
 }
 
@@ -373,7 +373,7 @@ func TestRenderDiff_FallbackOneSideNil(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, newLines, nil, 120, testStyles)
+	result := renderDiff(ch, newLines, nil, 120, testStyles, "")
 	assert.Contains(t, result, "+")
 	assert.NotContains(t, result, "no line information")
 }
@@ -441,7 +441,7 @@ func TestRenderDiff_ModifiedWithContext(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, specLines, specLines, 140, testStyles)
+	result := renderDiff(ch, specLines, specLines, 140, testStyles, "")
 	// Value diff at top
 	assert.Contains(t, result, "- description: old desc")
 	assert.Contains(t, result, "+ description: new desc")
@@ -469,7 +469,7 @@ func TestRenderDiff_AddedWithContext(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, specLines, nil, 120, testStyles)
+	result := renderDiff(ch, specLines, nil, 120, testStyles, "")
 	assert.Contains(t, result, "+ newProp: value")
 	assert.Contains(t, result, "spec context")
 	assert.Contains(t, result, "line-")
@@ -488,7 +488,7 @@ func TestRenderDiff_NoContextWhenNoLines(t *testing.T) {
 		},
 	}
 
-	result := renderDiff(ch, nil, nil, 120, testStyles)
+	result := renderDiff(ch, nil, nil, 120, testStyles, "")
 	assert.Contains(t, result, "- description: old")
 	assert.Contains(t, result, "+ description: new")
 	assert.NotContains(t, result, "spec context")
@@ -715,3 +715,17 @@ func TestResolveSpecAndLine_NilContext(t *testing.T) {
 	assert.Equal(t, newLines, lines)
 	assert.Equal(t, 0, changeLn) // no line info
 }
+
+func TestRenderDiffHeader_BreakingProgress(t *testing.T) {
+	ch := &whatChangedModel.Change{
+		ChangeType: whatChangedModel.PropertyRemoved,
+		Property:   "deprecated",
+		Original:   "true",
+		Breaking:   true,
+	}
+	assert.Contains(t, renderDiffHeader(ch, 120, testStyles, false, "breaking 3/17"), "breaking 3/17")
+	assert.NotContains(t, renderDiffHeader(ch, 120, testStyles, false, ""), "breaking")
+
+	ch.Breaking = false
+	assert.NotContains(t, renderDiffHeader(ch, 120, testStyles, false, "breaking 3/17"), "breaking 3/17")
+}
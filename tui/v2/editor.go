@@ -0,0 +1,89 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+)
+
+// EditorTargetFn resolves the file and line where a change of a commit can be
+// opened in an editor: the right (new) side of the change. Like RunChangeratorFn,
+// it is injected from the cmd package, which knows where each commit was read
+// from. A line of 0 opens the file at its start.
+type EditorTargetFn func(commit *model.Commit, change *whatChangedModel.Change) (path string, line int, err error)
+
+// editorClosedMsg is sent when the editor exits and the console resumes.
+type editorClosedMsg struct {
+	err error
+}
+
+// editorFromEnv returns the editor the user asked for, preferring $VISUAL.
+func editorFromEnv() string {
+	if editor := strings.TrimSpace(os.Getenv("VISUAL")); editor != "" {
+		return editor
+	}
+	return strings.TrimSpace(os.Getenv("EDITOR"))
+}
+
+// editorCommand builds the command that opens path at line in editor. The
+// editor may carry its own arguments, such as "code --wait". Editors are told
+// the line in the form they understand: "+line path" for vi, emacs, nano and
+// most terminal editors, "path:line" for those that take that instead.
+func editorCommand(editor, path string, line int) (*exec.Cmd, error) {
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return nil, errors.New("no editor is set: set $VISUAL or $EDITOR")
+	}
+	args := fields[1:]
+	switch {
+	case line <= 0:
+		args = append(args, path)
+	default:
+		switch strings.TrimSuffix(filepath.Base(fields[0]), ".exe") {
+		case "code", "code-insiders", "codium", "cursor", "windsurf":
+			args = append(args, "--goto", fmt.Sprintf("%s:%d", path, line))
+		case "subl", "zed", "hx", "helix":
+			args = append(args, fmt.Sprintf("%s:%d", path, line))
+		default:
+			args = append(args, fmt.Sprintf("+%d", line), path)
+		}
+	}
+	return exec.Command(fields[0], args...), nil
+}
+
+// openInEditor suspends the console and opens the active change in the user's
+// editor. The console resumes when the editor exits.
+func (m *ConsoleModel) openInEditor() tea.Cmd {
+	ch, commit := m.activeChange, m.activeCommit()
+	if ch == nil || commit == nil {
+		m.notice = "no change selected to open"
+		return nil
+	}
+	if m.editorTargetFn == nil {
+		m.notice = "opening changes in an editor is not available"
+		return nil
+	}
+	path, line, err := m.editorTargetFn(commit, ch)
+	if err != nil {
+		m.notice = "Error: " + err.Error()
+		return nil
+	}
+	cmd, err := editorCommand(editorFromEnv(), path, line)
+	if err != nil {
+		m.notice = "Error: " + err.Error()
+		return nil
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorClosedMsg{err: err}
+	})
+}
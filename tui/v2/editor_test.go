@@ -0,0 +1,101 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditorCommand_LineArguments(t *testing.T) {
+	tests := []struct {
+		editor string
+		line   int
+		args   []string
+	}{
+		{"vim", 12, []string{"vim", "+12", "spec.yaml"}},
+		{"emacs -nw", 12, []string{"emacs", "-nw", "+12", "spec.yaml"}},
+		{"code --wait", 12, []string{"code", "--wait", "--goto", "spec.yaml:12"}},
+		{"/usr/local/bin/subl", 12, []string{"/usr/local/bin/subl", "spec.yaml:12"}},
+		{"hx", 12, []string{"hx", "spec.yaml:12"}},
+		{"nano", 0, []string{"nano", "spec.yaml"}},
+	}
+	for _, tt := range tests {
+		cmd, err := editorCommand(tt.editor, "spec.yaml", tt.line)
+		require.NoError(t, err, tt.editor)
+		assert.Equal(t, tt.args, cmd.Args, tt.editor)
+	}
+
+	_, err := editorCommand("  ", "spec.yaml", 1)
+	assert.Error(t, err)
+}
+
+func TestOpenInEditor_ExecsEditorAtTarget(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "true")
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+
+	var gotCommit *model.Commit
+	var gotChange *whatChangedModel.Change
+	m = m.WithEditorTargets(func(commit *model.Commit, change *whatChangedModel.Change) (string, int, error) {
+		gotCommit, gotChange = commit, change
+		return "spec.yaml", 7, nil
+	})
+
+	_, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "o"}))
+	require.NotNil(t, cmd, "the editor runs while the console is suspended")
+	assert.Same(t, m.activeCommit(), gotCommit)
+	assert.Same(t, m.activeChange, gotChange)
+}
+
+func TestOpenInEditor_FromCodeModal(t *testing.T) {
+	t.Setenv("VISUAL", "true")
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m = m.WithEditorTargets(func(*model.Commit, *whatChangedModel.Change) (string, int, error) {
+		return "spec.yaml", 1, nil
+	})
+	m.openCodeModal(m.activeChange)
+	require.True(t, m.showCodeModal)
+
+	result, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "o"}))
+	require.NotNil(t, cmd)
+	assert.True(t, result.(ConsoleModel).showCodeModal, "the code modal stays open behind the editor")
+}
+
+func TestOpenInEditor_Notices(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+
+	result, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "o"}))
+	assert.Nil(t, cmd)
+	assert.Contains(t, result.(ConsoleModel).renderNavBar(), "not available")
+
+	m = m.WithEditorTargets(func(*model.Commit, *whatChangedModel.Change) (string, int, error) {
+		return "spec.yaml", 1, nil
+	})
+	result, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "o"}))
+	assert.Nil(t, cmd)
+	assert.Contains(t, result.(ConsoleModel).renderNavBar(), "$EDITOR")
+
+	t.Setenv("EDITOR", "vi")
+	m = m.WithEditorTargets(func(*model.Commit, *whatChangedModel.Change) (string, int, error) {
+		return "", 0, errors.New("cannot open 'shared.yaml'")
+	})
+	result, cmd = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "o"}))
+	assert.Nil(t, cmd)
+	assert.Contains(t, result.(ConsoleModel).renderNavBar(), "cannot open 'shared.yaml'")
+
+	result, _ = m.Update(editorClosedMsg{err: errors.New("exit status 1")})
+	assert.Contains(t, result.(ConsoleModel).renderNavBar(), "exit status 1")
+}
@@ -0,0 +1,108 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+)
+
+// helpKeysWidth is the width of the keys column of the help overlay.
+const helpKeysWidth = 18
+
+// helpModal is the overlay listing the key bindings, generated from the keymap.
+type helpModal struct {
+	vp     viewport.Model
+	title  string
+	width  int
+	height int
+}
+
+// newHelpModal builds the help overlay for the key bindings of a keymap.
+func newHelpModal(keys KeyMap, termWidth, termHeight int, styles consoleStyles) helpModal {
+	modalW, modalH, contentW := modalDimensions(termWidth, termHeight)
+	vp := viewport.New(
+		viewport.WithWidth(contentW),
+		viewport.WithHeight(max(modalH-5, 3)), // border(2) + title/separator(1) + bottom separator(1) + nav(1)
+	)
+	vp.SetContent(renderKeyBindings(keys, styles))
+	return helpModal{
+		vp:     vp,
+		title:  "Key bindings (" + keys.preset + ")",
+		width:  modalW,
+		height: modalH,
+	}
+}
+
+// renderKeyBindings lists every action with its keys and name, by section. The
+// names are what key binding overrides refer to.
+func renderKeyBindings(keys KeyMap, styles consoleStyles) string {
+	var sb strings.Builder
+	section := ""
+	for _, binding := range keyBindings {
+		if binding.section != section {
+			if section != "" {
+				sb.WriteByte('\n')
+			}
+			section = binding.section
+			sb.WriteString(styles.info.Render(" " + section))
+			sb.WriteByte('\n')
+		}
+		labels := make([]string, len(keys.keys[binding.action]))
+		for i, key := range keys.keys[binding.action] {
+			labels[i] = keyLabel(key)
+		}
+		sb.WriteString("   ")
+		sb.WriteString(styles.helpKey.Render(padToWidth(strings.Join(labels, " "), helpKeysWidth)))
+		sb.WriteString(binding.help)
+		sb.WriteString(styles.grey.Render(" (" + string(binding.action) + ")"))
+		sb.WriteByte('\n')
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// openHelp opens the help overlay.
+func (m *ConsoleModel) openHelp() {
+	m.helpModal = newHelpModal(m.keys, m.width, m.height, m.styles)
+	m.showHelp = true
+}
+
+// handleHelpKeys handles key events while the help overlay is showing.
+func (m ConsoleModel) handleHelpKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m.handleQuit()
+	}
+	switch m.keys.action(msg) {
+	case actHelp, actBack, actQuit, actSelect:
+		m.showHelp = false
+	default:
+		m.scrollOverlay(&m.helpModal.vp, msg)
+	}
+	return m, nil
+}
+
+// View renders the help overlay inside a bordered panel.
+func (h helpModal) View(styles consoleStyles, keys KeyMap) string {
+	border := styles.activePanel.Width(h.width).Height(h.height).
+		Padding(0, 1, 0, 1)
+	sep := styles.renderSeparator(h.width - 4)
+
+	var sb strings.Builder
+	sb.WriteString(styles.info.Render(" " + h.title))
+	sb.WriteByte('\n')
+	sb.WriteString(sep)
+	sb.WriteByte('\n')
+	sb.WriteString(h.vp.View())
+	sb.WriteByte('\n')
+	sb.WriteString(sep)
+	sb.WriteByte('\n')
+	sb.WriteString(" " + keys.hints(styles,
+		keyHint{[]keyAction{actUp, actDown}, "scroll"},
+		keyHint{[]keyAction{actPageUp, actPageDown}, "page"},
+		keyHint{[]keyAction{actHelp, actBack}, "close"},
+	))
+	return border.Render(sb.String())
+}
@@ -0,0 +1,294 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// keyAction names a console command that keys are bound to. The names are what
+// key binding overrides refer to.
+type keyAction string
+
+const (
+	actUp             keyAction = "up"
+	actDown           keyAction = "down"
+	actPageUp         keyAction = "page-up"
+	actPageDown       keyAction = "page-down"
+	actTop            keyAction = "top"
+	actBottom         keyAction = "bottom"
+	actSelect         keyAction = "select"
+	actBack           keyAction = "back"
+	actSwitchPanel    keyAction = "switch-panel"
+	actNextBreaking   keyAction = "next-breaking"
+	actPrevBreaking   keyAction = "prev-breaking"
+	actSearch         keyAction = "search"
+	actNextMatch      keyAction = "next-match"
+	actPrevMatch      keyAction = "prev-match"
+	actFilterBreaking keyAction = "filter-breaking"
+	actFilterAdded    keyAction = "filter-added"
+	actFilterModified keyAction = "filter-modified"
+	actFilterRemoved  keyAction = "filter-removed"
+	actClearFilters   keyAction = "clear-filters"
+	actSideBySide     keyAction = "side-by-side"
+	actRecenter       keyAction = "recenter"
+	actReview         keyAction = "review"
+	actNote           keyAction = "note"
+	actExportReview   keyAction = "export-review"
+	actActions        keyAction = "actions"
+	actEdit           keyAction = "edit"
+	actReport         keyAction = "report"
+	actCompareFrom    keyAction = "compare-from"
+	actCompareTo      keyAction = "compare-to"
+	actShrinkTree     keyAction = "shrink-tree"
+	actGrowTree       keyAction = "grow-tree"
+	actToggleCommits  keyAction = "toggle-commits"
+	actToggleMouse    keyAction = "toggle-mouse"
+	actHelp           keyAction = "help"
+	actQuit           keyAction = "quit"
+)
+
+// keyBinding is a console command with its default keys, and where and how the
+// help overlay lists it.
+type keyBinding struct {
+	action  keyAction
+	section string
+	help    string
+	keys    []string
+}
+
+// keyBindings lists the console commands in the order the help overlay shows them.
+var keyBindings = []keyBinding{
+	{actUp, "Navigation", "previous change, commit or line", []string{"up", "k"}},
+	{actDown, "Navigation", "next change, commit or line", []string{"down", "j"}},
+	{actPageUp, "Navigation", "page up", []string{"pgup"}},
+	{actPageDown, "Navigation", "page down", []string{"pgdown"}},
+	{actTop, "Navigation", "first change or line", []string{"home"}},
+	{actBottom, "Navigation", "last change or line", []string{"end"}},
+	{actSelect, "Navigation", "view the change, or go to the changes of a commit", []string{"enter"}},
+	{actBack, "Navigation", "go back, close, or clear the comparison", []string{"esc"}},
+	{actSwitchPanel, "Navigation", "switch panel", []string{"tab"}},
+	{actNextBreaking, "Changes", "next breaking change, across commits", []string{"]"}},
+	{actPrevBreaking, "Changes", "previous breaking change, across commits", []string{"["}},
+	{actSearch, "Changes", "search the changes", []string{"/"}},
+	{actNextMatch, "Changes", "next search match", []string{"n"}},
+	{actPrevMatch, "Changes", "previous search match", []string{"N"}},
+	{actFilterBreaking, "Changes", "show only breaking changes", []string{"b"}},
+	{actFilterAdded, "Changes", "show only additions", []string{"a"}},
+	{actFilterModified, "Changes", "show only modifications", []string{"m"}},
+	{actFilterRemoved, "Changes", "show only removals", []string{"d"}},
+	{actClearFilters, "Changes", "clear the filters", []string{"c"}},
+	{actSideBySide, "Changes", "switch between the split and unified diff", []string{"s"}},
+	{actRecenter, "Changes", "recenter the code view on the change", []string{"space"}},
+	{actReview, "Review", "cycle the review mark of the change", []string{"x"}},
+	{actNote, "Review", "edit the review note of the change", []string{"i"}},
+	{actExportReview, "Review", "export the review", []string{"w"}},
+	{actActions, "Review", "copy and export actions", []string{"e"}},
+	{actEdit, "Review", "open the change in $EDITOR", []string{"o"}},
+	{actReport, "Commits", "markdown report of the commit", []string{"r"}},
+	{actCompareFrom, "Commits", "compare from the highlighted commit", []string{"f"}},
+	{actCompareTo, "Commits", "compare to the highlighted commit", []string{"t"}},
+	{actShrinkTree, "Layout", "narrow the tree", []string{"<"}},
+	{actGrowTree, "Layout", "widen the tree", []string{">"}},
+	{actToggleCommits, "Layout", "collapse or expand the commit table", []string{"z"}},
+	{actToggleMouse, "Layout", "capture the mouse, or leave it to the terminal to select text", []string{"M"}},
+	{actHelp, "General", "show these key bindings", []string{"?"}},
+	{actQuit, "General", "quit (ctrl+c always quits)", []string{"q", "ctrl+c"}},
+}
+
+// keyPresets are the key binding presets, by the keys they bind differently from
+// the defaults.
+var keyPresets = map[string]map[keyAction][]string{
+	"default": {},
+	"vim": {
+		actPageUp:   {"ctrl+b", "ctrl+u", "pgup"},
+		actPageDown: {"ctrl+f", "ctrl+d", "pgdown"},
+		actTop:      {"g", "home"},
+		actBottom:   {"G", "end"},
+	},
+	"emacs": {
+		actUp:       {"ctrl+p", "up"},
+		actDown:     {"ctrl+n", "down"},
+		actPageUp:   {"alt+v", "pgup"},
+		actPageDown: {"ctrl+v", "pgdown"},
+		actTop:      {"alt+<", "home"},
+		actBottom:   {"alt+>", "end"},
+		actBack:     {"esc", "ctrl+g"},
+		actSearch:   {"ctrl+s", "/"},
+	},
+}
+
+// KeyPresets returns the names of the key binding presets.
+func KeyPresets() []string {
+	names := make([]string, 0, len(keyPresets))
+	for name := range keyPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// KeyMap binds the console's commands to keys. It is built from a preset and
+// overrides with NewKeyMap.
+type KeyMap struct {
+	preset  string
+	keys    map[keyAction][]string
+	actions map[string]keyAction
+}
+
+// defaultKeyMap returns the default key bindings.
+func defaultKeyMap() KeyMap {
+	keys, err := NewKeyMap("default", nil)
+	if err != nil {
+		panic(err) // the default bindings are fixed and do not conflict
+	}
+	return keys
+}
+
+// NewKeyMap builds the key bindings of a preset ("" is the default preset), with
+// overrides of the form "action=key[,key...]" replacing the keys of an action.
+// A key bound to two actions is an error.
+func NewKeyMap(preset string, overrides []string) (KeyMap, error) {
+	if preset == "" {
+		preset = "default"
+	}
+	presetKeys, ok := keyPresets[preset]
+	if !ok {
+		return KeyMap{}, fmt.Errorf("unknown key preset '%s' (available: %s)", preset, strings.Join(KeyPresets(), ", "))
+	}
+	km := KeyMap{preset: preset, keys: make(map[keyAction][]string, len(keyBindings))}
+	for _, binding := range keyBindings {
+		km.keys[binding.action] = binding.keys
+		if keys, ok := presetKeys[binding.action]; ok {
+			km.keys[binding.action] = keys
+		}
+	}
+
+	for _, override := range overrides {
+		name, value, found := strings.Cut(override, "=")
+		action := keyAction(strings.TrimSpace(name))
+		if !found {
+			return KeyMap{}, fmt.Errorf("invalid key binding '%s': expected action=key[,key...]", override)
+		}
+		if _, ok := km.keys[action]; !ok {
+			return KeyMap{}, fmt.Errorf("unknown action '%s' in key binding '%s' (available: %s)", action, override, actionNames())
+		}
+		var keys []string
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return KeyMap{}, fmt.Errorf("key binding '%s' has no keys", override)
+		}
+		km.keys[action] = keys
+	}
+
+	km.actions = make(map[string]keyAction)
+	for _, binding := range keyBindings {
+		for _, key := range km.keys[binding.action] {
+			if key == "ctrl+c" && binding.action != actQuit {
+				return KeyMap{}, fmt.Errorf("cannot bind ctrl+c to '%s': ctrl+c always quits", binding.action)
+			}
+			if other, taken := km.actions[key]; taken && other != binding.action {
+				return KeyMap{}, fmt.Errorf("key '%s' is bound to both '%s' and '%s'", key, other, binding.action)
+			}
+			km.actions[key] = binding.action
+		}
+	}
+	return km, nil
+}
+
+func actionNames() string {
+	names := make([]string, len(keyBindings))
+	for i, binding := range keyBindings {
+		names[i] = string(binding.action)
+	}
+	return strings.Join(names, ", ")
+}
+
+// action returns the action a key press is bound to, or "".
+func (km KeyMap) action(msg tea.KeyPressMsg) keyAction {
+	key := msg.String()
+	if action, ok := km.actions[key]; ok {
+		return action
+	}
+	if key == "ctrl+c" {
+		return actQuit
+	}
+	return ""
+}
+
+// is reports whether a key press is bound to one of the actions.
+func (km KeyMap) is(msg tea.KeyPressMsg, actions ...keyAction) bool {
+	return slices.Contains(actions, km.action(msg))
+}
+
+// label returns the key shown for an action in hints: its first key, with the
+// arrow keys drawn as arrows.
+func (km KeyMap) label(action keyAction) string {
+	keys := km.keys[action]
+	if len(keys) == 0 {
+		return ""
+	}
+	return keyLabel(keys[0])
+}
+
+// hint returns the keys shown for a hint that covers several actions, such as
+// "↑↓" for up and down, or "b/a/m/d" for the filters.
+func (km KeyMap) hint(actions ...keyAction) string {
+	labels := make([]string, 0, len(actions))
+	arrows := true
+	for _, action := range actions {
+		label := km.label(action)
+		labels = append(labels, label)
+		arrows = arrows && strings.ContainsAny(label, "↑↓←→")
+	}
+	if arrows {
+		return strings.Join(labels, "")
+	}
+	return strings.Join(labels, "/")
+}
+
+// keyLabel returns how a key is drawn in hints and the help overlay.
+func keyLabel(key string) string {
+	switch key {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "pgdown":
+		return "pgdn"
+	}
+	return key
+}
+
+// keyHint is a footer hint: the keys of its actions and what they do.
+type keyHint struct {
+	actions []keyAction
+	label   string
+}
+
+// hints renders a footer line of hints with the keys they are bound to.
+func (km KeyMap) hints(styles consoleStyles, hints ...keyHint) string {
+	var sb strings.Builder
+	for i, h := range hints {
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(styles.renderHotkey(km.hint(h.actions...)))
+		sb.WriteString(styles.renderLabel(h.label))
+	}
+	return sb.String()
+}
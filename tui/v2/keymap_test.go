@@ -0,0 +1,116 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/pb33f/openapi-changes/internal/review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keyPress(text string) tea.KeyPressMsg {
+	return tea.KeyPressMsg(tea.Key{Code: 0, Text: text})
+}
+
+func TestNewKeyMap_Presets(t *testing.T) {
+	assert.Equal(t, []string{"default", "emacs", "vim"}, KeyPresets())
+
+	vim, err := NewKeyMap("vim", nil)
+	require.NoError(t, err)
+	assert.Equal(t, actTop, vim.action(keyPress("g")))
+	assert.Equal(t, actBottom, vim.action(keyPress("G")))
+	assert.Equal(t, actDown, vim.action(keyPress("j")))
+
+	emacs, err := NewKeyMap("emacs", nil)
+	require.NoError(t, err)
+	assert.Equal(t, actDown, emacs.action(tea.KeyPressMsg(tea.Key{Code: 'n', Mod: tea.ModCtrl})))
+	assert.Empty(t, emacs.action(keyPress("j")), "emacs does not bind vi keys")
+
+	_, err = NewKeyMap("nano", nil)
+	assert.ErrorContains(t, err, "unknown key preset 'nano' (available: default, emacs, vim)")
+}
+
+func TestNewKeyMap_Overrides(t *testing.T) {
+	keys, err := NewKeyMap("", []string{"review=v, X", "quit=Q"})
+	require.NoError(t, err)
+	assert.Equal(t, actReview, keys.action(keyPress("v")))
+	assert.Equal(t, actReview, keys.action(keyPress("X")))
+	assert.Empty(t, keys.action(keyPress("x")), "an override replaces the action's keys")
+	assert.Empty(t, keys.action(keyPress("q")))
+	assert.Equal(t, actQuit, keys.action(tea.KeyPressMsg(tea.Key{Code: 'c', Mod: tea.ModCtrl})), "ctrl+c always quits")
+
+	for override, want := range map[string]string{
+		"review=n":    "key 'n' is bound to both 'next-match' and 'review'",
+		"reviw=v":     "unknown action 'reviw'",
+		"review":      "expected action=key",
+		"review= , ":  "has no keys",
+		"help=ctrl+c": "ctrl+c always quits",
+	} {
+		_, err := NewKeyMap("default", []string{override})
+		assert.ErrorContains(t, err, want, override)
+	}
+}
+
+func TestKeyMap_RebindingChangesDispatch(t *testing.T) {
+	keys, err := NewKeyMap("vim", []string{"review=v"})
+	require.NoError(t, err)
+	m := setupModelWithTree(t).WithKeyMap(keys)
+	m.focus = FocusTree
+	moveToFirstLeaf(t, &m)
+
+	m = pressKeys(m, "x")
+	assert.Nil(t, m.reviews.Get(review.ChangeHash(m.selectedChange())), "x is no longer bound")
+	m = pressKeys(m, "v")
+	assert.NotNil(t, m.reviews.Get(review.ChangeHash(m.selectedChange())))
+
+	m = pressKeys(m, "G")
+	assert.Equal(t, m.tree.prevLeaf(len(m.tree.entries)), m.tree.cursor, "G goes to the last change")
+	assert.Contains(t, stripANSI(m.renderNavBar()), "[V/I] REVIEW", "hints show the bound keys")
+}
+
+func TestHelpOverlay(t *testing.T) {
+	keys, err := NewKeyMap("default", []string{"review=v"})
+	require.NoError(t, err)
+	m := setupModelWithTree(t).WithKeyMap(keys)
+	m.focus = FocusTree
+
+	m = pressKeys(m, "?")
+	require.True(t, m.showHelp)
+	view := stripANSI(m.View().Content)
+	assert.Contains(t, view, "Key bindings (default)")
+	assert.Contains(t, view, "cycle the review mark of the change (review)")
+
+	m = pressKeys(m, "x")
+	assert.True(t, m.showHelp, "keys other than close do nothing behind the overlay")
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	m = result.(ConsoleModel)
+	assert.False(t, m.showHelp)
+
+	m = pressKeys(m, "?", "?")
+	assert.False(t, m.showHelp, "the help key closes the overlay again")
+}
+
+func TestFooterHints_FollowFocus(t *testing.T) {
+	m := setupHistoryModel(t, "aaa1111", "bbb2222")
+
+	m.setFocus(FocusCommitTable)
+	footer := stripANSI(m.renderNavBar())
+	assert.Contains(t, footer, "[F/T] COMPARE")
+	assert.NotContains(t, footer, "SEARCH")
+
+	m.setFocus(FocusTree)
+	footer = stripANSI(m.renderNavBar())
+	assert.Contains(t, footer, "[/] SEARCH")
+	assert.Contains(t, footer, "[B/A/M/D] FILTER")
+	assert.Contains(t, footer, "[↑↓] NAVIGATE")
+	assert.Contains(t, footer, "[?] HELP")
+
+	m.setFocus(FocusDiff)
+	footer = stripANSI(m.renderNavBar())
+	assert.Contains(t, footer, "[PGUP/PGDN] SCROLL")
+	assert.NotContains(t, footer, "FILTER")
+}
@@ -8,206 +8,309 @@ import (
 	tea "charm.land/bubbletea/v2"
 )
 
+// Key handlers dispatch on the action a key is bound to in m.keys, rather than on
+// the key itself, so that the bindings can be changed. The keys named in the
+// comments below are the defaults.
+
 func (m ConsoleModel) handleQuit() (tea.Model, tea.Cmd) {
 	m.cache.releaseAll()
 	return m, tea.Quit
 }
 
 // handleCommitTableKeys handles key events when the commit table has focus.
-// Arrow keys load the changerator for the highlighted commit immediately
+// Arrow keys start loading the highlighted commit in the background right away
 // (matching the old console's selection-changed behavior).
-// Enter jumps focus to the tree.
+// Enter jumps focus to the tree. f and t mark the highlighted commit as the from
+// and to side of a comparison, and esc clears the marks.
 func (m ConsoleModel) handleCommitTableKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
+	switch m.keys.action(msg) {
+	case actQuit:
 		return m.handleQuit()
-	case "enter":
+	case actCompareFrom:
+		return m, m.markComparison(true)
+	case actCompareTo:
+		return m, m.markComparison(false)
+	case actBack:
+		return m, m.clearComparison()
+	case actSelect, actSwitchPanel:
 		m.focus = FocusTree
 		m.commitTable.Blur()
 		return m, nil
-	case "up", "k":
+	case actUp:
 		m.commitTable.MoveUp(1)
-		m.selectHighlightedCommit()
-		return m, nil
-	case "down", "j":
+		return m, m.selectHighlightedCommit()
+	case actDown:
 		m.commitTable.MoveDown(1)
-		m.selectHighlightedCommit()
-		return m, nil
-	case "r":
+		return m, m.selectHighlightedCommit()
+	case actReport:
 		m.openReportModal()
 		return m, nil
-	case "tab":
-		m.focus = FocusTree
-		m.commitTable.Blur()
-		return m, nil
 	}
 	return m, nil
 }
 
 // handleTreeKeys handles key events when the tree has focus.
 func (m ConsoleModel) handleTreeKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
+	switch m.keys.action(msg) {
+	case actQuit:
 		return m.handleQuit()
-	case "esc":
+	case actBack:
 		if m.singleCommit {
 			return m.handleQuit()
 		}
+		m.setTableCollapsed(false)
 		m.focus = FocusCommitTable
 		m.commitTable.Focus()
 		return m, nil
-	case "enter":
+	case actSelect:
 		entry := m.tree.selectedEntry()
 		if entry != nil && entry.change != nil {
 			m.openCodeModal(entry.change)
 		}
 		return m, nil
-	case "r":
+	case actReport:
 		m.openReportModal()
 		return m, nil
-	case "up", "k":
+	case actUp:
 		m.tree.moveUp(1)
 		m.syncDiffToTreeCursor()
 		return m, nil
-	case "down", "j":
+	case actDown:
 		m.tree.moveDown(1)
 		m.syncDiffToTreeCursor()
 		return m, nil
-	case "pgup":
+	case actPageUp:
 		m.tree.moveUp(m.tree.height)
 		m.syncDiffToTreeCursor()
 		return m, nil
-	case "pgdown":
+	case actPageDown:
 		m.tree.moveDown(m.tree.height)
 		m.syncDiffToTreeCursor()
 		return m, nil
-	case "home":
+	case actTop:
 		m.tree.cursor = 0
 		m.tree.offset = 0
 		m.tree.snapToNextLeaf()
 		m.syncDiffToTreeCursor()
 		return m, nil
-	case "end":
+	case actBottom:
 		m.tree.moveDown(len(m.tree.entries))
 		m.syncDiffToTreeCursor()
 		return m, nil
-	case "tab":
+	case actSwitchPanel:
 		m.focus = FocusDiff
 		return m, nil
 	}
-	return m, nil
+	return m.handleChangeNavigationKeys(msg)
 }
 
 // handleDiffKeys handles key events when the diff view has focus.
 // Up/down navigate between changes (moving the tree cursor).
 // PgUp/PgDown/Home/End scroll the diff viewport.
 func (m ConsoleModel) handleDiffKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
+	switch m.keys.action(msg) {
+	case actQuit:
 		return m.handleQuit()
-	case "esc":
+	case actBack:
 		m.focus = FocusTree
 		return m, nil
-	case "r":
+	case actReport:
 		m.openReportModal()
 		return m, nil
-	case "up", "k":
+	case actUp:
 		m.tree.moveUp(1)
 		m.syncDiffToTreeCursor()
 		return m, nil
-	case "down", "j":
+	case actDown:
 		m.tree.moveDown(1)
 		m.syncDiffToTreeCursor()
 		return m, nil
-	case "pgup":
+	case actPageUp:
 		m.diffViewport.PageUp()
 		return m, nil
-	case "pgdown":
+	case actPageDown:
 		m.diffViewport.PageDown()
 		return m, nil
-	case "home":
+	case actTop:
 		m.diffViewport.GotoTop()
 		return m, nil
-	case "end":
+	case actBottom:
 		m.diffViewport.GotoBottom()
 		return m, nil
-	case "tab":
-		if !m.singleCommit {
+	case actSwitchPanel:
+		if !m.singleCommit && !m.tableCollapsed {
 			m.focus = FocusCommitTable
 			m.commitTable.Focus()
 		} else {
 			m.focus = FocusTree
 		}
 		return m, nil
-	case "enter":
+	case actSelect:
 		if m.activeChange != nil {
 			m.openCodeModal(m.activeChange)
 		}
 		return m, nil
 	}
+	return m.handleChangeNavigationKeys(msg)
+}
+
+// handleChangeNavigationKeys handles the keys shared by the tree and the diff view.
+// ]/[ jump to the next/previous breaking change, across commits. "/" starts a search,
+// n/N jump between its matches, and b/a/m/d toggle the breaking, added, modified
+// and removed filters; c clears them. s toggles the side-by-side diff. x cycles
+// the review mark of the change under the cursor, i edits its note and w exports
+// the review. e opens the action menu and o opens the change in an editor.
+func (m ConsoleModel) handleChangeNavigationKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	filter := m.tree.filter
+	switch m.keys.action(msg) {
+	case actSideBySide:
+		m.sideBySide = !m.sideBySide
+		if m.showDiff {
+			m.updateDiffContent()
+		}
+		return m, nil
+	case actActions:
+		m.openActionMenu()
+		return m, nil
+	case actEdit:
+		return m, m.openInEditor()
+	case actReview:
+		m.cycleReviewStatus()
+		return m, nil
+	case actNote:
+		m.startNote()
+		return m, nil
+	case actExportReview:
+		m.exportReview()
+		return m, nil
+	case actNextBreaking:
+		return m, m.jumpToBreaking(true)
+	case actPrevBreaking:
+		return m, m.jumpToBreaking(false)
+	case actSearch:
+		m.searching = true
+		m.searchOrigin = m.tree.cursor
+		m.tree.setQuery("")
+		return m, nil
+	case actNextMatch:
+		m.tree.nextMatch()
+		m.syncDiffToTreeCursor()
+		return m, nil
+	case actPrevMatch:
+		m.tree.prevMatch()
+		m.syncDiffToTreeCursor()
+		return m, nil
+	case actFilterBreaking:
+		filter.breaking = !filter.breaking
+	case actFilterAdded:
+		filter.additions = !filter.additions
+	case actFilterModified:
+		filter.modifications = !filter.modifications
+	case actFilterRemoved:
+		filter.removals = !filter.removals
+	case actClearFilters:
+		filter = treeFilter{}
+	default:
+		return m, nil
+	}
+	m.setFilter(filter)
+	return m, nil
+}
+
+// handleSearchKeys handles key events while a search query is being typed. The
+// cursor follows the first match from where the search began; enter keeps the
+// query for n/N, esc drops it and returns the cursor. Typed text is not bound to
+// actions, so these keys are fixed.
+func (m ConsoleModel) handleSearchKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.handleQuit()
+	case "enter":
+		m.searching = false
+		return m, nil
+	case "esc":
+		m.searching = false
+		m.tree.setQuery("")
+		m.tree.cursor = m.searchOrigin
+		m.tree.scrollToCursor()
+		m.syncDiffToTreeCursor()
+		return m, nil
+	case "backspace":
+		query := []rune(m.tree.query)
+		if len(query) == 0 {
+			return m, nil
+		}
+		m.tree.setQuery(string(query[:len(query)-1]))
+	default:
+		if msg.Text == "" {
+			return m, nil
+		}
+		m.tree.setQuery(m.tree.query + msg.Text)
+	}
+	if !m.tree.searchFrom(m.searchOrigin) {
+		m.tree.cursor = m.searchOrigin
+		m.tree.scrollToCursor()
+	}
+	m.syncDiffToTreeCursor()
 	return m, nil
 }
 
 // handleCodeModalKeys handles key events when the code modal is showing.
 // All scrolling goes through m.codeModal.vp so rendering and input share the same state.
 func (m ConsoleModel) handleCodeModalKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q", "enter":
+	if msg.String() == "ctrl+c" {
+		return m.handleQuit()
+	}
+	switch m.keys.action(msg) {
+	case actBack, actQuit, actSelect:
 		m.showCodeModal = false
 		m.focus = m.prevFocus
 		return m, nil
-	case "ctrl+c":
-		return m.handleQuit()
-	case "space":
+	case actRecenter:
 		m.codeModal.recenter()
 		return m, nil
+	case actEdit:
+		return m, m.openInEditor()
 	default:
-		if handleOverlayKeys(&m.codeModal.vp, msg) {
-			return m, nil
-		}
+		m.scrollOverlay(&m.codeModal.vp, msg)
 	}
 	return m, nil
 }
 
 // handleReportModalKeys handles key events when the report modal is showing.
 func (m ConsoleModel) handleReportModalKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q", "enter":
+	if msg.String() == "ctrl+c" {
+		return m.handleQuit()
+	}
+	switch m.keys.action(msg) {
+	case actBack, actQuit, actSelect:
 		m.showReportModal = false
 		m.focus = m.prevFocus
 		return m, nil
-	case "ctrl+c":
-		return m.handleQuit()
 	default:
-		if handleOverlayKeys(&m.reportModal.vp, msg) {
-			return m, nil
-		}
+		m.scrollOverlay(&m.reportModal.vp, msg)
 	}
 	return m, nil
 }
 
-// handleOverlayKeys handles scroll/page/home/end keys shared by modal overlays.
-// Returns true if the key was handled.
-func handleOverlayKeys(vp *viewport.Model, msg tea.KeyPressMsg) bool {
-	switch msg.String() {
-	case "up", "k":
+// scrollOverlay handles the scroll, page, top and bottom keys shared by modal
+// overlays. It reports whether the key was one of them.
+func (m ConsoleModel) scrollOverlay(vp *viewport.Model, msg tea.KeyPressMsg) bool {
+	switch m.keys.action(msg) {
+	case actUp:
 		vp.ScrollUp(1)
-		return true
-	case "down", "j":
+	case actDown:
 		vp.ScrollDown(1)
-		return true
-	case "pgup":
+	case actPageUp:
 		vp.PageUp()
-		return true
-	case "pgdown":
+	case actPageDown:
 		vp.PageDown()
-		return true
-	case "home":
+	case actTop:
 		vp.GotoTop()
-		return true
-	case "end":
+	case actBottom:
 		vp.GotoBottom()
-		return true
+	default:
+		return false
 	}
-	return false
+	return true
 }
@@ -0,0 +1,195 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"sync"
+
+	tea "charm.land/bubbletea/v2"
+	v3 "github.com/pb33f/doctor/model/high/v3"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+)
+
+// defaultCacheSize is how many commits' changerator results are kept when no
+// cache size is configured: the active commit and one either side of it.
+const defaultCacheSize = 3
+
+// commitLoadedMsg carries the result of running the changerator for a commit
+// in the background. A nil entry without an error means there were no changes.
+type commitLoadedMsg struct {
+	hash      string
+	entry     *cacheEntry
+	err       error
+	cancelled bool // the selection moved on before the load started
+}
+
+// commitLoader runs the changerator for commits in the background, one at a
+// time, because breaking rules are applied globally while it runs. Loads for
+// commits that are no longer wanted by the time their turn comes are cancelled.
+// It is shared by every copy of the model.
+type commitLoader struct {
+	run    sync.Mutex // held for the whole of a changerator run
+	mu     sync.Mutex // guards wanted
+	wanted map[string]bool
+}
+
+func newCommitLoader() *commitLoader {
+	return &commitLoader{wanted: make(map[string]bool)}
+}
+
+// want replaces the commits that are wanted: the active commit and those prefetched around it.
+func (l *commitLoader) want(hashes ...string) {
+	wanted := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		wanted[hash] = true
+	}
+	l.mu.Lock()
+	l.wanted = wanted
+	l.mu.Unlock()
+}
+
+func (l *commitLoader) isWanted(hash string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.wanted[hash]
+}
+
+// load runs the changerator for a commit and builds its cache entry.
+func (l *commitLoader) load(commit *model.Commit, runFn RunChangeratorFn,
+	breakingCfg *whatChangedModel.BreakingRulesConfig,
+) commitLoadedMsg {
+	l.run.Lock()
+	defer l.run.Unlock()
+
+	msg := commitLoadedMsg{hash: commit.Hash}
+	if !l.isWanted(commit.Hash) {
+		msg.cancelled = true
+		return msg
+	}
+	ctr, root, releaseFn, err := runFn(commit, breakingCfg)
+	if err != nil {
+		msg.err = err
+		return msg
+	}
+	if ctr == nil {
+		return msg
+	}
+
+	// Build node change tree
+	ctr.BuildNodeChangeTree(root)
+	stats := ctr.Calculatoratron()
+	statsMap := make(map[*v3.Node]nodeStats)
+	if root != nil {
+		computeStats(root, statsMap)
+	}
+	msg.entry = &cacheEntry{
+		result: &changeratorResultV2{
+			Changerator: ctr,
+			RightRoot:   root,
+			releaseFn:   releaseFn,
+		},
+		treeRoot:       root,
+		stats:          stats,
+		nodeStatsCache: statsMap,
+		newLines:       splitLines(commit.Data),
+		oldLines:       splitLines(commit.OldData),
+	}
+	return msg
+}
+
+// changerateCmd returns a command that loads a commit in the background.
+func (m *ConsoleModel) changerateCmd(commit *model.Commit) tea.Cmd {
+	m.pending[commit.Hash] = true
+	loader, runFn, breakingCfg := m.loader, m.runFn, m.breakingCfg
+	return func() tea.Msg {
+		return loader.load(commit, runFn, breakingCfg)
+	}
+}
+
+// prefetchIndexes returns the commits either side of the active one that are
+// loaded ahead of being selected, next first. The cache keeps room for the
+// active commit, so a smaller cache prefetches fewer commits.
+func (m ConsoleModel) prefetchIndexes() []int {
+	var indexes []int
+	for _, idx := range []int{m.activeIdx + 1, m.activeIdx - 1} {
+		if idx >= 0 && idx < len(m.commits) && len(indexes) < m.cache.maxSize-1 {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes
+}
+
+// wantCommits tells the loader which commits the selection wants loaded,
+// which cancels the queued loads of any others.
+func (m *ConsoleModel) wantCommits() {
+	hashes := []string{m.activeCommit().Hash}
+	for _, idx := range m.prefetchIndexes() {
+		hashes = append(hashes, m.commits[idx].Hash)
+	}
+	m.loader.want(hashes...)
+}
+
+// prefetchCmd loads the commits around the active one that are neither cached nor loading.
+func (m *ConsoleModel) prefetchCmd() tea.Cmd {
+	if m.runFn == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, idx := range m.prefetchIndexes() {
+		commit := m.commits[idx]
+		if _, cached := m.cache.entries[commit.Hash]; cached || m.pending[commit.Hash] {
+			continue
+		}
+		cmds = append(cmds, m.changerateCmd(commit))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleCommitLoaded caches a loaded commit and, when it is the commit waiting
+// to be shown, shows it. Results for commits the selection has moved away from
+// are released rather than cached, so that they cannot evict wanted commits.
+func (m *ConsoleModel) handleCommitLoaded(msg commitLoadedMsg) tea.Cmd {
+	delete(m.pending, msg.hash)
+	waiting := m.loading && msg.hash == m.activeHash
+	if msg.cancelled {
+		if waiting {
+			// cancelled before the selection came back to it
+			return m.loadActiveCommit()
+		}
+		return nil
+	}
+	if msg.entry != nil {
+		cached, isCached := m.cache.entries[msg.hash]
+		if isCached || !m.loader.isWanted(msg.hash) {
+			if msg.entry.result != nil {
+				msg.entry.result.Release()
+			}
+			msg.entry = cached
+		} else {
+			m.cache.put(msg.hash, msg.entry)
+			m.refreshCommitRows()
+		}
+	}
+	if !waiting {
+		return nil
+	}
+
+	m.loading = false
+	switch {
+	case msg.err != nil:
+		m.showEmptyState("Error: " + msg.err.Error())
+	case msg.entry == nil:
+		m.showEmptyState("No changes detected in this commit")
+	default:
+		m.applyCache(msg.entry)
+	}
+	switch {
+	case m.jump != nil && m.jump.returning:
+		m.returnJump()
+	case m.jump != nil && !m.landJump():
+		return tea.Batch(m.continueJump(), m.prefetchCmd())
+	}
+	return m.prefetchCmd()
+}
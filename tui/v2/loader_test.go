@@ -0,0 +1,199 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+	"github.com/pb33f/doctor/changerator"
+	v3 "github.com/pb33f/doctor/model/high/v3"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCmds runs a command and everything it leads to, feeding each message to the
+// model, as the bubbletea runtime would. Spinner ticks are dropped, as they only
+// animate and would otherwise keep ticking.
+func runCmds(t *testing.T, m ConsoleModel, cmd tea.Cmd) ConsoleModel {
+	t.Helper()
+	queue := []tea.Cmd{cmd}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next == nil {
+			continue
+		}
+		switch msg := next().(type) {
+		case tea.BatchMsg:
+			queue = append(queue, msg...)
+		case spinner.TickMsg:
+		default:
+			result, cmd := m.Update(msg)
+			m = result.(ConsoleModel)
+			queue = append(queue, cmd)
+		}
+	}
+	return m
+}
+
+// recordingRunFn is a changerator that records the commits it runs for. Commits
+// whose hash starts with "err" fail; the rest have no changes.
+type recordingRunFn struct {
+	mu     sync.Mutex
+	hashes []string
+}
+
+func (r *recordingRunFn) run(commit *model.Commit, _ *whatChangedModel.BreakingRulesConfig) (*changerator.Changerator, *v3.Node, func(), error) {
+	r.mu.Lock()
+	r.hashes = append(r.hashes, commit.Hash)
+	r.mu.Unlock()
+	if len(commit.Hash) >= 3 && commit.Hash[:3] == "err" {
+		return nil, nil, nil, errors.New("broken spec")
+	}
+	return nil, nil, nil, nil
+}
+
+func makeHistoryCommits(hashes ...string) []*model.Commit {
+	commits := make([]*model.Commit, 0, len(hashes))
+	for _, hash := range hashes {
+		commits = append(commits, &model.Commit{Hash: hash, Message: hash, CommitDate: time.Now()})
+	}
+	return commits
+}
+
+func TestCommitLoader_CancelsUnwantedLoads(t *testing.T) {
+	recorder := &recordingRunFn{}
+	loader := newCommitLoader()
+	loader.want("abc123")
+
+	msg := loader.load(&model.Commit{Hash: "def456"}, recorder.run, nil)
+	assert.True(t, msg.cancelled)
+	assert.Empty(t, recorder.hashes)
+
+	msg = loader.load(&model.Commit{Hash: "abc123"}, recorder.run, nil)
+	assert.False(t, msg.cancelled)
+	assert.Nil(t, msg.entry)
+	assert.Equal(t, []string{"abc123"}, recorder.hashes)
+}
+
+func TestCommitLoader_RunsOneAtATime(t *testing.T) {
+	var running, most atomic.Int32
+	runFn := func(_ *model.Commit, _ *whatChangedModel.BreakingRulesConfig) (*changerator.Changerator, *v3.Node, func(), error) {
+		n := running.Add(1)
+		if n > most.Load() {
+			most.Store(n)
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return nil, nil, nil, nil
+	}
+	loader := newCommitLoader()
+	loader.want("a", "b", "c", "d")
+
+	var wg sync.WaitGroup
+	for _, hash := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loader.load(&model.Commit{Hash: hash}, runFn, nil)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), most.Load())
+}
+
+func TestConsoleModel_LoadsInBackgroundAndPrefetches(t *testing.T) {
+	recorder := &recordingRunFn{}
+	m := NewConsoleModel(makeHistoryCommits("abc123", "err456", "ghi789"), nil, true, "test", recorder.run)
+	m.width, m.height = 120, 40
+	require.True(t, m.loading)
+	assert.Empty(t, recorder.hashes, "nothing runs until the program starts")
+	assert.Contains(t, m.renderBaseView(), "Loading commit abc123")
+
+	m = runCmds(t, m, m.Init())
+	assert.False(t, m.loading)
+	assert.Equal(t, "No changes detected in this commit", m.emptyState)
+	// the next commit is prefetched once the first has loaded
+	assert.Equal(t, []string{"abc123", "err456"}, recorder.hashes)
+	assert.Empty(t, m.pending)
+
+	m.focus = FocusCommitTable
+	result, cmd := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "j"}))
+	m = result.(ConsoleModel)
+	require.True(t, m.loading, "results with no changes are not cached")
+	m = runCmds(t, m, cmd)
+	assert.Equal(t, "Error: broken spec", m.emptyState)
+	assert.Equal(t, []string{"abc123", "err456", "err456", "ghi789", "abc123"}, recorder.hashes)
+}
+
+func TestConsoleModel_MovingOnCancelsQueuedLoads(t *testing.T) {
+	recorder := &recordingRunFn{}
+	m := NewConsoleModel(makeHistoryCommits("a1", "b2", "c3", "d4"), nil, true, "test", recorder.run)
+	m.focus = FocusCommitTable
+	initCmd := m.Init()
+
+	// move two commits down before anything has run
+	result, cmd1 := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "j"}))
+	m = result.(ConsoleModel)
+	result, cmd2 := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "j"}))
+	m = result.(ConsoleModel)
+	require.Equal(t, "c3", m.activeHash)
+
+	m = runCmds(t, m, tea.Batch(initCmd, cmd1, cmd2))
+	assert.NotContains(t, recorder.hashes, "a1", "the first commit was no longer wanted")
+	assert.Contains(t, recorder.hashes, "c3")
+	assert.False(t, m.loading)
+	assert.Empty(t, m.pending)
+}
+
+func TestConsoleModel_CachesWantedResultsOnly(t *testing.T) {
+	m := NewConsoleModel(makeHistoryCommits("a1", "b2", "c3", "d4"), nil, true, "test", (&recordingRunFn{}).run)
+	m.width, m.height = 120, 40
+
+	released := false
+	entry := func() *cacheEntry {
+		return &cacheEntry{
+			treeRoot: makeTestTree(),
+			result:   &changeratorResultV2{releaseFn: func() { released = true }},
+		}
+	}
+
+	// the first commit is shown once it has loaded
+	result, _ := m.Update(commitLoadedMsg{hash: "a1", entry: entry()})
+	m = result.(ConsoleModel)
+	assert.False(t, m.loading)
+	assert.NotEmpty(t, m.tree.entries)
+	assert.NotNil(t, m.cache.entries["a1"])
+
+	// a prefetched neighbour is cached
+	result, _ = m.Update(commitLoadedMsg{hash: "b2", entry: entry()})
+	m = result.(ConsoleModel)
+	assert.NotNil(t, m.cache.entries["b2"])
+	assert.False(t, released)
+
+	// a commit the selection has moved away from is released
+	result, _ = m.Update(commitLoadedMsg{hash: "d4", entry: entry()})
+	m = result.(ConsoleModel)
+	assert.Nil(t, m.cache.entries["d4"])
+	assert.True(t, released)
+}
+
+func TestConsoleModel_WithCacheSize(t *testing.T) {
+	m := NewConsoleModel(makeHistoryCommits("a1", "b2", "c3"), nil, true, "test", (&recordingRunFn{}).run)
+	m.activeIdx = 1
+
+	assert.Equal(t, []int{2, 0}, m.prefetchIndexes())
+	assert.Equal(t, []int{2}, m.WithCacheSize(2).prefetchIndexes())
+	assert.Empty(t, m.WithCacheSize(1).prefetchIndexes())
+	assert.Equal(t, 1, m.WithCacheSize(0).cache.maxSize)
+	assert.Equal(t, 10, m.WithCacheSize(10).cache.maxSize)
+}
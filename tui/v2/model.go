@@ -8,6 +8,7 @@ import (
 	"strings"
 	"unicode/utf8"
 
+	"charm.land/bubbles/v2/spinner"
 	"charm.land/bubbles/v2/table"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
@@ -17,6 +18,8 @@ import (
 	v3 "github.com/pb33f/doctor/model/high/v3"
 	"github.com/pb33f/doctor/terminal"
 	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/review"
+	"github.com/pb33f/openapi-changes/internal/severity"
 	"github.com/pb33f/openapi-changes/model"
 )
 
@@ -33,8 +36,10 @@ const (
 
 // ConsoleModel is the top-level Bubbletea model for the console command.
 type ConsoleModel struct {
-	// Bubbles components
+	// Bubbles components — commitView draws the rows of commitTable from
+	// tableOffset, and is kept in step with it by showCommitRows.
 	commitTable  table.Model
+	commitView   table.Model
 	diffViewport viewport.Model
 
 	// Custom tree
@@ -48,11 +53,23 @@ type ConsoleModel struct {
 	reportModal     reportModal
 	showReportModal bool
 
+	// Action menu — copy and export actions for the active change and commit
+	actionMenu     actionMenu
+	showActionMenu bool
+
+	// Help overlay — the key bindings of keys, which every key handler dispatches on
+	helpModal helpModal
+	showHelp  bool
+	keys      KeyMap
+
 	// Data
-	commits     []*model.Commit
-	cache       *commitCache
-	breakingCfg *whatChangedModel.BreakingRulesConfig
-	runFn       RunChangeratorFn
+	commits        []*model.Commit
+	cache          *commitCache
+	breakingCfg    *whatChangedModel.BreakingRulesConfig
+	severities     severity.Config
+	runFn          RunChangeratorFn
+	jsonReportFn   JSONReportFn
+	editorTargetFn EditorTargetFn
 
 	// UI state
 	focus          PanelFocus
@@ -68,6 +85,54 @@ type ConsoleModel struct {
 	activeIdx      int
 	emptyState     string
 
+	// Layout — treeSplit is the percentage of the width the tree panel takes, which
+	// the keyboard and dragging the border between the panels change; dragging is
+	// set while the border is held. tableCollapsed shrinks the commit table to a
+	// line showing the active commit, and tableOffset is the first commit the
+	// table shows. mouse is set while the console captures the mouse, which
+	// stops the terminal from selecting text.
+	treeSplit      int
+	dragging       bool
+	tableCollapsed bool
+	tableOffset    int
+	mouse          bool
+
+	// Side-by-side diff — sideBySide is the layout asked for with the toggle key;
+	// diffSideBySide is whether it is shown, as narrow panels fall back to one column.
+	sideBySide     bool
+	diffSideBySide bool
+
+	// Search input — the query lives on the tree; searchOrigin is where the cursor
+	// was when the search began, so that cancelling can return it there.
+	searching    bool
+	searchOrigin int
+
+	// Review marks — noting is set while the note of the change under the cursor
+	// is being typed into noteInput. notice is a message for the nav bar that the
+	// next key press clears.
+	reviews   *review.Store
+	noting    bool
+	noteInput string
+	notice    string
+
+	// Comparison — compareFrom and compareTo are the commit table rows marked as the
+	// old and new side of a comparison, or -1. comparison is the synthetic commit
+	// between them, which is shown in place of the active commit until another
+	// commit is selected.
+	compareFrom int
+	compareTo   int
+	comparison  *model.Commit
+
+	// Background loading — loading is set while the active commit loads, pending
+	// holds the commits with a load in flight, and jump is a breaking change jump
+	// waiting for a commit to load.
+	loader  *commitLoader
+	pending map[string]bool
+	loading bool
+	spinner spinner.Model
+	jump    *breakingJump
+	initCmd tea.Cmd
+
 	// Diff panel summary header (rendered above viewport)
 	diffSummary  string // pre-rendered diff summary for right panel fixed header
 	diffSummaryH int    // line count of summary
@@ -100,11 +165,19 @@ func NewConsoleModel(commits []*model.Commit, breakingConfig *whatChangedModel.B
 
 	m := ConsoleModel{
 		commits:     commits,
-		cache:       newCommitCache(3),
+		cache:       newCommitCache(defaultCacheSize),
 		breakingCfg: breakingConfig,
 		runFn:       runFn,
 		styles:      styles,
 		palette:     palette,
+		treeSplit:   defaultTreeSplit,
+		keys:        defaultKeyMap(),
+		loader:      newCommitLoader(),
+		pending:     make(map[string]bool),
+		reviews:     review.NewStore(""),
+		compareFrom: -1,
+		compareTo:   -1,
+		spinner:     spinner.New(spinner.WithSpinner(spinner.MiniDot), spinner.WithStyle(styles.nav)),
 	}
 
 	// Single commit mode
@@ -115,6 +188,8 @@ func NewConsoleModel(commits []*model.Commit, breakingConfig *whatChangedModel.B
 
 	// Build commit table (default width, resized on first WindowSizeMsg)
 	m.commitTable = buildCommitTable(commits, 120, 0, styles)
+	m.commitView = buildCommitTable(nil, 120, 0, styles)
+	m.showCommitRows()
 
 	// Initialize tree with placeholder height (will be recalculated on WindowSizeMsg)
 	m.tree = newTreeModel(nil, 20)
@@ -122,12 +197,12 @@ func NewConsoleModel(commits []*model.Commit, breakingConfig *whatChangedModel.B
 	// Initialize diff viewport
 	m.diffViewport = viewport.New(viewport.WithWidth(80), viewport.WithHeight(10))
 
-	// Load first commit synchronously
+	// Start loading the first commit; Init runs the load in the background
 	if len(commits) > 0 {
 		m.activeIdx = 0
 		m.highlightedIdx = 0
 		m.activeHash = commits[0].Hash
-		m.loadActiveCommit()
+		m.initCmd = m.loadActiveCommit()
 	} else {
 		m.emptyState = "No commits to display"
 	}
@@ -135,9 +210,73 @@ func NewConsoleModel(commits []*model.Commit, breakingConfig *whatChangedModel.B
 	return m
 }
 
-// Init implements tea.Model.
+// WithSeverities returns the model with the change severities from the rules
+// config, which decide how each change in the tree is styled.
+func (m ConsoleModel) WithSeverities(severities severity.Config) ConsoleModel {
+	m.severities = severities
+	m.tree.severities = severities
+	return m
+}
+
+// WithCacheSize returns the model with room in the commit cache for size
+// commits' changerator results. The default is 3. Commits either side of the
+// active one are prefetched while there is room for them.
+func (m ConsoleModel) WithCacheSize(size int) ConsoleModel {
+	m.cache = newCommitCache(max(size, 1))
+	if len(m.commits) > 0 {
+		m.wantCommits()
+	}
+	return m
+}
+
+// WithReviews returns the model with a store of review marks, which the tree
+// shows as badges and which saves the marks made in the console. Without one,
+// marks are kept in memory only.
+func (m ConsoleModel) WithReviews(reviews *review.Store) ConsoleModel {
+	m.reviews = reviews
+	m.tree.reviews = reviews
+	return m
+}
+
+// WithJSONReports returns the model with a function that builds the JSON report
+// of a commit, which the action menu exports. Without one, only the markdown
+// report can be exported.
+func (m ConsoleModel) WithJSONReports(fn JSONReportFn) ConsoleModel {
+	m.jsonReportFn = fn
+	return m
+}
+
+// WithEditorTargets returns the model with a function that resolves where a
+// change is opened in an editor. Without one, changes cannot be opened.
+func (m ConsoleModel) WithEditorTargets(fn EditorTargetFn) ConsoleModel {
+	m.editorTargetFn = fn
+	return m
+}
+
+// WithSideBySide returns the model with the side-by-side diff turned on or
+// off. It is off by default; the toggle key switches it either way.
+func (m ConsoleModel) WithSideBySide(on bool) ConsoleModel {
+	m.sideBySide = on
+	return m
+}
+
+// WithMouse returns the model with mouse capture turned on or off. It is off by
+// default, which leaves the mouse to the terminal for selecting text; the toggle
+// key switches it either way.
+func (m ConsoleModel) WithMouse(on bool) ConsoleModel {
+	m.mouse = on
+	return m
+}
+
+// WithKeyMap returns the model with its key bindings replaced, as built by NewKeyMap.
+func (m ConsoleModel) WithKeyMap(keys KeyMap) ConsoleModel {
+	m.keys = keys
+	return m
+}
+
+// Init implements tea.Model. It starts loading the first commit.
 func (m ConsoleModel) Init() tea.Cmd {
-	return nil
+	return m.initCmd
 }
 
 // Update implements tea.Model.
@@ -149,13 +288,59 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.recalculateLayout()
 		return m, nil
 
+	case commitLoadedMsg:
+		return m, m.handleCommitLoaded(msg)
+
+	case actionDoneMsg:
+		m.notice = msg.notice
+		return m, nil
+
+	case editorClosedMsg:
+		if msg.err != nil {
+			m.notice = "Error: editor: " + msg.err.Error()
+		}
+		return m, nil
+
+	case tea.MouseClickMsg, tea.MouseReleaseMsg, tea.MouseWheelMsg, tea.MouseMotionMsg:
+		return m, m.handleMouse(msg.(tea.MouseMsg))
+
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
 	case tea.KeyPressMsg:
+		// any key press ends a breaking change jump still waiting for a commit to load
+		m.jump = nil
+		m.notice = ""
+		if m.showHelp {
+			return m.handleHelpKeys(msg)
+		}
 		if m.showReportModal {
 			return m.handleReportModalKeys(msg)
 		}
 		if m.showCodeModal {
 			return m.handleCodeModalKeys(msg)
 		}
+		if m.showActionMenu {
+			return m.handleActionMenuKeys(msg)
+		}
+		if m.searching {
+			return m.handleSearchKeys(msg)
+		}
+		if m.noting {
+			return m.handleNoteKeys(msg)
+		}
+		if m.keys.is(msg, actHelp) {
+			m.openHelp()
+			return m, nil
+		}
+		if m.handleLayoutKeys(msg) {
+			return m, nil
+		}
 		switch m.focus {
 		case FocusCommitTable:
 			return m.handleCommitTableKeys(msg)
@@ -171,6 +356,9 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View implements tea.Model.
 func (m ConsoleModel) View() tea.View {
 	v := tea.View{AltScreen: true}
+	if m.mouse {
+		v.MouseMode = tea.MouseModeCellMotion
+	}
 
 	if m.width == 0 || m.height == 0 {
 		v.Content = "Initializing..."
@@ -179,12 +367,18 @@ func (m ConsoleModel) View() tea.View {
 
 	baseView := m.renderBaseView()
 
-	if m.showReportModal {
+	if m.showHelp {
+		x, y := m.overlayPosition(m.helpModal.width, m.helpModal.height)
+		v.Content = renderOverlay(baseView, m.helpModal.View(m.styles, m.keys), x, y)
+	} else if m.showReportModal {
 		x, y := m.overlayPosition(m.reportModal.width, m.reportModal.height)
-		v.Content = renderOverlay(baseView, m.reportModal.View(m.styles), x, y)
+		v.Content = renderOverlay(baseView, m.reportModal.View(m.styles, m.keys), x, y)
 	} else if m.showCodeModal {
 		x, y := m.overlayPosition(m.codeModal.width, m.codeModal.height)
-		v.Content = renderOverlay(baseView, m.codeModal.View(m.styles), x, y)
+		v.Content = renderOverlay(baseView, m.codeModal.View(m.styles, m.keys), x, y)
+	} else if m.showActionMenu {
+		x, y := m.overlayPosition(actionMenuWidth, m.actionMenu.height())
+		v.Content = renderOverlay(baseView, m.actionMenu.View(m.styles, m.keys), x, y)
 	} else {
 		v.Content = baseView
 	}
@@ -201,7 +395,10 @@ func (m ConsoleModel) renderBaseView() string {
 	if !m.singleCommit {
 		tableH := m.tableHeight()
 		panelStyle := m.panelBorder(FocusCommitTable)
-		tableView := m.commitTable.View()
+		tableView := m.commitView.View()
+		if m.tableCollapsed {
+			tableView = m.renderCollapsedTable()
+		}
 		sb.WriteString(panelStyle.Width(m.width).Height(tableH).Render(tableView))
 		sb.WriteByte('\n')
 	}
@@ -213,7 +410,13 @@ func (m ConsoleModel) renderBaseView() string {
 	treePanelStyle := m.panelBorder(FocusTree)
 	treeShowCursor := m.focus == FocusTree || m.focus == FocusDiff || m.showDiff
 	treeContent := m.tree.View(treeW-2, treeShowCursor, m.styles)
-	if m.emptyState != "" {
+	if m.loading {
+		loading := " Loading commit " + shortHash(m.activeHash) + "…"
+		if m.comparison != nil {
+			loading = " Loading comparison " + m.comparison.Hash + "…"
+		}
+		treeContent = "  " + m.spinner.View() + m.styles.grey.Render(loading)
+	} else if m.emptyState != "" {
 		treeContent = m.styles.grey.Render("  " + m.emptyState)
 	}
 	leftPanel := treePanelStyle.Width(treeW).Height(bottomH).Render(treeContent)
@@ -284,10 +487,13 @@ func modalDimensions(termWidth, termHeight int) (modalW, modalH, contentW int) {
 // Height functions return the total height (for lipgloss Height()).
 // Internal components need the content height = total - 2 (border top + bottom).
 func (m *ConsoleModel) recalculateLayout() {
-	if !m.singleCommit {
+	if !m.singleCommit && !m.tableCollapsed {
 		tableContent := m.tableHeight() - 2
 		m.commitTable.SetHeight(tableContent - 1) // -1 more for header row
-		resizeCommitTable(&m.commitTable, m.commits, m.highlightedIdx, m.width, m.styles)
+		resizeCommitTable(&m.commitTable, m.commitRows(), m.width, m.styles)
+		resizeCommitTable(&m.commitView, nil, m.width, m.styles)
+		m.commitView.SetHeight(tableContent) // the header row is drawn as well
+		m.scrollCommitTable()
 	}
 
 	bottomH := m.bottomHeight()
@@ -304,8 +510,9 @@ func (m *ConsoleModel) recalculateLayout() {
 	m.diffViewport.SetHeight(vpH)
 	m.diffViewport.SetWidth(diffW - 2)
 
-	// Render diff content that was deferred before layout was ready.
-	if m.pendingDiff {
+	// Render diff content that was deferred before layout was ready, or that
+	// switches between the side-by-side and single-column layouts at this width.
+	if m.pendingDiff || (m.showDiff && m.diffSideBySide != m.canShowSideBySide()) {
 		m.pendingDiff = false
 		m.updateDiffContent()
 	}
@@ -314,6 +521,9 @@ func (m *ConsoleModel) recalculateLayout() {
 	if m.showCodeModal {
 		m.rebuildCodeModal()
 	}
+	if m.showHelp {
+		m.openHelp()
+	}
 }
 
 // Layout: all height functions return the value passed to lipgloss Height(),
@@ -325,6 +535,9 @@ func (m ConsoleModel) tableHeight() int {
 	if m.singleCommit {
 		return 0
 	}
+	if m.tableCollapsed {
+		return collapsedTableHeight
+	}
 	// Fixed: content = header + rows + padding, plus 2 for border.
 	h := len(m.commits) + 4 + 2
 	if h < 10 {
@@ -345,7 +558,9 @@ func (m ConsoleModel) bottomHeight() int {
 }
 
 func (m ConsoleModel) splitWidths() (treeW, diffW int) {
-	treeW = m.width / 2
+	treeW = m.width * m.treeSplit / 100
+	// neither panel gets narrower than its minimum, unless the terminal is too narrow for both
+	treeW = max(min(treeW, m.width-minPanelWidth), min(minPanelWidth, m.width/2))
 	diffW = m.width - treeW // handles odd widths
 	return
 }
@@ -359,99 +574,178 @@ func (m ConsoleModel) panelBorder(panel PanelFocus) lipgloss.Style {
 
 func (m ConsoleModel) renderNavBar() string {
 	s := m.styles
-	type hint struct{ key, label string }
-	items := []hint{
-		{"↑↓", "navigate"}, {"enter", "view"}, {"r", "report"},
-		{"esc", "back"}, {"tab", "switch"}, {"q", "quit"},
-	}
-
 	var sb strings.Builder
 	sb.WriteByte(' ')
 
-	if len(m.commits) > 0 {
+	if m.searching {
+		sb.WriteString(s.helpKey.Render("/" + m.tree.query + "▏"))
+		sb.WriteString("  ")
+		sb.WriteString(s.nav.Render(m.searchStatus()))
+		sb.WriteString("  ")
+		sb.WriteString(s.renderHotkey("enter"))
+		sb.WriteString(s.renderLabel("done"))
+		sb.WriteString("  ")
+		sb.WriteString(s.renderHotkey("esc"))
+		sb.WriteString(s.renderLabel("cancel"))
+		return sb.String()
+	}
+
+	if m.noting {
+		sb.WriteString(s.helpKey.Render("note: " + m.noteInput + "▏"))
+		sb.WriteString("  ")
+		sb.WriteString(s.renderHotkey("enter"))
+		sb.WriteString(s.renderLabel("save"))
+		sb.WriteString("  ")
+		sb.WriteString(s.renderHotkey("esc"))
+		sb.WriteString(s.renderLabel("cancel"))
+		return sb.String()
+	}
+
+	if m.comparison != nil {
+		sb.WriteString(s.nav.Render("comparing " + m.comparison.Hash))
+		sb.WriteString("  ")
+	} else if len(m.commits) > 0 {
 		sb.WriteString(s.nav.Render(fmt.Sprintf("%d/%d", m.activeIdx+1, len(m.commits))))
 		sb.WriteString("  ")
 	}
+	if m.tree.query != "" {
+		sb.WriteString(s.nav.Render("/" + m.tree.query + " " + m.searchStatus()))
+		sb.WriteString("  ")
+	}
+	if m.tree.filter.active() {
+		sb.WriteString(s.nav.Render("filter: " + m.tree.filter.String()))
+		sb.WriteString("  ")
+	}
+	if m.notice != "" {
+		sb.WriteString(s.nav.Render(m.notice))
+		sb.WriteString("  ")
+	}
+
+	sb.WriteString(m.keys.hints(s, m.footerHints()...))
+
+	return sb.String()
+}
 
-	for i, item := range items {
-		if i > 0 {
-			sb.WriteString("  ")
+// footerHints returns the hints the nav bar shows for the focused panel. The
+// help overlay lists every key binding.
+func (m ConsoleModel) footerHints() []keyHint {
+	layout := "split"
+	if m.diffSideBySide {
+		layout = "unified"
+	}
+	switch m.focus {
+	case FocusCommitTable:
+		return []keyHint{
+			{[]keyAction{actUp, actDown}, "navigate"},
+			{[]keyAction{actSelect}, "changes"},
+			{[]keyAction{actCompareFrom, actCompareTo}, "compare"},
+			{[]keyAction{actReport}, "report"},
+			{[]keyAction{actToggleCommits}, "collapse"},
+			{[]keyAction{actSwitchPanel}, "switch"},
+			{[]keyAction{actHelp}, "help"},
+			{[]keyAction{actQuit}, "quit"},
+		}
+	case FocusDiff:
+		return []keyHint{
+			{[]keyAction{actUp, actDown}, "changes"},
+			{[]keyAction{actPageUp, actPageDown}, "scroll"},
+			{[]keyAction{actSelect}, "view"},
+			{[]keyAction{actPrevBreaking, actNextBreaking}, "breaking"},
+			{[]keyAction{actSideBySide}, layout},
+			{[]keyAction{actShrinkTree, actGrowTree}, "resize"},
+			{[]keyAction{actReview, actNote}, "review"},
+			{[]keyAction{actActions}, "actions"},
+			{[]keyAction{actEdit}, "edit"},
+			{[]keyAction{actBack}, "back"},
+			{[]keyAction{actHelp}, "help"},
+			{[]keyAction{actQuit}, "quit"},
+		}
+	default:
+		return []keyHint{
+			{[]keyAction{actUp, actDown}, "navigate"},
+			{[]keyAction{actSelect}, "view"},
+			{[]keyAction{actPrevBreaking, actNextBreaking}, "breaking"},
+			{[]keyAction{actSearch}, "search"},
+			{[]keyAction{actFilterBreaking, actFilterAdded, actFilterModified, actFilterRemoved}, "filter"},
+			{[]keyAction{actSideBySide}, layout},
+			{[]keyAction{actReview, actNote}, "review"},
+			{[]keyAction{actActions}, "actions"},
+			{[]keyAction{actEdit}, "edit"},
+			{[]keyAction{actBack}, "back"},
+			{[]keyAction{actSwitchPanel}, "switch"},
+			{[]keyAction{actHelp}, "help"},
+			{[]keyAction{actQuit}, "quit"},
 		}
-		sb.WriteString(s.renderHotkey(item.key))
-		sb.WriteString(s.renderLabel(item.label))
 	}
+}
 
-	return sb.String()
+// searchStatus describes where the cursor is among the search matches.
+func (m ConsoleModel) searchStatus() string {
+	if len(m.tree.matches) == 0 {
+		if m.tree.query == "" {
+			return ""
+		}
+		return "no matches"
+	}
+	return fmt.Sprintf("%d/%d", m.tree.matchPosition(), len(m.tree.matches))
 }
 
-// loadActiveCommit runs the changerator for the active commit and updates the tree.
-func (m *ConsoleModel) loadActiveCommit() {
-	if m.activeIdx < 0 || m.activeIdx >= len(m.commits) {
+// loadActiveCommit shows the active commit straight from the cache, or starts
+// loading it in the background and shows a spinner until it has loaded. Either
+// way, the commits around it are prefetched.
+func (m *ConsoleModel) loadActiveCommit() tea.Cmd {
+	commit := m.activeCommit()
+	if commit == nil {
 		m.emptyState = "No commit selected"
-		return
+		return nil
 	}
-
-	commit := m.commits[m.activeIdx]
+	m.wantCommits()
 
 	// Check cache
 	if entry := m.cache.get(commit.Hash); entry != nil {
+		m.loading = false
 		m.applyCache(entry)
-		return
+		return m.prefetchCmd()
 	}
 
 	if m.runFn == nil {
 		m.emptyState = "Changerator not configured"
-		return
-	}
-
-	ctr, root, releaseFn, err := m.runFn(commit, m.breakingCfg)
-	if err != nil {
-		m.emptyState = fmt.Sprintf("Error: %s", err)
-		m.tree = newTreeModel(nil, m.tree.height)
-		m.showDiff = false
-		m.diffSummary = ""
-		m.diffSummaryH = 0
-		return
-	}
-	if ctr == nil {
-		m.emptyState = "No changes detected in this commit"
-		m.tree = newTreeModel(nil, m.tree.height)
-		m.showDiff = false
-		m.diffSummary = ""
-		m.diffSummaryH = 0
-		return
+		return nil
 	}
 
-	// Build node change tree
-	ctr.BuildNodeChangeTree(root)
-	stats := ctr.Calculatoratron()
-	statsMap := make(map[*v3.Node]nodeStats)
-	if root != nil {
-		computeStats(root, statsMap)
+	m.showEmptyState("")
+	m.loading = true
+	cmds := []tea.Cmd{m.spinner.Tick}
+	if !m.pending[commit.Hash] {
+		cmds = append(cmds, m.changerateCmd(commit))
 	}
+	return tea.Batch(cmds...)
+}
 
-	// Cache the result
-	entry := &cacheEntry{
-		result: &changeratorResultV2{
-			Changerator: ctr,
-			RightRoot:   root,
-			releaseFn:   releaseFn,
-		},
-		treeRoot:       root,
-		stats:          stats,
-		nodeStatsCache: statsMap,
-		newLines:       splitLines(commit.Data),
-		oldLines:       splitLines(commit.OldData),
-	}
-	m.cache.put(commit.Hash, entry)
-	m.applyCache(entry)
+// showEmptyState empties the tree and the diff, and shows a message in place of
+// the tree. The filter and search query are kept for the next commit.
+func (m *ConsoleModel) showEmptyState(state string) {
+	m.emptyState = state
+	filter, query := m.tree.filter, m.tree.query
+	m.tree = newTreeModel(nil, m.tree.height)
+	m.tree.filter, m.tree.query = filter, query
+	m.showDiff = false
+	m.activeChange = nil
+	m.diffSummary = ""
+	m.diffSummaryH = 0
 }
 
 func (m *ConsoleModel) applyCache(entry *cacheEntry) {
 	m.emptyState = ""
+	// The filter and search query carry over from one commit to the next.
+	filter, query := m.tree.filter, m.tree.query
 	m.tree = newTreeModel(entry.treeRoot, m.tree.height)
+	m.tree.severities = m.severities
+	m.tree.reviews = m.reviews
 	// TODO: wire stats into renderNode for badge display on branch nodes.
-	if entry.nodeStatsCache != nil {
+	if filter.active() {
+		m.tree.setFilter(filter)
+	} else if entry.nodeStatsCache != nil {
 		m.tree.statsCache = entry.nodeStatsCache
 	} else {
 		m.tree.statsCache = make(map[*v3.Node]nodeStats)
@@ -459,6 +753,7 @@ func (m *ConsoleModel) applyCache(entry *cacheEntry) {
 			computeStats(entry.treeRoot, m.tree.statsCache)
 		}
 	}
+	m.tree.setQuery(query)
 	m.showDiff = false
 	m.activeChange = nil
 	m.diffSummary = ""
@@ -469,19 +764,263 @@ func (m *ConsoleModel) applyCache(entry *cacheEntry) {
 }
 
 // selectHighlightedCommit syncs activeIdx to the table cursor and loads the commit.
-func (m *ConsoleModel) selectHighlightedCommit() {
+func (m *ConsoleModel) selectHighlightedCommit() tea.Cmd {
 	row := m.commitTable.Cursor()
 	if row < 0 || row >= len(m.commits) {
-		return
+		return nil
 	}
 	m.highlightedIdx = row
-	m.commitTable.SetRows(buildCommitRows(m.commits, m.highlightedIdx, m.styles))
-	if row == m.activeIdx {
-		return // already loaded
+	m.scrollCommitTable()
+	var cmd tea.Cmd
+	if row != m.activeIdx || m.comparison != nil {
+		m.comparison = nil
+		m.activeIdx = row
+		m.activeHash = m.commits[row].Hash
+		cmd = m.loadActiveCommit()
+	}
+	m.refreshCommitRows()
+	return cmd
+}
+
+// selectCommit moves the commit table cursor to a commit and loads it.
+func (m *ConsoleModel) selectCommit(idx int) tea.Cmd {
+	m.commitTable.SetCursor(idx)
+	return m.selectHighlightedCommit()
+}
+
+// scrollCommitTable scrolls the commit table just enough to show the commit
+// under its cursor, keeping the table full.
+func (m *ConsoleModel) scrollCommitTable() {
+	cursor, height := m.commitTable.Cursor(), m.commitTable.Height()
+	if cursor < m.tableOffset {
+		m.tableOffset = cursor
+	}
+	if height > 0 && cursor >= m.tableOffset+height {
+		m.tableOffset = cursor - height + 1
+	}
+	m.tableOffset = max(0, min(m.tableOffset, len(m.commits)-height))
+	m.showCommitRows()
+}
+
+// showCommitRows gives commitView the rows of the commit table from tableOffset.
+// The table scrolls itself as its cursor moves, but does not expose where it has
+// scrolled to, so only the rows that are shown are drawn, which a click can be
+// mapped back to.
+func (m *ConsoleModel) showCommitRows() {
+	rows := m.commitTable.Rows()
+	end := min(m.tableOffset+m.commitTable.Height(), len(rows))
+	start := min(m.tableOffset, end)
+	m.commitView.SetRows(rows[start:end])
+	m.commitView.SetCursor(m.commitTable.Cursor() - start)
+}
+
+// refreshCommitRows rebuilds the commit table rows, whose counts follow the filter.
+func (m *ConsoleModel) refreshCommitRows() {
+	if !m.singleCommit {
+		m.commitTable.SetRows(m.commitRows())
+		m.showCommitRows()
 	}
-	m.activeIdx = row
-	m.activeHash = m.commits[row].Hash
-	m.loadActiveCommit()
+}
+
+// setFilter applies a filter to the tree and to the counts in the commit table.
+func (m *ConsoleModel) setFilter(filter treeFilter) {
+	m.tree.setFilter(filter)
+	m.refreshCommitRows()
+	if entry := m.tree.selectedEntry(); entry != nil && entry.change != nil {
+		m.syncDiffToTreeCursor()
+	} else {
+		m.showDiff = false
+		m.activeChange = nil
+		m.updateDiffContent()
+	}
+}
+
+// commitCounts are the changes and breaking changes shown for a commit in the commit table.
+type commitCounts struct {
+	changes  int
+	breaking int
+}
+
+// filteredCommitCounts counts what the filter leaves in each commit whose tree is
+// loaded. It returns nil when no filter is active, and the commit's own totals are shown.
+func (m ConsoleModel) filteredCommitCounts() map[string]commitCounts {
+	if !m.tree.filter.active() {
+		return nil
+	}
+	counts := make(map[string]commitCounts, len(m.cache.entries))
+	for hash, entry := range m.cache.entries {
+		if entry.treeRoot == nil {
+			continue
+		}
+		stats := computeFilteredStats(entry.treeRoot, make(map[*v3.Node]nodeStats), m.tree.filter)
+		counts[hash] = commitCounts{changes: stats.total, breaking: stats.totalBreaking}
+	}
+	return counts
+}
+
+// breakingJump is a jump to a breaking change in another commit, which carries
+// on as each commit it passes through loads. Once there are none left, it is
+// returning to the commit it started from, and puts the cursor back when that loads.
+type breakingJump struct {
+	forward      bool
+	returning    bool
+	originIdx    int
+	originCursor int
+}
+
+// jumpToBreaking moves the tree cursor to the next (or previous) breaking change.
+// Past the end of the active commit's tree, it carries on through the commits
+// in the commit table, loading them through the cache, and skipping those known
+// to have no breaking changes. When there are none left, it goes back to where
+// it started.
+func (m *ConsoleModel) jumpToBreaking(forward bool) tea.Cmd {
+	m.jump = nil
+	idx := m.tree.prevBreaking(m.tree.cursor)
+	if forward {
+		idx = m.tree.nextBreaking(m.tree.cursor)
+	}
+	if idx >= 0 {
+		m.moveTreeCursor(idx)
+		return nil
+	}
+	if m.singleCommit || m.loading || m.comparison != nil {
+		return nil
+	}
+	m.jump = &breakingJump{forward: forward, originIdx: m.activeIdx, originCursor: m.tree.cursor}
+	return m.continueJump()
+}
+
+// continueJump selects the next commit in the direction of the jump that may
+// have breaking changes. When it has to be loaded, the jump lands once it has.
+func (m *ConsoleModel) continueJump() tea.Cmd {
+	jump := m.jump
+	step := -1
+	if jump.forward {
+		step = 1
+	}
+	var cmds []tea.Cmd
+	counts := m.filteredCommitCounts()
+	for i := m.activeIdx + step; i >= 0 && i < len(m.commits); i += step {
+		if n, known := m.commitBreakingCount(i, counts); known && n == 0 {
+			continue
+		}
+		cmds = append(cmds, m.selectCommit(i))
+		if m.loading || m.landJump() {
+			return tea.Batch(cmds...)
+		}
+	}
+
+	// there are no breaking changes left in that direction
+	m.jump = nil
+	if m.activeIdx != jump.originIdx {
+		cmds = append(cmds, m.selectCommit(jump.originIdx))
+		if m.loading {
+			m.jump = &breakingJump{returning: true, originIdx: jump.originIdx, originCursor: jump.originCursor}
+		} else {
+			m.moveTreeCursor(jump.originCursor)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// returnJump puts the cursor back where a jump that found no breaking changes
+// started, once the commit it started from has loaded again.
+func (m *ConsoleModel) returnJump() {
+	cursor := m.jump.originCursor
+	m.jump = nil
+	if cursor < len(m.tree.entries) {
+		m.moveTreeCursor(cursor)
+	}
+}
+
+// landJump puts the cursor on the first (or last) breaking change of the active
+// commit, ending the jump. It reports false when the commit has none.
+func (m *ConsoleModel) landJump() bool {
+	idx := m.tree.prevBreaking(len(m.tree.entries))
+	if m.jump.forward {
+		idx = m.tree.nextBreaking(-1)
+	}
+	if idx < 0 {
+		return false
+	}
+	m.jump = nil
+	m.moveTreeCursor(idx)
+	return true
+}
+
+// moveTreeCursor puts the tree cursor on an entry and shows its diff.
+func (m *ConsoleModel) moveTreeCursor(idx int) {
+	m.tree.cursor = idx
+	m.tree.scrollToCursor()
+	m.syncDiffToTreeCursor()
+}
+
+// commitBreakingCount returns how many breaking changes the tree of a commit
+// shows, and false when that is not known without loading the commit. For a
+// commit that is not loaded, the totals of its changes stand in for its tree,
+// which is close enough to skip commits without breaking changes.
+func (m ConsoleModel) commitBreakingCount(idx int, counts map[string]commitCounts) (int, bool) {
+	if n, ok := m.loadedBreakingCount(idx, counts); ok {
+		return n, true
+	}
+	if commit := m.commits[idx]; counts == nil && commit.Changes != nil {
+		return commit.Changes.TotalBreakingChanges(), true
+	}
+	return 0, false
+}
+
+// loadedBreakingCount returns how many breaking changes the tree of a commit
+// shows, and false when its tree is not loaded.
+func (m ConsoleModel) loadedBreakingCount(idx int, counts map[string]commitCounts) (int, bool) {
+	if idx == m.activeIdx {
+		_, total := m.tree.breakingPosition()
+		return total, true
+	}
+	commit := m.commits[idx]
+	if counts != nil {
+		count, ok := counts[commit.Hash]
+		return count.breaking, ok
+	}
+	if entry, ok := m.cache.entries[commit.Hash]; ok && entry.treeRoot != nil {
+		if stats, ok := entry.nodeStatsCache[entry.treeRoot]; ok {
+			return stats.totalBreaking, true
+		}
+		return computeStats(entry.treeRoot, make(map[*v3.Node]nodeStats)).totalBreaking, true
+	}
+	return 0, false
+}
+
+// breakingProgress describes where the active change is among the breaking
+// changes of every commit, such as "breaking 3/17". Only loaded commits are
+// counted, so while any are not, a count is marked as at least, as in
+// "breaking 3/17+". It is empty unless the active change is breaking.
+func (m ConsoleModel) breakingProgress() string {
+	if m.activeChange == nil || !m.activeChange.Breaking {
+		return ""
+	}
+	pos, total := m.tree.breakingPosition()
+	if m.comparison != nil {
+		return fmt.Sprintf("breaking %d/%d", pos, total)
+	}
+	counts := m.filteredCommitCounts()
+	posMore, totalMore := "", ""
+	for i := range m.commits {
+		if i == m.activeIdx {
+			continue
+		}
+		n, known := m.loadedBreakingCount(i, counts)
+		if !known {
+			totalMore = "+"
+			if i < m.activeIdx {
+				posMore = "+"
+			}
+		}
+		total += n
+		if i < m.activeIdx {
+			pos += n
+		}
+	}
+	return fmt.Sprintf("breaking %d%s/%d%s", pos, posMore, total, totalMore)
 }
 
 // syncDiffToTreeCursor updates the diff to show whichever change the tree cursor is on.
@@ -505,6 +1044,7 @@ func (m *ConsoleModel) syncDiffToTreeCursor() {
 // When line info is available, it renders a summary header above a spec view
 // centered on the highlight. Otherwise, falls back to renderDiff().
 func (m *ConsoleModel) updateDiffContent() {
+	m.diffSideBySide = false
 	if m.activeChange == nil {
 		m.diffViewport.SetContent("")
 		m.diffSummary = ""
@@ -527,7 +1067,7 @@ func (m *ConsoleModel) updateDiffContent() {
 			vpH = 3
 		}
 		m.diffViewport.SetHeight(vpH)
-		content := renderDiff(m.activeChange, newLines, oldLines, diffW-2, m.styles)
+		content := renderDiff(m.activeChange, newLines, oldLines, diffW-2, m.styles, m.breakingProgress())
 		m.diffViewport.SetContent(content)
 		m.diffViewport.GotoTop()
 		return
@@ -535,7 +1075,7 @@ func (m *ConsoleModel) updateDiffContent() {
 
 	// Render summary header
 	_, diffW := m.splitWidths()
-	summary := renderDiffSummary(m.activeChange, diffW-2, m.styles)
+	summary := renderDiffSummary(m.activeChange, diffW-2, m.styles, m.breakingProgress())
 	summaryH := strings.Count(summary, "\n")
 	if summaryH > 0 {
 		summaryH++ // +1 for separator
@@ -554,6 +1094,12 @@ func (m *ConsoleModel) updateDiffContent() {
 	}
 	m.diffViewport.SetHeight(vpH)
 
+	if m.canShowSideBySide() && len(newLines) > 0 && len(oldLines) > 0 {
+		m.diffSideBySide = true
+		m.renderSideBySideDiff(newLines, oldLines, diffW-2, vpH)
+		return
+	}
+
 	// Compute highlight range
 	rangeStart, rangeEnd := computeBlockRangeForChange(lines, changeLn, m.activeChange)
 	hl := highlightRange{start: rangeStart, end: rangeEnd}
@@ -571,6 +1117,58 @@ func (m *ConsoleModel) updateDiffContent() {
 	m.diffViewport.SetYOffset(targetOffset)
 }
 
+// canShowSideBySide reports whether the side-by-side diff is on and the diff panel is wide enough for it.
+func (m ConsoleModel) canShowSideBySide() bool {
+	_, diffW := m.splitWidths()
+	return m.sideBySide && diffW-2 >= sideBySideMinWidth
+}
+
+// renderSideBySideDiff renders the old and new spec side by side into the diff
+// viewport, centered on the highlight of the spec the change is shown in. The
+// line alignment is computed once per commit and kept in the cache.
+func (m *ConsoleModel) renderSideBySideDiff(newLines, oldLines []string, contentW, vpH int) {
+	ch := m.activeChange
+	entry := m.cache.get(m.activeHash)
+	var rows []sideBySideRow
+	if entry != nil {
+		rows = entry.sideBySideRows
+	}
+	if rows == nil {
+		rows = alignSpecLines(oldLines, newLines)
+		if entry != nil {
+			entry.sideBySideRows = rows
+		}
+	}
+
+	var oldHL, newHL highlightRange
+	if ch.Context != nil && ch.Context.OriginalLine != nil {
+		start, end := computeBlockRangeForChange(oldLines, *ch.Context.OriginalLine, ch)
+		oldHL = highlightRange{start: start, end: end}
+	}
+	if ch.Context != nil && ch.Context.NewLine != nil {
+		start, end := computeBlockRangeForChange(newLines, *ch.Context.NewLine, ch)
+		newHL = highlightRange{start: start, end: end}
+	}
+	m.diffViewport.SetContent(renderSideBySide(rows, oldLines, newLines, oldHL, newHL, ch.ChangeType, contentW, m.styles))
+
+	// Removals are centered on the old spec; everything else on the new one.
+	var row int
+	switch ch.ChangeType {
+	case whatChangedModel.PropertyRemoved, whatChangedModel.ObjectRemoved:
+		row = findSideBySideRow(rows, oldHL.start, true)
+	default:
+		row = findSideBySideRow(rows, newHL.start, false)
+		if row < 0 {
+			row = findSideBySideRow(rows, oldHL.start, true)
+		}
+	}
+	if row < 0 {
+		m.diffViewport.GotoTop()
+		return
+	}
+	m.diffViewport.SetYOffset(max(row-vpH/2, 0))
+}
+
 // openCodeModal opens the full-spec code modal for a change.
 // For removals the relevant content is in the old spec; for additions/modifications
 // it is in the new spec. The line number is chosen to match.
@@ -606,29 +1204,37 @@ func (m *ConsoleModel) rebuildCodeModal() {
 	m.codeModal = newCodeModal(lines, hl, m.activeChange, m.width, m.height, m.styles)
 }
 
-// openReportModal opens the markdown report modal for the active commit.
-func (m *ConsoleModel) openReportModal() {
-	if m.showCodeModal {
-		return
-	}
-
+// reportMarkdown returns the markdown report of the active commit, generating it
+// the first time it is asked for. It is empty when the commit has no report.
+func (m *ConsoleModel) reportMarkdown() string {
 	entry := m.cache.get(m.activeHash)
 	if entry == nil {
-		return
+		return ""
 	}
-
-	// Get or generate raw markdown
 	if entry.markdown == "" {
 		if entry.result == nil || entry.result.Changerator == nil {
-			return
+			return ""
 		}
 		mdRenderer := renderer.NewMarkdownRenderer()
 		md, err := entry.result.Changerator.GenerateReport(mdRenderer, renderer.OutputFormatMarkdown)
-		if err != nil || md == "" {
-			return
+		if err != nil {
+			return ""
 		}
 		entry.markdown = md
 	}
+	return entry.markdown
+}
+
+// openReportModal opens the markdown report modal for the active commit.
+func (m *ConsoleModel) openReportModal() {
+	if m.showCodeModal {
+		return
+	}
+
+	entry := m.cache.get(m.activeHash)
+	if entry == nil || m.reportMarkdown() == "" {
+		return
+	}
 
 	modalW, modalH, contentW := modalDimensions(m.width, m.height)
 
@@ -641,8 +1247,8 @@ func (m *ConsoleModel) openReportModal() {
 	}
 
 	commitMsg := ""
-	if m.activeIdx >= 0 && m.activeIdx < len(m.commits) {
-		commitMsg = m.commits[m.activeIdx].Message
+	if commit := m.activeCommit(); commit != nil {
+		commitMsg = commit.Message
 	}
 
 	m.reportModal = newReportModal(rendered, commitMsg, modalW, modalH, contentW, m.styles)
@@ -673,7 +1279,7 @@ func innerWidth(termWidth int) int {
 func buildCommitTable(commits []*model.Commit, termWidth int, highlightedIdx int, styles consoleStyles) table.Model {
 	iw := innerWidth(termWidth)
 	cols := commitColumns(iw)
-	rows := buildCommitRows(commits, highlightedIdx, styles)
+	rows := buildCommitRows(commits, highlightedIdx, nil, styles)
 
 	t := table.New(
 		table.WithColumns(cols),
@@ -688,10 +1294,10 @@ func buildCommitTable(commits []*model.Commit, termWidth int, highlightedIdx int
 }
 
 // resizeCommitTable updates column widths and styles to fill the new terminal width.
-func resizeCommitTable(t *table.Model, commits []*model.Commit, highlightedIdx int, termWidth int, styles consoleStyles) {
+func resizeCommitTable(t *table.Model, rows []table.Row, termWidth int, styles consoleStyles) {
 	iw := innerWidth(termWidth)
 	t.SetColumns(commitColumns(iw))
-	t.SetRows(buildCommitRows(commits, highlightedIdx, styles))
+	t.SetRows(rows)
 	t.SetWidth(iw)
 	t.SetStyles(commitTableStyles(iw, styles))
 }
@@ -710,7 +1316,9 @@ func commitColumns(iw int) []table.Column {
 	}
 }
 
-func buildCommitRows(commits []*model.Commit, highlightedIdx int, styles consoleStyles) []table.Row {
+// buildCommitRows builds the commit table rows. With counts (when a filter is
+// active), commits show the filtered counts, or "-" when their tree is not loaded.
+func buildCommitRows(commits []*model.Commit, highlightedIdx int, counts map[string]commitCounts, styles consoleStyles) []table.Row {
 	rows := make([]table.Row, 0, len(commits))
 	for i, c := range commits {
 		date := c.CommitDate.Format("01/02/06")
@@ -721,7 +1329,12 @@ func buildCommitRows(commits []*model.Commit, highlightedIdx int, styles console
 		}
 		changes := "-"
 		breaking := "-"
-		if c.Changes != nil {
+		if counts != nil {
+			if count, ok := counts[c.Hash]; ok {
+				changes = fmt.Sprint(count.changes)
+				breaking = fmt.Sprint(count.breaking)
+			}
+		} else if c.Changes != nil {
 			changes = fmt.Sprint(c.Changes.TotalChanges())
 			breaking = fmt.Sprint(c.Changes.TotalBreakingChanges())
 		}
@@ -377,6 +377,9 @@ func TestEmptyChangerator_ShowsMessage(t *testing.T) {
 		return nil, nil, nil, nil
 	}
 	m := NewConsoleModel(commits, nil, true, "test", runFn)
+	assert.True(t, m.loading)
+	m = runCmds(t, m, m.Init())
+	assert.False(t, m.loading)
 	assert.Equal(t, "No changes detected in this commit", m.emptyState)
 }
 
@@ -399,7 +402,7 @@ func TestCodeModal_ModifiedChange_ShowsNewSpec(t *testing.T) {
 	assert.Equal(t, FocusCodeModal, m.focus)
 
 	// Modal should contain content from the NEW spec
-	view := m.codeModal.View(m.styles)
+	view := m.codeModal.View(m.styles, m.keys)
 	assert.Contains(t, view, "New Title")
 }
 
@@ -420,7 +423,7 @@ func TestCodeModal_RemovedChange_ShowsOldSpec(t *testing.T) {
 
 	assert.True(t, m.showCodeModal)
 	// Modal should contain content from the OLD spec (which has "Old Title")
-	view := m.codeModal.View(m.styles)
+	view := m.codeModal.View(m.styles, m.keys)
 	assert.Contains(t, view, "Old Title")
 }
 
@@ -441,7 +444,7 @@ func TestCodeModal_AddedChange_ShowsNewSpec(t *testing.T) {
 
 	assert.True(t, m.showCodeModal)
 	// Modal should show the new spec
-	view := m.codeModal.View(m.styles)
+	view := m.codeModal.View(m.styles, m.keys)
 	assert.Contains(t, view, "New Title")
 }
 
@@ -665,7 +668,7 @@ func TestCodeModal_ObjectAdded_HighlightsRange(t *testing.T) {
 	assert.Greater(t, m.codeModal.highlight.end, m.codeModal.highlight.start,
 		"ObjectAdded should expand to a multi-line highlight range")
 
-	view := m.codeModal.View(m.styles)
+	view := m.codeModal.View(m.styles, m.keys)
 	// Primary line should have > marker
 	assert.Contains(t, view, ">")
 	// Body lines should have │ gutter marker
@@ -790,3 +793,209 @@ func TestWindowResize_RebuildCodeModal(t *testing.T) {
 	assert.Equal(t, FocusCodeModal, updated.focus)
 	assert.Equal(t, FocusTree, updated.prevFocus)
 }
+
+func TestSearchKeys_FollowAndCancel(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	origin := m.tree.cursor
+
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "/"}))
+	m = result.(ConsoleModel)
+	require.True(t, m.searching)
+
+	for _, text := range []string{"d", "e", "s"} {
+		result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: text}))
+		m = result.(ConsoleModel)
+	}
+	assert.Equal(t, "des", m.tree.query)
+	require.NotNil(t, m.activeChange)
+	assert.Equal(t, "description", m.activeChange.Property, "the cursor should follow the first match")
+	assert.Contains(t, m.renderNavBar(), "/des")
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	m = result.(ConsoleModel)
+	assert.False(t, m.searching)
+	assert.Empty(t, m.tree.query)
+	assert.Equal(t, origin, m.tree.cursor)
+	assert.Equal(t, FocusTree, m.focus, "esc should only cancel the search")
+}
+
+func TestSearchKeys_EnterKeepsQueryForNextMatch(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+
+	for _, text := range []string{"/", "e"} {
+		result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: text}))
+		m = result.(ConsoleModel)
+	}
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	m = result.(ConsoleModel)
+	assert.False(t, m.searching)
+	assert.False(t, m.showCodeModal)
+	require.Len(t, m.tree.matches, 3)
+	assert.Equal(t, "title", m.activeChange.Property)
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "n"}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, "deprecated", m.activeChange.Property)
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "N"}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, "title", m.activeChange.Property)
+	assert.Contains(t, m.renderNavBar(), "1/3")
+}
+
+func TestFilterKeys_NarrowTreeAndCommitCounts(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m.singleCommit = false
+	m.commits = append(m.commits, &model.Commit{Hash: "def456", Message: "not loaded", CommitDate: time.Now()})
+
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "b"}))
+	m = result.(ConsoleModel)
+	assert.True(t, m.tree.filter.breaking)
+	require.Len(t, m.tree.entries, 2)
+	assert.Equal(t, "deprecated", m.activeChange.Property)
+	assert.Contains(t, m.renderNavBar(), "filter: breaking")
+
+	counts := m.filteredCommitCounts()
+	assert.Equal(t, commitCounts{changes: 1, breaking: 1}, counts["abc123"])
+	rows := m.commitTable.Rows()
+	require.Len(t, rows, 2)
+	assert.Contains(t, rows[1][4], "-", "commits that are not loaded have no filtered counts")
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "a"}))
+	m = result.(ConsoleModel)
+	assert.Empty(t, m.tree.entries, "no change is both breaking and an addition")
+	assert.Nil(t, m.activeChange)
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "c"}))
+	m = result.(ConsoleModel)
+	assert.False(t, m.tree.filter.active())
+	assert.Len(t, m.tree.entries, 4)
+	assert.Nil(t, m.filteredCommitCounts())
+}
+
+// addBreakingCommit adds a commit whose cached tree holds a single breaking change.
+func addBreakingCommit(m *ConsoleModel, hash, property string) {
+	ch := &whatChangedModel.Change{
+		ChangeType: whatChangedModel.PropertyRemoved,
+		Property:   property,
+		Original:   "x",
+		Breaking:   true,
+	}
+	node := &v3.Node{Label: "Info", Type: "Info"}
+	node.AppendChange(&mockChanged{changes: []*whatChangedModel.Change{ch}})
+	m.commits = append(m.commits, &model.Commit{Hash: hash, Message: hash, CommitDate: time.Now()})
+	m.cache.put(hash, &cacheEntry{treeRoot: &v3.Node{Label: "Document", Type: "Document", Children: []*v3.Node{node}}})
+}
+
+func TestJumpToBreaking_AcrossCommits(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.singleCommit = false
+	m.focus = FocusTree
+	// a commit known to have no breaking changes is skipped without being loaded
+	m.commits = append(m.commits, &model.Commit{Hash: "nobreak", CommitDate: time.Now(),
+		Changes: &whatChangedModel.DocumentChanges{PropertyChanges: whatChangedModel.NewPropertyChanges(nil)}})
+	addBreakingCommit(&m, "def456", "operationId")
+	m.commitTable = buildCommitTable(m.commits, m.width, m.highlightedIdx, m.styles)
+
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "]"}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, 0, m.activeIdx)
+	assert.Equal(t, "deprecated", m.activeChange.Property)
+	// the commit that is not loaded may still have breaking changes in its tree
+	assert.Equal(t, "breaking 1/2+", m.breakingProgress())
+	assert.Contains(t, m.diffSummary+m.diffViewport.View(), "breaking 1/2+")
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "]"}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, 2, m.activeIdx)
+	assert.Equal(t, 2, m.commitTable.Cursor())
+	assert.Equal(t, "operationId", m.activeChange.Property)
+	assert.Equal(t, "breaking 2+/2+", m.breakingProgress())
+
+	// past the last breaking change, the cursor stays where it is
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "]"}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, 2, m.activeIdx)
+	assert.Equal(t, "operationId", m.activeChange.Property)
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "["}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, 0, m.activeIdx)
+	assert.Equal(t, "deprecated", m.activeChange.Property)
+}
+
+func TestJumpToBreaking_NoneLeftRestoresCommit(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.singleCommit = false
+	m.focus = FocusTree
+	// the second commit's breaking count is unknown, so it is loaded and found to have none
+	m.commits = append(m.commits, &model.Commit{Hash: "def456", CommitDate: time.Now()})
+	node := &v3.Node{Label: "Info", Type: "Info"}
+	node.AppendChange(&mockChanged{changes: []*whatChangedModel.Change{
+		{ChangeType: whatChangedModel.Modified, Property: "version"},
+	}})
+	m.cache.put("def456", &cacheEntry{treeRoot: &v3.Node{Children: []*v3.Node{node}}})
+	m.commitTable = buildCommitTable(m.commits, m.width, m.highlightedIdx, m.styles)
+	m.moveTreeCursor(m.tree.nextBreaking(-1))
+	cursor := m.tree.cursor
+
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "]"}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, 0, m.activeIdx)
+	assert.Equal(t, 0, m.commitTable.Cursor())
+	assert.Equal(t, cursor, m.tree.cursor)
+	assert.Equal(t, "deprecated", m.activeChange.Property)
+}
+
+func TestJumpToBreaking_NoneLeftRestoresCursorAfterReload(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.singleCommit = false
+	m.focus = FocusTree
+	// the cursor is past the only breaking change, on the change that follows it
+	m.moveTreeCursor(m.tree.nextBreaking(-1) + 1)
+	cursor := m.tree.cursor
+	require.Equal(t, "description", m.activeChange.Property)
+	origin := m.cache.entries["abc123"]
+
+	// loading the second commit evicts the first, which has to load again
+	m.runFn = func(_ *model.Commit, _ *whatChangedModel.BreakingRulesConfig) (*changerator.Changerator, *v3.Node, func(), error) {
+		return nil, nil, func() {}, nil
+	}
+	m.cache.maxSize = 1
+	m.commits = append(m.commits, &model.Commit{Hash: "def456", CommitDate: time.Now()})
+	m.commitTable = buildCommitTable(m.commits, m.width, m.highlightedIdx, m.styles)
+
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "]"}))
+	m = result.(ConsoleModel)
+	require.True(t, m.loading)
+	assert.Equal(t, 1, m.activeIdx)
+
+	result, _ = m.Update(commitLoadedMsg{hash: "def456",
+		entry: &cacheEntry{treeRoot: &v3.Node{Children: []*v3.Node{{Label: "Info", Type: "Info"}}}}})
+	m = result.(ConsoleModel)
+	require.True(t, m.loading)
+	assert.Equal(t, 0, m.activeIdx)
+
+	result, _ = m.Update(commitLoadedMsg{hash: "abc123", entry: origin})
+	m = result.(ConsoleModel)
+	assert.False(t, m.loading)
+	assert.Nil(t, m.jump)
+	assert.Equal(t, cursor, m.tree.cursor)
+	assert.Equal(t, "description", m.activeChange.Property)
+}
+
+func TestBreakingProgress_FilterCountsOnlyLoadedCommits(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.singleCommit = false
+	addBreakingCommit(&m, "def456", "operationId")
+	m.commits = append(m.commits, &model.Commit{Hash: "ghi789", CommitDate: time.Now(),
+		Changes: &whatChangedModel.DocumentChanges{PropertyChanges: whatChangedModel.NewPropertyChanges(nil)}})
+	m.moveTreeCursor(m.tree.nextBreaking(-1))
+	assert.Equal(t, "breaking 1/2+", m.breakingProgress())
+
+	m.setFilter(treeFilter{breaking: true})
+	m.moveTreeCursor(m.tree.nextBreaking(-1))
+	assert.Equal(t, "breaking 1/2+", m.breakingProgress())
+}
@@ -0,0 +1,253 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	defaultTreeSplit     = 50 // percent of the width the tree panel takes
+	minTreeSplit         = 20
+	maxTreeSplit         = 80
+	treeSplitStep        = 5
+	minPanelWidth        = 20 // narrowest the tree or diff panel gets, in columns
+	collapsedTableHeight = 3  // border + the active commit line
+	wheelScrollLines     = 3
+)
+
+// handleLayoutKeys handles the keys that change the layout from any panel: < and >
+// move the split between the tree and the diff, z collapses or expands the
+// commit table, and M turns mouse capture on or off. It reports whether the key
+// was one of them.
+func (m *ConsoleModel) handleLayoutKeys(msg tea.KeyPressMsg) bool {
+	switch m.keys.action(msg) {
+	case actShrinkTree:
+		m.setTreeSplit(m.treeSplit - treeSplitStep)
+	case actGrowTree:
+		m.setTreeSplit(m.treeSplit + treeSplitStep)
+	case actToggleCommits:
+		if m.singleCommit {
+			return false
+		}
+		m.setTableCollapsed(!m.tableCollapsed)
+	case actToggleMouse:
+		m.mouse = !m.mouse
+		if m.mouse {
+			m.notice = "mouse on"
+		} else {
+			m.notice = "mouse off, the terminal can select text"
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// setTreeSplit sets the percentage of the width the tree panel takes, within
+// bounds that keep both panels usable, and lays the panels out again.
+func (m *ConsoleModel) setTreeSplit(split int) {
+	split = max(minTreeSplit, min(split, maxTreeSplit))
+	if split == m.treeSplit {
+		return
+	}
+	m.treeSplit = split
+	// the diff is rendered for the width of its panel
+	m.pendingDiff = m.pendingDiff || m.showDiff
+	m.recalculateLayout()
+}
+
+// setTableCollapsed collapses the commit table to a line showing the active
+// commit, or expands it again. A collapsed table cannot have focus.
+func (m *ConsoleModel) setTableCollapsed(collapsed bool) {
+	if m.singleCommit || collapsed == m.tableCollapsed {
+		return
+	}
+	m.tableCollapsed = collapsed
+	if collapsed && m.focus == FocusCommitTable {
+		m.setFocus(FocusTree)
+	}
+	m.pendingDiff = m.pendingDiff || m.showDiff
+	m.recalculateLayout()
+}
+
+// setFocus moves focus to a panel, keeping the commit table's own focus in step.
+func (m *ConsoleModel) setFocus(panel PanelFocus) {
+	m.focus = panel
+	if panel == FocusCommitTable {
+		m.commitTable.Focus()
+	} else {
+		m.commitTable.Blur()
+	}
+}
+
+// renderCollapsedTable renders the line a collapsed commit table shows in place
+// of its rows: the active commit and where it is in the history.
+func (m ConsoleModel) renderCollapsedTable() string {
+	commit := m.activeCommit()
+	if commit == nil {
+		return ""
+	}
+	position := fmt.Sprintf("%d/%d", m.activeIdx+1, len(m.commits))
+	if m.comparison != nil {
+		position = "comparison"
+	}
+	line := fmt.Sprintf(" ▸ %s  %s", shortHash(commit.Hash), commit.Message)
+	line = truncateToWidth(strings.ReplaceAll(line, "\n", " "), innerWidth(m.width)-len(position)-4)
+	return m.styles.helpKey.Render(line) + "  " + m.styles.grey.Render(position)
+}
+
+// handleMouse handles mouse events. A click selects the commit or change under
+// the pointer and focuses its panel, and a click on the change already selected
+// opens it. The wheel scrolls what is under the pointer, or the modal while one
+// is open. Dragging the border between the tree and the diff resizes them.
+func (m *ConsoleModel) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	mouse := msg.Mouse()
+
+	if m.dragging {
+		switch msg.(type) {
+		case tea.MouseMotionMsg:
+			if m.width > 0 {
+				m.setTreeSplit((mouse.X + 1) * 100 / m.width)
+			}
+		case tea.MouseReleaseMsg:
+			m.dragging = false
+		}
+		return nil
+	}
+
+	if wheel, ok := msg.(tea.MouseWheelMsg); ok {
+		return m.handleMouseWheel(wheel)
+	}
+	click, ok := msg.(tea.MouseClickMsg)
+	if !ok || click.Button != tea.MouseLeft {
+		return nil
+	}
+	if m.showReportModal || m.showCodeModal || m.showActionMenu || m.showHelp || m.searching || m.noting {
+		return nil
+	}
+
+	tableH := m.tableHeight()
+	treeW, _ := m.splitWidths()
+	switch {
+	case mouse.Y < tableH:
+		return m.clickCommitTable(mouse.Y - 1) // -1 for the border
+	case mouse.Y >= tableH+m.bottomHeight():
+		return nil
+	case mouse.X == treeW-1 || mouse.X == treeW:
+		m.dragging = true
+	case mouse.X < treeW:
+		return m.clickTree(mouse.Y - tableH - 1)
+	default:
+		m.setFocus(FocusDiff)
+	}
+	return nil
+}
+
+// handleMouseWheel scrolls the open modal, or the panel under the pointer.
+func (m *ConsoleModel) handleMouseWheel(msg tea.MouseWheelMsg) tea.Cmd {
+	up := msg.Button == tea.MouseWheelUp
+	if msg.Button != tea.MouseWheelUp && msg.Button != tea.MouseWheelDown {
+		return nil
+	}
+	switch {
+	case m.showReportModal:
+		scrollViewport(&m.reportModal.vp, up)
+		return nil
+	case m.showCodeModal:
+		scrollViewport(&m.codeModal.vp, up)
+		return nil
+	case m.showHelp:
+		scrollViewport(&m.helpModal.vp, up)
+		return nil
+	case m.showActionMenu || m.searching || m.noting:
+		return nil
+	}
+
+	treeW, _ := m.splitWidths()
+	switch {
+	case msg.Y < m.tableHeight():
+		if m.tableCollapsed {
+			return nil
+		}
+		if up {
+			m.commitTable.MoveUp(1)
+		} else {
+			m.commitTable.MoveDown(1)
+		}
+		return m.selectHighlightedCommit()
+	case msg.X < treeW:
+		if up {
+			m.tree.moveUp(1)
+		} else {
+			m.tree.moveDown(1)
+		}
+		m.syncDiffToTreeCursor()
+	default:
+		scrollViewport(&m.diffViewport, up)
+	}
+	return nil
+}
+
+// clickCommitTable selects the commit on a line of the commit table. Line 0 is
+// the header. Clicking a collapsed table expands it.
+func (m *ConsoleModel) clickCommitTable(line int) tea.Cmd {
+	if m.tableCollapsed {
+		m.setTableCollapsed(false)
+		m.setFocus(FocusCommitTable)
+		return nil
+	}
+	m.setFocus(FocusCommitTable)
+	row := m.commitRowAt(line)
+	if row < 0 || row == m.commitTable.Cursor() {
+		return nil
+	}
+	m.commitTable.SetCursor(row)
+	return m.selectHighlightedCommit()
+}
+
+// commitRowAt returns the commit shown on a line of the commit table, or -1.
+func (m ConsoleModel) commitRowAt(line int) int {
+	row := m.tableOffset + line - 1 // -1 for the header
+	if line < 1 || line > m.commitTable.Height() || row >= len(m.commits) {
+		return -1
+	}
+	return row
+}
+
+// clickTree moves the tree cursor to the change on a line of the tree, or to the
+// first change under a node. Clicking the change already under the cursor opens
+// it in the code modal, as enter does.
+func (m *ConsoleModel) clickTree(line int) tea.Cmd {
+	wasFocused := m.focus == FocusTree
+	m.setFocus(FocusTree)
+	idx := m.tree.offset + line
+	if line < 0 || line >= m.tree.height || idx >= len(m.tree.entries) {
+		return nil
+	}
+	if idx == m.tree.cursor && wasFocused {
+		if entry := m.tree.selectedEntry(); entry != nil && entry.change != nil {
+			m.openCodeModal(entry.change)
+		}
+		return nil
+	}
+	m.tree.cursor = idx
+	m.tree.snapToNextLeaf()
+	m.tree.scrollToCursor()
+	m.syncDiffToTreeCursor()
+	return nil
+}
+
+// scrollViewport scrolls a viewport by a turn of the mouse wheel.
+func scrollViewport(vp *viewport.Model, up bool) {
+	if up {
+		vp.ScrollUp(wheelScrollLines)
+	} else {
+		vp.ScrollDown(wheelScrollLines)
+	}
+}
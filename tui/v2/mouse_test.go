@@ -0,0 +1,182 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendMouse(m ConsoleModel, msg tea.MouseMsg) (ConsoleModel, tea.Cmd) {
+	result, cmd := m.Update(msg)
+	return result.(ConsoleModel), cmd
+}
+
+func setupHistoryModel(t *testing.T, hashes ...string) ConsoleModel {
+	t.Helper()
+	m := NewConsoleModel(makeHistoryCommits(hashes...), nil, true, "test", (&recordingRunFn{}).run)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return runCmds(t, result.(ConsoleModel), m.Init())
+}
+
+func TestLayoutKeys_ResizeSplit(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	treeW, diffW := m.splitWidths()
+	assert.Equal(t, 60, treeW)
+	assert.Equal(t, 60, diffW)
+
+	m = pressKeys(m, "<", "<")
+	treeW, diffW = m.splitWidths()
+	assert.Equal(t, 48, treeW)
+	assert.Equal(t, 72, diffW)
+	assert.Equal(t, 70, m.diffViewport.Width(), "the diff is laid out for its new width")
+
+	m = pressKeys(m, ">", ">", ">", ">", ">", ">", ">", ">", ">", ">")
+	assert.Equal(t, maxTreeSplit, m.treeSplit, "the split stops short of hiding the diff")
+}
+
+func TestLayoutKeys_CollapseCommitTable(t *testing.T) {
+	m := setupHistoryModel(t, "aaa1111", "bbb2222", "ccc3333")
+	m.setFocus(FocusCommitTable)
+	expandedBottom := m.bottomHeight()
+
+	m = pressKeys(m, "z")
+	require.True(t, m.tableCollapsed)
+	assert.Equal(t, collapsedTableHeight, m.tableHeight())
+	assert.Greater(t, m.bottomHeight(), expandedBottom, "the tree and diff take the room")
+	assert.Equal(t, FocusTree, m.focus, "a collapsed table cannot keep focus")
+	assert.Contains(t, stripANSI(m.View().Content), "▸ aaa1111")
+
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	m = result.(ConsoleModel)
+	assert.False(t, m.tableCollapsed, "going back to the commits expands the table")
+	assert.Equal(t, FocusCommitTable, m.focus)
+
+	single := pressKeys(setupModelWithTree(t), "z")
+	assert.False(t, single.tableCollapsed, "a single commit has no table to collapse")
+}
+
+func TestMouse_ClickSelectsCommit(t *testing.T) {
+	m := setupHistoryModel(t, "aaa1111", "bbb2222", "ccc3333")
+	m.setFocus(FocusTree)
+
+	// border, header, then the rows
+	m, cmd := sendMouse(m, tea.MouseClickMsg{X: 20, Y: 4, Button: tea.MouseLeft})
+	m = runCmds(t, m, cmd)
+	assert.Equal(t, FocusCommitTable, m.focus)
+	assert.Equal(t, 2, m.commitTable.Cursor())
+	assert.Equal(t, "ccc3333", m.activeHash)
+
+	m, _ = sendMouse(m, tea.MouseClickMsg{X: 20, Y: 1, Button: tea.MouseLeft})
+	assert.Equal(t, 2, m.commitTable.Cursor(), "clicking the header selects nothing")
+
+	m = pressKeys(m, "z")
+	m, _ = sendMouse(m, tea.MouseClickMsg{X: 20, Y: 1, Button: tea.MouseLeft})
+	assert.False(t, m.tableCollapsed, "clicking the collapsed table expands it")
+}
+
+func TestMouse_ClickSelectsCommitInScrolledTable(t *testing.T) {
+	hashes := make([]string, 20)
+	for i := range hashes {
+		hashes[i] = fmt.Sprintf("c%06d", i)
+	}
+	m := setupHistoryModel(t, hashes...)
+	// a message naming another commit does not make its line that commit
+	m.commits[12].Message = "revert " + hashes[0]
+	m.refreshCommitRows()
+	m.setFocus(FocusCommitTable)
+
+	m, cmd := sendMouse(m, tea.MouseClickMsg{X: 20, Y: 2, Button: tea.MouseLeft})
+	m = runCmds(t, m, cmd)
+	require.Equal(t, 0, m.commitTable.Cursor())
+	m.commitTable.SetCursor(15)
+	m = runCmds(t, m, m.selectHighlightedCommit())
+	require.Greater(t, m.tableOffset, 0, "the table has scrolled")
+	require.LessOrEqual(t, m.tableOffset, 12)
+
+	y := 12 - m.tableOffset + 2 // +2 for the border and the header
+	lines := strings.Split(stripANSI(m.View().Content), "\n")
+	require.Contains(t, lines[y], hashes[12])
+
+	m, cmd = sendMouse(m, tea.MouseClickMsg{X: 20, Y: y, Button: tea.MouseLeft})
+	m = runCmds(t, m, cmd)
+	assert.Equal(t, 12, m.commitTable.Cursor())
+	assert.Equal(t, hashes[12], m.activeHash)
+}
+
+func TestMouse_ClickSelectsAndOpensChange(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	moveToFirstLeaf(t, &m)
+	first := m.tree.cursor
+	second := m.tree.nextLeaf(first)
+	require.Greater(t, second, first)
+
+	y := second - m.tree.offset + 1 // +1 for the border
+	m, _ = sendMouse(m, tea.MouseClickMsg{X: 5, Y: y, Button: tea.MouseLeft})
+	assert.Equal(t, second, m.tree.cursor)
+	assert.Same(t, m.tree.entries[second].change, m.activeChange)
+	assert.False(t, m.showCodeModal)
+
+	m, _ = sendMouse(m, tea.MouseClickMsg{X: 5, Y: y, Button: tea.MouseLeft})
+	assert.True(t, m.showCodeModal, "clicking the selected change opens it")
+
+	m, _ = sendMouse(m, tea.MouseClickMsg{X: 5, Y: 1, Button: tea.MouseLeft})
+	assert.Equal(t, second, m.tree.cursor, "clicks do not reach the panels behind a modal")
+}
+
+func TestMouse_WheelScrollsPanelUnderPointer(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	moveToFirstLeaf(t, &m)
+	first := m.tree.cursor
+
+	m, _ = sendMouse(m, tea.MouseWheelMsg{X: 5, Y: 5, Button: tea.MouseWheelDown})
+	assert.Equal(t, m.tree.nextLeaf(first), m.tree.cursor)
+	m, _ = sendMouse(m, tea.MouseWheelMsg{X: 5, Y: 5, Button: tea.MouseWheelUp})
+	assert.Equal(t, first, m.tree.cursor)
+
+	m.diffViewport.SetContent(strings.Repeat("x\n", 100))
+	m, _ = sendMouse(m, tea.MouseWheelMsg{X: 100, Y: 5, Button: tea.MouseWheelDown})
+	assert.Equal(t, wheelScrollLines, m.diffViewport.YOffset())
+	assert.Equal(t, first, m.tree.cursor, "scrolling the diff leaves the tree alone")
+}
+
+func TestMouse_DragResizesSplit(t *testing.T) {
+	m := setupModelWithTree(t)
+	treeW, _ := m.splitWidths()
+
+	m, _ = sendMouse(m, tea.MouseClickMsg{X: treeW - 1, Y: 5, Button: tea.MouseLeft})
+	require.True(t, m.dragging)
+	m, _ = sendMouse(m, tea.MouseMotionMsg{X: 35, Y: 5, Button: tea.MouseLeft})
+	assert.Equal(t, 30, m.treeSplit)
+	treeW, _ = m.splitWidths()
+	assert.Equal(t, 36, treeW, "the border follows the pointer")
+
+	m, _ = sendMouse(m, tea.MouseReleaseMsg{X: 35, Y: 5, Button: tea.MouseLeft})
+	assert.False(t, m.dragging)
+	m, _ = sendMouse(m, tea.MouseMotionMsg{X: 80, Y: 5})
+	assert.Equal(t, 30, m.treeSplit)
+}
+
+func TestLayoutKeys_ToggleMouse(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	assert.Equal(t, tea.MouseModeNone, m.View().MouseMode, "the terminal keeps the mouse by default")
+
+	m = pressKeys(m, "M")
+	assert.True(t, m.mouse)
+	assert.Equal(t, tea.MouseModeCellMotion, m.View().MouseMode)
+
+	m = pressKeys(m, "M")
+	assert.Equal(t, tea.MouseModeNone, m.View().MouseMode)
+
+	assert.Equal(t, tea.MouseModeCellMotion, setupModelWithTree(t).WithMouse(true).View().MouseMode)
+}
@@ -39,13 +39,15 @@ func newReportModal(renderedContent, commitMsg string, modalW, modalH, contentW
 }
 
 // View renders the report modal content inside a bordered panel.
-func (m reportModal) View(styles consoleStyles) string {
+func (m reportModal) View(styles consoleStyles, keys KeyMap) string {
 	border := styles.activePanel.Width(m.width).Height(m.height).
 		Padding(0, 1, 0, 1)
 
-	nav := " " + styles.renderHotkey("↑↓") + styles.renderLabel("scroll") +
-		"  " + styles.renderHotkey("pgup/pgdn") + styles.renderLabel("page") +
-		"  " + styles.renderHotkey("esc") + styles.renderLabel("close")
+	nav := " " + keys.hints(styles,
+		keyHint{[]keyAction{actUp, actDown}, "scroll"},
+		keyHint{[]keyAction{actPageUp, actPageDown}, "page"},
+		keyHint{[]keyAction{actBack}, "close"},
+	)
 	sep := styles.renderSeparator(m.width - 4)
 
 	var sb strings.Builder
@@ -20,7 +20,7 @@ func TestNewReportModal_BasicRendering(t *testing.T) {
 	styles := newConsoleStyles()
 	modal := newReportModal("# Test Report\nSome content here", "test commit", 80, 30, 76, styles)
 
-	view := modal.View(styles)
+	view := modal.View(styles, defaultKeyMap())
 	assert.NotEmpty(t, view)
 	assert.Contains(t, view, "test commit")
 }
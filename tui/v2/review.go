@@ -0,0 +1,132 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/review"
+)
+
+// reviewBadge returns the badge a review mark shows beside its change in the
+// tree, and the style of the badge. Changes without a mark have no badge.
+func reviewBadge(mark *review.Mark, styles consoleStyles) (string, lipgloss.Style) {
+	if mark == nil {
+		return "", styles.grey
+	}
+	var badge string
+	style := styles.grey
+	switch mark.Status {
+	case review.Reviewed:
+		badge = "[reviewed]"
+	case review.Approved:
+		badge, style = "[approved]", styles.added
+	case review.NeedsDiscussion:
+		badge, style = "[discuss]", styles.warning
+	}
+	if mark.Note != "" {
+		badge = strings.TrimSpace(badge + " ✎ " + mark.Note)
+	}
+	return badge, style
+}
+
+// selectedChange returns the change under the tree cursor, or nil.
+func (m ConsoleModel) selectedChange() *whatChangedModel.Change {
+	if entry := m.tree.selectedEntry(); entry != nil {
+		return entry.change
+	}
+	return nil
+}
+
+// reviewMark returns a copy of a change's mark to edit, or a new mark for it.
+func (m ConsoleModel) reviewMark(ch *whatChangedModel.Change, hash string) *review.Mark {
+	if existing := m.reviews.Get(hash); existing != nil {
+		mark := *existing
+		return &mark
+	}
+	return review.NewMark(ch, m.activeHash)
+}
+
+// cycleReviewStatus moves the change under the cursor on to its next review
+// status: reviewed, approved, needs-discussion and then unmarked.
+func (m *ConsoleModel) cycleReviewStatus() {
+	ch := m.selectedChange()
+	if ch == nil {
+		return
+	}
+	hash := review.ChangeHash(ch)
+	mark := m.reviewMark(ch, hash)
+	mark.Status = review.Next(mark.Status)
+	m.saveReviewMark(hash, mark)
+}
+
+// startNote begins editing the note of the change under the cursor.
+func (m *ConsoleModel) startNote() {
+	ch := m.selectedChange()
+	if ch == nil {
+		return
+	}
+	m.noting = true
+	m.noteInput = ""
+	if mark := m.reviews.Get(review.ChangeHash(ch)); mark != nil {
+		m.noteInput = mark.Note
+	}
+}
+
+// saveNote sets the note being edited on the change under the cursor. An empty
+// note removes it.
+func (m *ConsoleModel) saveNote() {
+	m.noting = false
+	ch := m.selectedChange()
+	if ch == nil {
+		return
+	}
+	hash := review.ChangeHash(ch)
+	mark := m.reviewMark(ch, hash)
+	mark.Note = strings.TrimSpace(m.noteInput)
+	m.saveReviewMark(hash, mark)
+}
+
+// saveReviewMark records a mark and writes the review file, so that no
+// decision is lost when the console exits.
+func (m *ConsoleModel) saveReviewMark(hash string, mark *review.Mark) {
+	m.reviews.Set(hash, mark)
+	if err := m.reviews.Save(); err != nil {
+		m.notice = "Error: " + err.Error()
+	}
+}
+
+// exportReview writes the markdown summary and the baseline of the review.
+func (m *ConsoleModel) exportReview() {
+	markdownPath, baselinePath, err := m.reviews.Export()
+	if err != nil {
+		m.notice = "Error: " + err.Error()
+		return
+	}
+	m.notice = fmt.Sprintf("review exported to %s and %s", markdownPath, baselinePath)
+}
+
+// handleNoteKeys handles key events while a review note is being typed. Enter
+// saves the note and esc discards the edit.
+func (m ConsoleModel) handleNoteKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.handleQuit()
+	case "enter":
+		m.saveNote()
+	case "esc":
+		m.noting = false
+	case "backspace":
+		if note := []rune(m.noteInput); len(note) > 0 {
+			m.noteInput = string(note[:len(note)-1])
+		}
+	default:
+		m.noteInput += msg.Text
+	}
+	return m, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/pb33f/openapi-changes/internal/review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pressKeys(m ConsoleModel, texts ...string) ConsoleModel {
+	for _, text := range texts {
+		result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: text}))
+		m = result.(ConsoleModel)
+	}
+	return m
+}
+
+func TestReviewKeys_MarkCyclesAndPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.json")
+	m := setupModelWithTree(t).WithReviews(review.NewStore(path))
+	m.focus = FocusTree
+	ch := m.selectedChange()
+	require.NotNil(t, ch)
+	hash := review.ChangeHash(ch)
+
+	m = pressKeys(m, "x")
+	require.NotNil(t, m.reviews.Get(hash))
+	assert.Equal(t, review.Reviewed, m.reviews.Get(hash).Status)
+	assert.Equal(t, "abc123", m.reviews.Get(hash).Commit)
+	assert.Contains(t, stripANSI(m.tree.View(80, true, m.styles)), "[reviewed]")
+
+	m = pressKeys(m, "x")
+	assert.Equal(t, review.Approved, m.reviews.Get(hash).Status)
+
+	loaded, err := review.Load(path)
+	require.NoError(t, err)
+	require.NotNil(t, loaded.Get(hash), "marks are saved as they are made")
+	assert.Equal(t, review.Approved, loaded.Get(hash).Status)
+
+	m = pressKeys(m, "x", "x")
+	assert.Nil(t, m.reviews.Get(hash), "the cycle ends unmarked")
+}
+
+func TestReviewKeys_Note(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	hash := review.ChangeHash(m.selectedChange())
+
+	m = pressKeys(m, "i")
+	require.True(t, m.noting)
+	m = pressKeys(m, "a", "s", "k", "x")
+	result, _ := m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyBackspace}))
+	m = result.(ConsoleModel)
+	assert.Contains(t, m.renderNavBar(), "note: ask")
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	m = result.(ConsoleModel)
+
+	assert.False(t, m.noting)
+	require.NotNil(t, m.reviews.Get(hash))
+	assert.Equal(t, "ask", m.reviews.Get(hash).Note)
+	assert.Empty(t, m.reviews.Get(hash).Status, "typing a note does not mark the change")
+
+	m = pressKeys(m, "i", "!")
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	m = result.(ConsoleModel)
+	assert.Equal(t, "ask", m.reviews.Get(hash).Note, "esc discards the edit")
+	assert.Equal(t, FocusTree, m.focus)
+}
+
+func TestReviewKeys_Export(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+	m = pressKeys(m, "w")
+	assert.Contains(t, m.renderNavBar(), "Error", "an in-memory review has nowhere to export to")
+
+	path := filepath.Join(t.TempDir(), "review.json")
+	m = pressKeys(m.WithReviews(review.NewStore(path)), "x", "w")
+	markdownPath, _ := review.ExportPaths(path)
+	assert.Contains(t, m.notice, markdownPath)
+	assert.FileExists(t, markdownPath)
+
+	m = pressKeys(m, "j")
+	assert.Empty(t, m.notice, "the next key press clears the notice")
+}
@@ -0,0 +1,123 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// sideBySideMinWidth is the narrowest diff panel content width that shows the
+// side-by-side layout; narrower panels fall back to the single-column view.
+const sideBySideMinWidth = 90
+
+// sideBySideRow pairs a line of the old spec with a line of the new spec.
+// A zero line number leaves that side of the row blank.
+type sideBySideRow struct {
+	oldLine int  // 1-based
+	newLine int  // 1-based
+	changed bool // the lines differ, or only one side has a line
+}
+
+// alignSpecLines aligns the old and new spec so that unchanged lines share a
+// row. Replaced lines are paired up, and added or removed lines face a blank.
+func alignSpecLines(oldLines, newLines []string) []sideBySideRow {
+	matcher := difflib.NewMatcher(oldLines, newLines)
+	rows := make([]sideBySideRow, 0, max(len(oldLines), len(newLines)))
+	for _, op := range matcher.GetOpCodes() {
+		if op.Tag == 'e' {
+			for i := 0; i < op.I2-op.I1; i++ {
+				rows = append(rows, sideBySideRow{oldLine: op.I1 + i + 1, newLine: op.J1 + i + 1})
+			}
+			continue
+		}
+		for i := 0; i < max(op.I2-op.I1, op.J2-op.J1); i++ {
+			row := sideBySideRow{changed: true}
+			if op.I1+i < op.I2 {
+				row.oldLine = op.I1 + i + 1
+			}
+			if op.J1+i < op.J2 {
+				row.newLine = op.J1 + i + 1
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// findSideBySideRow returns the row that shows a line of the old (or new) spec, or -1.
+func findSideBySideRow(rows []sideBySideRow, line int, old bool) int {
+	if line <= 0 {
+		return -1
+	}
+	for i, row := range rows {
+		if (old && row.oldLine == line) || (!old && row.newLine == line) {
+			return i
+		}
+	}
+	return -1
+}
+
+// renderSideBySide renders aligned old | new columns of the spec. Both sides
+// scroll together because each row holds both. Each side highlights its own
+// block range, and lines that differ are marked in the colour of their side.
+func renderSideBySide(rows []sideBySideRow, oldLines, newLines []string, oldHL, newHL highlightRange,
+	changeType, contentW int, styles consoleStyles,
+) string {
+	colW := (contentW - 3) / 2
+	numWidth := digitCount(max(len(oldLines), len(newLines)))
+	separator := styles.grey.Render(" │ ")
+
+	var sb strings.Builder
+	sb.Grow(len(rows) * (contentW + 40))
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(renderSideBySideCell(oldLines, row.oldLine, row.changed, oldHL, "-", styles.removed,
+			changeType, numWidth, colW, styles))
+		sb.WriteString(separator)
+		sb.WriteString(renderSideBySideCell(newLines, row.newLine, row.changed, newHL, "+", styles.added,
+			changeType, numWidth, colW, styles))
+	}
+	return sb.String()
+}
+
+// renderSideBySideCell renders one side of a row, padded to the column width.
+func renderSideBySideCell(lines []string, lineNo int, changed bool, hl highlightRange, changedGutter string,
+	changedStyle lipgloss.Style, changeType, numWidth, colW int, styles consoleStyles,
+) string {
+	if lineNo <= 0 || lineNo > len(lines) {
+		return strings.Repeat(" ", colW)
+	}
+	primaryStyle, rangeStyle, bodyGutter := contextHighlightStyles(changeType, styles)
+	numStr := fmt.Sprintf("%*d", numWidth, lineNo)
+	line := lines[lineNo-1]
+
+	var cell string
+	switch {
+	case lineNo == hl.start:
+		cell = primaryStyle.Render(padToWidth(truncateToWidth(fmt.Sprintf("> %s│ %s", numStr, line), colW), colW))
+	case lineNo > hl.start && lineNo <= hl.end:
+		cell = rangeStyle.Render(padToWidth(truncateToWidth(fmt.Sprintf("%s %s│ %s", bodyGutter, numStr, line), colW), colW))
+	case changed:
+		cell = changedStyle.Render(padToWidth(truncateToWidth(fmt.Sprintf("%s %s│ %s", changedGutter, numStr, line), colW), colW))
+	default:
+		prefix := fmt.Sprintf("  %s│ ", numStr)
+		text := truncateToWidth(line, colW-visualLen(prefix))
+		cell = styles.grey.Render(prefix) + highlightLine(text, styles)
+	}
+	return padToWidth(cell, colW)
+}
+
+// padToWidth pads a string (possibly containing ANSI escapes) with spaces to a visible width.
+func padToWidth(s string, width int) string {
+	if pad := width - visualLen(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
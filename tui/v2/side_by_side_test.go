@@ -0,0 +1,109 @@
+// Copyright 2023-2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlignSpecLines(t *testing.T) {
+	oldLines := []string{"info:", "  title: Old", "paths: {}"}
+	newLines := []string{"info:", "  title: New", "  version: 2", "paths: {}"}
+
+	rows := alignSpecLines(oldLines, newLines)
+	assert.Equal(t, []sideBySideRow{
+		{oldLine: 1, newLine: 1},
+		{oldLine: 2, newLine: 2, changed: true},
+		{newLine: 3, changed: true},
+		{oldLine: 3, newLine: 4},
+	}, rows)
+
+	assert.Equal(t, 1, findSideBySideRow(rows, 2, true))
+	assert.Equal(t, 2, findSideBySideRow(rows, 3, false))
+	assert.Equal(t, -1, findSideBySideRow(rows, 0, false))
+	assert.Equal(t, -1, findSideBySideRow(rows, 9, true))
+}
+
+func TestRenderSideBySide_AlignsColumns(t *testing.T) {
+	oldLines := []string{"info:", "  title: Old", "paths: {}"}
+	newLines := []string{"info:", "  title: New", "  version: 2", "paths: {}"}
+	rows := alignSpecLines(oldLines, newLines)
+
+	result := renderSideBySide(rows, oldLines, newLines, singleLineRange(2), singleLineRange(2),
+		whatChangedModel.Modified, 100, testStyles)
+	lines := strings.Split(result, "\n")
+	require.Len(t, lines, 4)
+
+	// every row is split at the same column
+	colW := (100 - 3) / 2
+	for _, line := range lines {
+		assert.Equal(t, 100-1, visualLen(line), line)
+		assert.Equal(t, " │ ", string([]rune(stripANSI(line))[colW:colW+3]), line)
+	}
+	assert.Contains(t, lines[1], "> 2│   title: Old")
+	assert.Contains(t, lines[1], "> 2│   title: New")
+	assert.Contains(t, lines[2], "+ 3│   version: 2")
+	assert.True(t, strings.HasPrefix(stripANSI(lines[2]), strings.Repeat(" ", colW)), "the old side of an added line is blank")
+}
+
+func TestRenderSideBySide_TruncatesLongLines(t *testing.T) {
+	oldLines := []string{"description: " + strings.Repeat("x", 200)}
+	rows := alignSpecLines(oldLines, oldLines)
+
+	result := renderSideBySide(rows, oldLines, oldLines, highlightRange{}, highlightRange{},
+		whatChangedModel.Modified, 100, testStyles)
+	assert.Equal(t, 100-1, visualLen(result))
+}
+
+func TestSideBySideDiff_ToggleAndNarrowFallback(t *testing.T) {
+	m := setupModelWithTree(t).WithSideBySide(true)
+	m.focus = FocusTree
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 240, Height: 40})
+	m = result.(ConsoleModel)
+	require.True(t, m.diffSideBySide)
+	require.NotNil(t, m.cache.get("abc123").sideBySideRows)
+	content := m.diffViewport.View()
+	assert.Contains(t, content, "title: Old Title")
+	assert.Contains(t, content, "title: New Title")
+	assert.Contains(t, m.renderNavBar(), "UNIFIED")
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "s"}))
+	m = result.(ConsoleModel)
+	assert.False(t, m.sideBySide)
+	assert.False(t, m.diffSideBySide)
+	assert.NotContains(t, m.diffViewport.View(), "Old Title")
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "s"}))
+	m = result.(ConsoleModel)
+	assert.True(t, m.diffSideBySide)
+
+	// too narrow for two columns
+	result, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(ConsoleModel)
+	assert.True(t, m.sideBySide)
+	assert.False(t, m.diffSideBySide)
+	assert.Contains(t, m.renderNavBar(), "SPLIT")
+}
+
+func TestSideBySideDiff_OffByDefault(t *testing.T) {
+	m := setupModelWithTree(t)
+	m.focus = FocusTree
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 240, Height: 40})
+	m = result.(ConsoleModel)
+	assert.False(t, m.sideBySide)
+	assert.False(t, m.diffSideBySide)
+	assert.Contains(t, m.renderNavBar(), "SPLIT")
+
+	result, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 0, Text: "s"}))
+	m = result.(ConsoleModel)
+	assert.True(t, m.diffSideBySide)
+}
@@ -28,6 +28,7 @@ type consoleStyles struct {
 	modified       lipgloss.Style
 	removed        lipgloss.Style
 	breaking       lipgloss.Style
+	warning        lipgloss.Style
 	grey           lipgloss.Style
 	detail         lipgloss.Style
 	info           lipgloss.Style
@@ -73,6 +74,7 @@ func newConsoleStylesForPalette(palette terminal.Palette) consoleStyles {
 		modified:       consoleStyleFg(palette.Modification),
 		removed:        consoleStyleFg(palette.Removal),
 		breaking:       consoleStyleFg(palette.Breaking).Bold(true),
+		warning:        consoleStyleFg(palette.Modification).Bold(true),
 		grey:           consoleStyleFg(palette.Muted),
 		detail:         consoleStyleFg(palette.Detail),
 		info:           consoleStyleFg(palette.Primary),
@@ -102,6 +104,7 @@ func newNoColorStyles() consoleStyles {
 		modified:       lipgloss.NewStyle(),
 		removed:        lipgloss.NewStyle(),
 		breaking:       lipgloss.NewStyle().Bold(true),
+		warning:        lipgloss.NewStyle().Bold(true),
 		grey:           lipgloss.NewStyle(),
 		detail:         lipgloss.NewStyle(),
 		info:           lipgloss.NewStyle(),
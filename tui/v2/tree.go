@@ -5,6 +5,7 @@ package v2
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"unicode/utf8"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/mattn/go-runewidth"
 	v3 "github.com/pb33f/doctor/model/high/v3"
 	whatChangedModel "github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/openapi-changes/internal/review"
+	"github.com/pb33f/openapi-changes/internal/severity"
 )
 
 // treeModel is a custom tree widget backed by a flattened entry list.
@@ -25,6 +28,13 @@ type treeModel struct {
 	height     int
 	root       *v3.Node
 	statsCache map[*v3.Node]nodeStats
+	severities severity.Config
+	reviews    *review.Store
+	filter     treeFilter
+
+	// Search — matches are the leaf entries the cursor visits for the query, in tree order.
+	query   string
+	matches []int
 }
 
 // treeEntry is a single row in the flattened tree display.
@@ -39,6 +49,55 @@ type treeEntry struct {
 	guides  []bool // guides[i] = true → show │ at depth i; false → show space
 }
 
+// treeFilter narrows the tree to the changes that pass it. The change kinds that
+// are switched on are combined; with none switched on, every kind passes.
+type treeFilter struct {
+	breaking      bool
+	additions     bool
+	modifications bool
+	removals      bool
+}
+
+func (f treeFilter) active() bool {
+	return f.breaking || f.additions || f.modifications || f.removals
+}
+
+func (f treeFilter) matches(ch *whatChangedModel.Change) bool {
+	if f.breaking && !ch.Breaking {
+		return false
+	}
+	if !f.additions && !f.modifications && !f.removals {
+		return true
+	}
+	switch ch.ChangeType {
+	case whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded:
+		return f.additions
+	case whatChangedModel.Modified:
+		return f.modifications
+	case whatChangedModel.PropertyRemoved, whatChangedModel.ObjectRemoved:
+		return f.removals
+	}
+	return false
+}
+
+// String describes the filter for the nav bar.
+func (f treeFilter) String() string {
+	var parts []string
+	if f.breaking {
+		parts = append(parts, "breaking")
+	}
+	if f.additions {
+		parts = append(parts, "added")
+	}
+	if f.modifications {
+		parts = append(parts, "modified")
+	}
+	if f.removals {
+		parts = append(parts, "removed")
+	}
+	return strings.Join(parts, "+")
+}
+
 type nodeStats struct {
 	additions      int
 	modifications  int
@@ -62,15 +121,17 @@ func newTreeModel(root *v3.Node, height int) treeModel {
 	return t
 }
 
-// rebuild flattens the tree from root. The tree is always fully expanded.
+// rebuild flattens the tree from root. The tree is always fully expanded, less
+// the changes the filter hides and the branches left without any changes.
 func (t *treeModel) rebuild() {
 	if t.root == nil {
 		t.entries = nil
+		t.matches = nil
 		return
 	}
 	t.entries = make([]treeEntry, 0, 64)
-	rootChanges := getNodeChanges(t.root)
-	children := t.root.Children
+	rootChanges := t.visibleChanges(t.root)
+	children := t.visibleChildren(t.root)
 	totalItems := len(rootChanges) + len(children)
 	idx := 0
 
@@ -95,11 +156,13 @@ func (t *treeModel) rebuild() {
 	if t.offset > t.cursor {
 		t.offset = t.cursor
 	}
+	t.updateMatches()
 }
 
 func (t *treeModel) flattenNode(node *v3.Node, depth int, isLast bool, parentGuides []bool) {
-	changes := getNodeChanges(node)
-	hasKids := len(node.Children) > 0 || len(changes) > 0
+	changes := t.visibleChanges(node)
+	children := t.visibleChildren(node)
+	hasKids := len(children) > 0 || len(changes) > 0
 
 	guides := make([]bool, len(parentGuides))
 	copy(guides, parentGuides)
@@ -115,10 +178,10 @@ func (t *treeModel) flattenNode(node *v3.Node, depth int, isLast bool, parentGui
 	childGuides := make([]bool, len(parentGuides)+1)
 	copy(childGuides, parentGuides)
 	childGuides[len(parentGuides)] = !isLast
-	totalItems := len(node.Children) + len(changes)
+	totalItems := len(children) + len(changes)
 	idx := 0
 
-	for _, child := range node.Children {
+	for _, child := range children {
 		idx++
 		t.flattenNode(child, depth+1, idx == totalItems, childGuides)
 	}
@@ -137,6 +200,205 @@ func (t *treeModel) flattenNode(node *v3.Node, depth int, isLast bool, parentGui
 	}
 }
 
+// visibleChanges returns the node's own changes that pass the filter.
+func (t *treeModel) visibleChanges(node *v3.Node) []*whatChangedModel.Change {
+	changes := getNodeChanges(node)
+	if !t.filter.active() {
+		return changes
+	}
+	var visible []*whatChangedModel.Change
+	for _, ch := range changes {
+		if t.filter.matches(ch) {
+			visible = append(visible, ch)
+		}
+	}
+	return visible
+}
+
+// visibleChildren returns the node's children, less those the filter leaves without
+// changes. statsCache holds the filtered stats whenever a filter is active.
+func (t *treeModel) visibleChildren(node *v3.Node) []*v3.Node {
+	if !t.filter.active() {
+		return node.Children
+	}
+	var visible []*v3.Node
+	for _, child := range node.Children {
+		if t.statsCache[child].total > 0 {
+			visible = append(visible, child)
+		}
+	}
+	return visible
+}
+
+// setFilter recomputes the stats for what the filter leaves visible and rebuilds
+// the tree, keeping the cursor on the same change when it is still visible.
+func (t *treeModel) setFilter(filter treeFilter) {
+	t.filter = filter
+	if t.root == nil {
+		return
+	}
+	var selected *whatChangedModel.Change
+	if entry := t.selectedEntry(); entry != nil {
+		selected = entry.change
+	}
+	t.statsCache = make(map[*v3.Node]nodeStats)
+	computeFilteredStats(t.root, t.statsCache, filter)
+	t.rebuild()
+
+	t.cursor = 0
+	for i, entry := range t.entries {
+		if selected != nil && entry.change == selected {
+			t.cursor = i
+			break
+		}
+	}
+	t.snapToNextLeaf()
+	t.scrollToCursor()
+}
+
+// setQuery sets the search query and finds the entries that match it.
+func (t *treeModel) setQuery(query string) {
+	t.query = query
+	t.updateMatches()
+}
+
+// updateMatches finds the leaves that match the query: leaves whose property
+// matches, and the first leaf under each branch whose label matches.
+func (t *treeModel) updateMatches() {
+	t.matches = t.matches[:0]
+	if t.query == "" {
+		return
+	}
+	for i, entry := range t.entries {
+		if !t.entryMatches(entry) {
+			continue
+		}
+		target := i
+		if entry.change == nil {
+			target = t.nextLeaf(i)
+		}
+		if target >= 0 && (len(t.matches) == 0 || t.matches[len(t.matches)-1] != target) {
+			t.matches = append(t.matches, target)
+		}
+	}
+}
+
+// entryMatches reports whether an entry's label or property contains the query, ignoring case.
+func (t *treeModel) entryMatches(entry treeEntry) bool {
+	if t.query == "" {
+		return false
+	}
+	text := entryText(entry)
+	return strings.Contains(strings.ToLower(text), strings.ToLower(t.query))
+}
+
+func entryText(entry treeEntry) string {
+	if entry.change != nil {
+		if entry.change.Property != "" {
+			return entry.change.Property
+		}
+		return entry.change.Type
+	}
+	if entry.node.Label != "" {
+		return entry.node.Label
+	}
+	return entry.node.Type
+}
+
+// searchFrom moves the cursor to the first match at or after pos, wrapping
+// around. It reports false, leaving the cursor alone, when nothing matches.
+func (t *treeModel) searchFrom(pos int) bool {
+	if len(t.matches) == 0 {
+		return false
+	}
+	target := t.matches[0]
+	for _, idx := range t.matches {
+		if idx >= pos {
+			target = idx
+			break
+		}
+	}
+	t.cursor = target
+	t.scrollToCursor()
+	return true
+}
+
+// nextMatch moves the cursor to the next match after it, wrapping around.
+func (t *treeModel) nextMatch() {
+	t.searchFrom(t.cursor + 1)
+}
+
+// prevMatch moves the cursor to the previous match before it, wrapping around.
+func (t *treeModel) prevMatch() {
+	if len(t.matches) == 0 {
+		return
+	}
+	target := t.matches[len(t.matches)-1]
+	for i := len(t.matches) - 1; i >= 0; i-- {
+		if t.matches[i] < t.cursor {
+			target = t.matches[i]
+			break
+		}
+	}
+	t.cursor = target
+	t.scrollToCursor()
+}
+
+// matchPosition returns the 1-based position of the cursor among the matches, or 0.
+func (t *treeModel) matchPosition() int {
+	for i, idx := range t.matches {
+		if idx == t.cursor {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// nextBreaking returns the index of the first breaking leaf after pos, or -1.
+func (t *treeModel) nextBreaking(pos int) int {
+	for i := max(pos+1, 0); i < len(t.entries); i++ {
+		if ch := t.entries[i].change; ch != nil && ch.Breaking {
+			return i
+		}
+	}
+	return -1
+}
+
+// prevBreaking returns the index of the last breaking leaf before pos, or -1.
+func (t *treeModel) prevBreaking(pos int) int {
+	for i := min(pos, len(t.entries)) - 1; i >= 0; i-- {
+		if ch := t.entries[i].change; ch != nil && ch.Breaking {
+			return i
+		}
+	}
+	return -1
+}
+
+// breakingPosition returns how many breaking leaves there are up to and
+// including the cursor, and how many there are in the tree.
+func (t *treeModel) breakingPosition() (pos, total int) {
+	for i, entry := range t.entries {
+		if entry.change == nil || !entry.change.Breaking {
+			continue
+		}
+		total++
+		if i <= t.cursor {
+			pos++
+		}
+	}
+	return pos, total
+}
+
+// scrollToCursor scrolls the least distance that brings the cursor into view.
+func (t *treeModel) scrollToCursor() {
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.height > 0 && t.cursor >= t.offset+t.height {
+		t.offset = t.cursor - t.height + 1
+	}
+}
+
 // selectedEntry returns the entry at the cursor, or nil.
 func (t *treeModel) selectedEntry() *treeEntry {
 	if t.cursor < 0 || t.cursor >= len(t.entries) {
@@ -317,9 +579,16 @@ func (t treeModel) renderChange(ch *whatChangedModel.Change, isCursor bool, styl
 		style = styles.grey
 	}
 
-	if ch.Breaking {
+	// Breaking changes are errors unless the rules config assigns another severity.
+	switch t.severities.Of(ch) {
+	case severity.Error:
 		prefix = "{X} " + prefix
 		style = styles.breaking
+	case severity.Warning:
+		prefix = "{!} " + prefix
+		style = styles.warning
+	case severity.Ignore:
+		style = styles.grey
 	}
 
 	prop := ch.Property
@@ -327,17 +596,35 @@ func (t treeModel) renderChange(ch *whatChangedModel.Change, isCursor bool, styl
 		prop = ch.Type
 	}
 
+	var badge string
+	badgeStyle := styles.grey
+	if t.reviews != nil {
+		badge, badgeStyle = reviewBadge(t.reviews.Get(review.ChangeHash(ch)), styles)
+	}
+
 	// When this row is highlighted, use plain text — the row background does the work
 	if isCursor {
+		if badge != "" {
+			return fmt.Sprintf("%s %s %s", prefix, prop, badge)
+		}
 		return fmt.Sprintf("%s %s", prefix, prop)
 	}
-	return style.Render(fmt.Sprintf("%s %s", prefix, prop))
+	var label string
+	if t.entryMatches(treeEntry{change: ch}) {
+		label = style.Render(prefix+" ") + style.Underline(true).Render(prop)
+	} else {
+		label = style.Render(fmt.Sprintf("%s %s", prefix, prop))
+	}
+	if badge != "" {
+		label += " " + badgeStyle.Render(badge)
+	}
+	return label
 }
 
 func (t treeModel) renderNode(entry treeEntry, styles consoleStyles) string {
-	label := entry.node.Label
-	if label == "" {
-		label = entry.node.Type
+	label := entryText(entry)
+	if t.entryMatches(entry) {
+		return styles.info.Underline(true).Render(label)
 	}
 	return styles.info.Render(label)
 }
@@ -357,9 +644,18 @@ func getNodeChanges(node *v3.Node) []*whatChangedModel.Change {
 // (from getNodeChanges), so only the node that directly owns a breaking leaf
 // displays the breaking count — ancestors do not repeat it.
 func computeStats(node *v3.Node, cache map[*v3.Node]nodeStats) nodeStats {
+	return computeFilteredStats(node, cache, treeFilter{})
+}
+
+// computeFilteredStats computes change statistics counting only the changes that
+// pass the filter, which are the changes the filtered tree shows.
+func computeFilteredStats(node *v3.Node, cache map[*v3.Node]nodeStats, filter treeFilter) nodeStats {
 	s := nodeStats{}
 
 	for _, ch := range getNodeChanges(node) {
+		if !filter.matches(ch) {
+			continue
+		}
 		switch ch.ChangeType {
 		case whatChangedModel.PropertyAdded, whatChangedModel.ObjectAdded:
 			s.additions++
@@ -376,7 +672,7 @@ func computeStats(node *v3.Node, cache map[*v3.Node]nodeStats) nodeStats {
 	s.totalBreaking = s.directBreaking
 
 	for _, child := range node.Children {
-		cs := computeStats(child, cache)
+		cs := computeFilteredStats(child, cache, filter)
 		s.additions += cs.additions
 		s.modifications += cs.modifications
 		s.deletions += cs.deletions
@@ -388,6 +684,13 @@ func computeStats(node *v3.Node, cache map[*v3.Node]nodeStats) nodeStats {
 	return s
 }
 
+var ansiEscapeRe = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
+
+// stripANSI removes the ANSI escapes from a string.
+func stripANSI(s string) string {
+	return ansiEscapeRe.ReplaceAllString(s, "")
+}
+
 // visualLen returns the approximate visible length of a string, ignoring ANSI escapes.
 func visualLen(s string) int {
 	n := 0
@@ -281,3 +281,88 @@ func TestSelectedEntry_Empty(t *testing.T) {
 	tm := newTreeModel(nil, 20)
 	assert.Nil(t, tm.selectedEntry())
 }
+
+func TestTreeFilter_Matches(t *testing.T) {
+	added := &whatChangedModel.Change{ChangeType: whatChangedModel.ObjectAdded}
+	removed := &whatChangedModel.Change{ChangeType: whatChangedModel.PropertyRemoved, Breaking: true}
+
+	assert.True(t, treeFilter{}.matches(added))
+	assert.True(t, treeFilter{additions: true}.matches(added))
+	assert.False(t, treeFilter{removals: true}.matches(added))
+	assert.False(t, treeFilter{breaking: true}.matches(added))
+	assert.True(t, treeFilter{breaking: true, removals: true}.matches(removed))
+	assert.False(t, treeFilter{breaking: true, additions: true}.matches(removed))
+	assert.Equal(t, "breaking+added", treeFilter{breaking: true, additions: true}.String())
+}
+
+func TestSetFilter_PrunesTreeAndRecomputesStats(t *testing.T) {
+	root := makeTestTree()
+	tm := newTreeModel(root, 20)
+	tm.moveDown(3) // [M] version
+
+	tm.setFilter(treeFilter{modifications: true})
+
+	// Expected entries:
+	// 0: Paths (branch)
+	// 1: [M] title (leaf on Paths)
+	// 2: Info (branch)
+	// 3: [M] version (leaf on Info)
+	require.Len(t, tm.entries, 4)
+	assert.Equal(t, "title", tm.entries[1].change.Property)
+	assert.Equal(t, 3, tm.cursor, "the cursor should stay on the same change")
+	assert.Equal(t, 2, tm.statsCache[root].total)
+	assert.Equal(t, 1, tm.statsCache[root.Children[0]].total)
+
+	tm.setFilter(treeFilter{breaking: true})
+	require.Len(t, tm.entries, 3)
+	assert.Equal(t, "deprecated", tm.selectedEntry().change.Property,
+		"the cursor should snap to a visible change")
+	assert.Equal(t, 1, tm.statsCache[root].totalBreaking)
+
+	tm.setFilter(treeFilter{})
+	assert.Len(t, tm.entries, 7)
+}
+
+func TestSearch_MatchesLabelsAndProperties(t *testing.T) {
+	root := makeTestTree()
+	tm := newTreeModel(root, 20)
+
+	// "tle" matches the title property; "info" matches the Info branch, whose first leaf is version
+	tm.setQuery("TLE")
+	assert.Equal(t, []int{4}, tm.matches)
+	tm.setQuery("info")
+	assert.Equal(t, []int{6}, tm.matches)
+
+	tm.setQuery("e")
+	assert.Equal(t, []int{2, 3, 4, 6}, tm.matches)
+	require.True(t, tm.searchFrom(3))
+	assert.Equal(t, 3, tm.cursor)
+	assert.Equal(t, 2, tm.matchPosition())
+	tm.nextMatch()
+	assert.Equal(t, 4, tm.cursor)
+	tm.nextMatch()
+	tm.nextMatch()
+	assert.Equal(t, 2, tm.cursor, "next should wrap to the first match")
+	tm.prevMatch()
+	assert.Equal(t, 6, tm.cursor, "previous should wrap to the last match")
+
+	tm.setQuery("nothing")
+	assert.Empty(t, tm.matches)
+	assert.False(t, tm.searchFrom(0))
+	assert.Equal(t, 6, tm.cursor)
+}
+
+func TestBreakingNavigation(t *testing.T) {
+	root := makeTestTree()
+	tm := newTreeModel(root, 20)
+
+	// the only breaking leaf is the deprecated removal at index 2
+	assert.Equal(t, 2, tm.nextBreaking(-1))
+	assert.Equal(t, -1, tm.nextBreaking(2))
+	assert.Equal(t, 2, tm.prevBreaking(len(tm.entries)))
+	assert.Equal(t, -1, tm.prevBreaking(2))
+
+	pos, total := tm.breakingPosition()
+	assert.Equal(t, 1, pos)
+	assert.Equal(t, 1, total)
+}